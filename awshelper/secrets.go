@@ -0,0 +1,45 @@
+package awshelper
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+const secretsManagerClientContextKey ctxKey = iota
+
+type ctxKey byte
+
+// ContextWithSecretsManagerClient returns a new context with the given Secrets Manager client, which is then used by
+// `GetSecretString` instead of a client created from the AWS session. This is mostly useful to mock Secrets Manager in tests.
+func ContextWithSecretsManagerClient(ctx context.Context, client secretsmanageriface.SecretsManagerAPI) context.Context {
+	return context.WithValue(ctx, secretsManagerClientContextKey, client)
+}
+
+// GetSecretString returns the string value of the secret with the given ID, either its name or its ARN, from AWS Secrets Manager.
+func GetSecretString(ctx context.Context, config *AwsSessionConfig, terragruntOptions *options.TerragruntOptions, secretID string) (string, error) {
+	client, ok := ctx.Value(secretsManagerClientContextKey).(secretsmanageriface.SecretsManagerAPI)
+	if !ok {
+		sess, err := CreateAwsSession(config, terragruntOptions)
+		if err != nil {
+			return "", errors.New(err)
+		}
+
+		client = secretsmanager.New(sess)
+	}
+
+	output, err := client.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(secretID)})
+	if err != nil {
+		return "", errors.Errorf("error reading the secret %s from AWS Secrets Manager: %w", secretID, err)
+	}
+
+	if output.SecretString == nil {
+		return "", errors.Errorf("the secret %s has no string value, binary secrets are not supported", secretID)
+	}
+
+	return *output.SecretString, nil
+}
@@ -20,7 +20,9 @@ import (
 	"github.com/gruntwork-io/terragrunt/cli/commands/graph"
 	"github.com/gruntwork-io/terragrunt/cli/commands/hclvalidate"
 
+	"github.com/gruntwork-io/terragrunt/cli/commands/backend"
 	"github.com/gruntwork-io/terragrunt/cli/commands/scaffold"
+	"github.com/gruntwork-io/terragrunt/cli/commands/source"
 
 	"github.com/gruntwork-io/terragrunt/shell"
 
@@ -34,9 +36,16 @@ import (
 	"github.com/gruntwork-io/terragrunt/cli/commands"
 	awsproviderpatch "github.com/gruntwork-io/terragrunt/cli/commands/aws-provider-patch"
 	"github.com/gruntwork-io/terragrunt/cli/commands/catalog"
+	codegenCmd "github.com/gruntwork-io/terragrunt/cli/commands/codegen"
+	"github.com/gruntwork-io/terragrunt/cli/commands/doctor"
 	graphdependencies "github.com/gruntwork-io/terragrunt/cli/commands/graph-dependencies"
+	"github.com/gruntwork-io/terragrunt/cli/commands/hcldiff"
 	"github.com/gruntwork-io/terragrunt/cli/commands/hclfmt"
+	"github.com/gruntwork-io/terragrunt/cli/commands/hclfunctions"
+	"github.com/gruntwork-io/terragrunt/cli/commands/hclincludes"
 	outputmodulegroups "github.com/gruntwork-io/terragrunt/cli/commands/output-module-groups"
+	providercache "github.com/gruntwork-io/terragrunt/cli/commands/provider-cache"
+	renderinputs "github.com/gruntwork-io/terragrunt/cli/commands/render-inputs"
 	renderjson "github.com/gruntwork-io/terragrunt/cli/commands/render-json"
 	runall "github.com/gruntwork-io/terragrunt/cli/commands/run-all"
 	terraformCmd "github.com/gruntwork-io/terragrunt/cli/commands/terraform"
@@ -150,12 +159,21 @@ func TerragruntCommands(opts *options.TerragruntOptions) cli.Commands {
 		graphdependencies.NewCommand(opts),  // graph-dependencies
 		hclfmt.NewCommand(opts),             // hclfmt
 		renderjson.NewCommand(opts),         // render-json
+		renderinputs.NewCommand(opts),       // render-inputs
 		awsproviderpatch.NewCommand(opts),   // aws-provider-patch
 		outputmodulegroups.NewCommand(opts), // output-module-groups
 		catalog.NewCommand(opts),            // catalog
 		scaffold.NewCommand(opts),           // scaffold
 		graph.NewCommand(opts),              // graph
 		hclvalidate.NewCommand(opts),        // hclvalidate
+		hclfunctions.NewCommand(opts),       // hclfunctions
+		hclincludes.NewCommand(opts),        // hclincludes
+		hcldiff.NewCommand(opts),            // hcldiff
+		source.NewCommand(opts),             // source
+		providercache.NewCommand(opts),      // provider-cache
+		codegenCmd.NewCommand(opts),         // codegen
+		backend.NewCommand(opts),            // backend
+		doctor.NewCommand(opts),             // doctor
 	}
 
 	sort.Sort(cmds)
@@ -285,6 +303,19 @@ func initialSetup(cliCtx *cli.Context, opts *options.TerragruntOptions) error {
 		opts.WorkingDir = currentDir
 	}
 
+	// Use the directory of the nearest config file in the parent directories as the working dir.
+	if opts.ConfigSearchUp && cliCtx.Command.Name == terraformCmd.CommandName && !filepath.IsAbs(opts.TerragruntConfigPath) {
+		configPath, err := config.FindConfigInParentDirs(opts.WorkingDir, opts.TerragruntConfigPath)
+		if err != nil {
+			return err
+		}
+
+		if configPath != "" {
+			opts.Logger.Debugf("Using the directory of the nearest config %s as the working dir", configPath)
+			opts.WorkingDir = filepath.Dir(configPath)
+		}
+	}
+
 	opts.WorkingDir = filepath.ToSlash(opts.WorkingDir)
 
 	workingDir, err := filepath.Abs(opts.WorkingDir)
@@ -335,6 +366,26 @@ func initialSetup(cliCtx *cli.Context, opts *options.TerragruntOptions) error {
 		return err
 	}
 
+	if opts.IncludesFile != "" {
+		includesFile, err := util.CanonicalPath(opts.IncludesFile, opts.WorkingDir)
+		if err != nil {
+			return err
+		}
+
+		if !util.IsFile(includesFile) {
+			return errors.Errorf("the includes file %s does not exist", opts.IncludesFile)
+		}
+
+		includeDirs, err := util.GetDirsFromFile(opts.WorkingDir, includesFile)
+		if err != nil {
+			return err
+		}
+
+		opts.Logger.Debugf("Includes file set. Excluding by default.")
+		opts.ExcludeByDefault = true
+		opts.IncludeDirs = append(opts.IncludeDirs, includeDirs...)
+	}
+
 	if len(opts.IncludeDirs) > 0 {
 		opts.Logger.Debugf("Included directories set. Excluding by default.")
 		opts.ExcludeByDefault = true
@@ -360,7 +411,7 @@ func initialSetup(cliCtx *cli.Context, opts *options.TerragruntOptions) error {
 		return err
 	}
 
-	excludeDirs, err := util.GetExcludeDirsFromFile(opts.WorkingDir, opts.ExcludesFile)
+	excludeDirs, err := util.GetDirsFromFile(opts.WorkingDir, opts.ExcludesFile)
 	if err != nil {
 		return err
 	}
@@ -342,6 +342,61 @@ func TestParseMultiStringArg(t *testing.T) {
 	}
 }
 
+func TestQuietOverridesLogLevel(t *testing.T) {
+	t.Parallel()
+
+	quietFlag := doubleDashed(commands.TerragruntQuietFlagName)
+	logLevelFlag := doubleDashed(commands.TerragruntLogLevelFlagName)
+
+	testCases := [][]string{
+		{"plan", quietFlag, logLevelFlag, "debug"},
+		{"plan", logLevelFlag, "debug", quietFlag},
+	}
+
+	for _, args := range testCases {
+		var output bytes.Buffer
+
+		opts := options.NewTerragruntOptions()
+		opts.Logger = log.New(log.WithOutput(&output), log.WithLevel(log.InfoLevel), log.WithFormatter(opts.LogFormatter))
+
+		actualOptions, err := runAppTest(args, opts)
+		require.NoError(t, err)
+		assert.Equal(t, log.WarnLevel, actualOptions.LogLevel, "For args %q", args)
+
+		actualOptions.Logger.Infof("info message")
+		actualOptions.Logger.Warnf("warn message")
+		assert.NotContains(t, output.String(), "info message", "For args %q", args)
+		assert.Contains(t, output.String(), "warn message", "For args %q", args)
+	}
+
+	// the result doesn't depend on the order the actions of the flags run in
+	for _, quietFirst := range []bool{true, false} {
+		opts := options.NewTerragruntOptions()
+		opts.Quiet = true
+
+		flags := commands.NewGlobalFlags(opts)
+
+		quiet, ok := flags.Get(commands.TerragruntQuietFlagName).(*cliPkg.BoolFlag)
+		require.True(t, ok)
+
+		logLevel, ok := flags.Get(commands.TerragruntLogLevelFlagName).(*cliPkg.GenericFlag[string])
+		require.True(t, ok)
+
+		runQuiet := func() { require.NoError(t, quiet.Action(nil, true)) }
+		runLogLevel := func() { require.NoError(t, logLevel.Action(nil, "debug")) }
+
+		if quietFirst {
+			runQuiet()
+			runLogLevel()
+		} else {
+			runLogLevel()
+			runQuiet()
+		}
+
+		assert.Equal(t, log.WarnLevel, opts.LogLevel, "quiet first: %t", quietFirst)
+	}
+}
+
 func TestParseMutliStringKeyValueArg(t *testing.T) {
 	t.Parallel()
 
@@ -3,6 +3,7 @@ package awsproviderpatch
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -32,11 +33,20 @@ func runAwsProviderPatch(ctx context.Context, opts *options.TerragruntOptions, c
 		return errors.New(MissingOverrideAttrError(FlagNameTerragruntOverrideAttr))
 	}
 
+	return PatchModules(opts)
+}
+
+// PatchModules patches the provider blocks in the code of the modules downloaded into the .terraform/modules folder. If
+// AwsProviderPatchDryRun is set, the files are left untouched: the diff of each file that would be patched is printed
+// instead, and an error is returned if there is any.
+func PatchModules(opts *options.TerragruntOptions) error {
 	terraformFilesInModules, err := findAllTerraformFilesInModules(opts)
 	if err != nil {
 		return err
 	}
 
+	var filesToPatch []string
+
 	for _, terraformFile := range terraformFilesInModules {
 		opts.Logger.Debugf("Looking at file %s", terraformFile)
 
@@ -45,20 +55,51 @@ func runAwsProviderPatch(ctx context.Context, opts *options.TerragruntOptions, c
 			return err
 		}
 
-		updatedTerraformFileContents, codeWasUpdated, err := PatchAwsProviderInTerraformCode(originalTerraformFileContents, terraformFile, opts.AwsProviderPatchOverrides)
+		updatedTerraformFileContents, codeWasUpdated, err := PatchProviderInTerraformCode(originalTerraformFileContents, terraformFile, opts.AwsProviderPatchProviderName, opts.AwsProviderPatchOverrides)
 		if err != nil {
 			return err
 		}
 
-		if codeWasUpdated {
-			opts.Logger.Debugf("Patching AWS provider in %s", terraformFile)
+		if !codeWasUpdated {
+			continue
+		}
 
-			if err := util.WriteFileWithSamePermissions(terraformFile, terraformFile, []byte(updatedTerraformFileContents)); err != nil {
+		if opts.AwsProviderPatchDryRun {
+			relPath, err := util.GetPathRelativeTo(terraformFile, opts.WorkingDir)
+			if err != nil {
 				return err
 			}
+
+			diff, err := util.BytesDiff(opts.Logger, []byte(originalTerraformFileContents), []byte(updatedTerraformFileContents), relPath)
+			if err != nil {
+				return err
+			}
+
+			if _, err := fmt.Fprintf(opts.Writer, "%s\n", diff); err != nil {
+				return errors.New(err)
+			}
+
+			filesToPatch = append(filesToPatch, relPath)
+
+			continue
+		}
+
+		providerName := opts.AwsProviderPatchProviderName
+		if providerName == "aws" {
+			providerName = "AWS"
+		}
+
+		opts.Logger.Debugf("Patching %s provider in %s", providerName, terraformFile)
+
+		if err := util.WriteFileWithSamePermissions(terraformFile, terraformFile, []byte(updatedTerraformFileContents)); err != nil {
+			return err
 		}
 	}
 
+	if len(filesToPatch) > 0 {
+		return errors.New(PatchNeededError(filesToPatch))
+	}
+
 	return nil
 }
 
@@ -128,8 +169,14 @@ func findAllTerraformFilesInModules(opts *options.TerragruntOptions) ([]string,
 }
 
 // PatchAwsProviderInTerraformCode looks for provider "aws" { ... } blocks in the given Terraform code and overwrites
-// the attributes in those provider blocks with the given attributes. It returns the new Terraform code and a boolean
-// true if that code was updated.
+// the attributes in those provider blocks with the given attributes. See PatchProviderInTerraformCode.
+func PatchAwsProviderInTerraformCode(terraformCode string, terraformFilePath string, attributesToOverride map[string]string) (string, bool, error) {
+	return PatchProviderInTerraformCode(terraformCode, terraformFilePath, "aws", attributesToOverride)
+}
+
+// PatchProviderInTerraformCode looks for provider "<providerName>" { ... } blocks in the given Terraform code and
+// overwrites the attributes in those provider blocks with the given attributes. It returns the new Terraform code and a
+// boolean true if that code was updated.
 //
 // For example, if you passed in the following Terraform code:
 //
@@ -146,7 +193,7 @@ func findAllTerraformFilesInModules(opts *options.TerragruntOptions) ([]string,
 // This is a temporary workaround for a Terraform bug (https://github.com/hashicorp/terraform/issues/13018) where
 // any dynamic values in nested provider blocks are not handled correctly when you call 'terraform import', so by
 // temporarily hard-coding them, we can allow 'import' to work.
-func PatchAwsProviderInTerraformCode(terraformCode string, terraformFilePath string, attributesToOverride map[string]string) (string, bool, error) {
+func PatchProviderInTerraformCode(terraformCode string, terraformFilePath string, providerName string, attributesToOverride map[string]string) (string, bool, error) {
 	if len(attributesToOverride) == 0 {
 		return terraformCode, false, nil
 	}
@@ -159,7 +206,7 @@ func PatchAwsProviderInTerraformCode(terraformCode string, terraformFilePath str
 	codeWasUpdated := false
 
 	for _, block := range hclFile.Body().Blocks() {
-		if block.Type() == "provider" && len(block.Labels()) == 1 && block.Labels()[0] == "aws" {
+		if block.Type() == "provider" && len(block.Labels()) == 1 && block.Labels()[0] == providerName {
 			for key, value := range attributesToOverride {
 				attributeOverridden, err := overrideAttributeInBlock(block, key, value)
 				if err != nil {
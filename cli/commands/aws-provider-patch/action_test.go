@@ -1,9 +1,13 @@
 package awsproviderpatch_test
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 
 	awsproviderpatch "github.com/gruntwork-io/terragrunt/cli/commands/aws-provider-patch"
+	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -26,6 +30,18 @@ output "hello" {
 }
 `
 
+const terraformCodeExampleGcpProviderProjectOverriddenExpected = `
+provider "google" {
+  credentials = file("account.json")
+  project     = "my-other-project-id"
+  region      = "us-central1"
+}
+
+output "hello" {
+  value = "Hello, World"
+}
+`
+
 const terraformCodeExampleAwsProviderEmptyOriginal = `
 provider "aws" {
 }
@@ -332,3 +348,94 @@ func TestPatchAwsProviderInTerraformCodeHappyPath(t *testing.T) {
 		})
 	}
 }
+
+func TestPatchProviderInTerraformCode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName               string
+		originalTerraformCode  string
+		providerName           string
+		attributesToOverride   map[string]string
+		expectedCodeWasUpdated bool
+		expectedTerraformCode  string
+	}{
+		{"google provider, with project override", terraformCodeExampleGcpProvider, "google", map[string]string{"project": `"my-other-project-id"`}, true, terraformCodeExampleGcpProviderProjectOverriddenExpected},
+		{"google provider, with non-matching override", terraformCodeExampleGcpProvider, "google", map[string]string{"zone": `"us-central1-a"`}, false, terraformCodeExampleGcpProvider},
+		{"google provider, with aws provider name", terraformCodeExampleGcpProvider, "aws", map[string]string{"project": `"my-other-project-id"`}, false, terraformCodeExampleGcpProvider},
+		{"aws provider, with google provider name", terraformCodeExampleAwsProviderNonEmptyOriginal, "google", map[string]string{"region": `"eu-west-1"`}, false, terraformCodeExampleAwsProviderNonEmptyOriginal},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+			actualTerraformCode, actualCodeWasUpdated, err := awsproviderpatch.PatchProviderInTerraformCode(testCase.originalTerraformCode, "test.tf", testCase.providerName, testCase.attributesToOverride)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedCodeWasUpdated, actualCodeWasUpdated)
+			assert.Equal(t, testCase.expectedTerraformCode, actualTerraformCode)
+		})
+	}
+}
+
+func TestPatchModulesDryRun(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+	moduleDir := filepath.Join(workingDir, ".terraform", "modules", "example")
+	require.NoError(t, os.MkdirAll(moduleDir, os.ModePerm))
+
+	modulesJSON := `{"Modules":[{"Key":"","Source":"","Dir":"."},{"Key":"example","Source":"./example","Dir":".terraform/modules/example"}]}`
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, ".terraform", "modules", "modules.json"), []byte(modulesJSON), 0644))
+
+	moduleFile := filepath.Join(moduleDir, "main.tf")
+	require.NoError(t, os.WriteFile(moduleFile, []byte(terraformCodeExampleGcpProvider), 0644))
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	var stdout bytes.Buffer
+
+	opts.WorkingDir = workingDir
+	opts.Writer = &stdout
+	opts.AwsProviderPatchProviderName = "google"
+	opts.AwsProviderPatchOverrides = map[string]string{"project": `"my-other-project-id"`}
+	opts.AwsProviderPatchDryRun = true
+
+	err = awsproviderpatch.PatchModules(opts)
+	require.Error(t, err)
+
+	var patchNeeded awsproviderpatch.PatchNeededError
+	require.ErrorAs(t, err, &patchNeeded)
+	assert.Equal(t, awsproviderpatch.PatchNeededError{".terraform/modules/example/main.tf"}, patchNeeded)
+
+	expectedDiff := `--- old/.terraform/modules/example/main.tf
++++ new/.terraform/modules/example/main.tf
+@@ -1,7 +1,7 @@
+ 
+ provider "google" {
+   credentials = file("account.json")
+-  project     = "my-project-id"
++  project     = "my-other-project-id"
+   region      = "us-central1"
+ }
+ 
+`
+	assert.Equal(t, expectedDiff+"\n", stdout.String())
+
+	// the dry run leaves the files untouched
+	contents, err := os.ReadFile(moduleFile)
+	require.NoError(t, err)
+	assert.Equal(t, terraformCodeExampleGcpProvider, string(contents))
+
+	// without dry run, the files are patched
+	opts.AwsProviderPatchDryRun = false
+	stdout.Reset()
+
+	require.NoError(t, awsproviderpatch.PatchModules(opts))
+	assert.Empty(t, stdout.String())
+
+	contents, err = os.ReadFile(moduleFile)
+	require.NoError(t, err)
+	assert.Equal(t, terraformCodeExampleGcpProviderProjectOverriddenExpected, string(contents))
+}
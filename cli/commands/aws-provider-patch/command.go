@@ -2,7 +2,8 @@
 //
 // The `aws-provider-patch` command finds all Terraform modules nested in the current code (i.e., in the .terraform/modules
 // folder), looks for provider "aws" { ... } blocks in those modules, and overwrites the attributes in those provider
-// blocks with the attributes specified in terragrntOptions.
+// blocks with the attributes specified in terragrntOptions. The blocks of another provider, such as provider "google",
+// can be patched instead by setting AwsProviderPatchProviderName.
 //
 // For example, if were running Terragrunt against code that contained a module:
 //
@@ -37,7 +38,9 @@ import (
 const (
 	CommandName = "aws-provider-patch"
 
-	FlagNameTerragruntOverrideAttr = "terragrunt-override-attr"
+	FlagNameTerragruntOverrideAttr     = "terragrunt-override-attr"
+	FlagNameTerragruntOverrideProvider = "terragrunt-override-provider"
+	FlagNameDryRun                     = "dry-run"
 )
 
 func NewFlags(opts *options.TerragruntOptions) cli.Flags {
@@ -48,6 +51,17 @@ func NewFlags(opts *options.TerragruntOptions) cli.Flags {
 			EnvVar:      "TERRAGRUNT_EXCLUDE_DIR",
 			Usage:       "A key=value attribute to override in a provider block as part of the aws-provider-patch command. May be specified multiple times.",
 		},
+		&cli.GenericFlag[string]{
+			Name:        FlagNameTerragruntOverrideProvider,
+			Destination: &opts.AwsProviderPatchProviderName,
+			EnvVar:      "TERRAGRUNT_OVERRIDE_PROVIDER",
+			Usage:       "The name of the provider whose blocks are patched as part of the aws-provider-patch command, e.g. google. Defaults to aws.",
+		},
+		&cli.BoolFlag{
+			Name:        FlagNameDryRun,
+			Destination: &opts.AwsProviderPatchDryRun,
+			Usage:       "Print the diff of the files that would be patched without updating them, and exit with an error if any.",
+		},
 	}
 }
 
@@ -1,6 +1,9 @@
 package awsproviderpatch
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type MissingOverrideAttrError string
 
@@ -27,3 +30,9 @@ func (err MalformedJSONValError) Error() string {
 	val := err.value
 	return fmt.Sprintf(`Error unmarshaling JSON string %s. This usually happens when the JSON string is malformed, or if the value is not properly quoted (e.g., "%s"). Underlying error: %s`, val, val, err.underlyingErr)
 }
+
+type PatchNeededError []string
+
+func (files PatchNeededError) Error() string {
+	return fmt.Sprintf("The dry run found %d files to patch: %s", len(files), strings.Join(files, ", "))
+}
@@ -0,0 +1,133 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// Collision is a state location shared by the remote states of several units.
+type Collision struct {
+	// Location identifies where the state is stored, e.g. `s3://bucket/key`.
+	Location string `json:"location"`
+	// Units are the paths of the units storing their state at the location, relative to the working directory.
+	Units []string `json:"units"`
+}
+
+// RunCheckCollisions prints the units of the working directory whose remote states are stored at the same location,
+// and returns an error if there are any.
+func RunCheckCollisions(ctx context.Context, opts *Options) error {
+	collisions, err := FindCollisions(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.CheckCollisionsJSON {
+		if collisions == nil {
+			collisions = []Collision{}
+		}
+
+		out, err := json.MarshalIndent(collisions, "", "  ")
+		if err != nil {
+			return errors.New(err)
+		}
+
+		if _, err := fmt.Fprintln(opts.Writer, string(out)); err != nil {
+			return errors.New(err)
+		}
+	} else {
+		for _, collision := range collisions {
+			if _, err := fmt.Fprintln(opts.Writer, collision.Location); err != nil {
+				return errors.New(err)
+			}
+
+			for _, unit := range collision.Units {
+				if _, err := fmt.Fprintf(opts.Writer, "  %s\n", unit); err != nil {
+					return errors.New(err)
+				}
+			}
+		}
+	}
+
+	if len(collisions) > 0 {
+		return errors.Errorf("found %d remote state locations shared by several units", len(collisions))
+	}
+
+	opts.Logger.Infof("No remote state locations shared by several units found in %s", opts.WorkingDir)
+
+	return nil
+}
+
+// FindCollisions returns the state locations shared by the remote states of several units in the working directory,
+// sorted by location. The units whose config can't be parsed are skipped with a warning, as are the units without a
+// remote state or whose state location depends on the unit directory or is only known at init time.
+func FindCollisions(ctx context.Context, opts *Options) ([]Collision, error) {
+	configPaths, err := config.FindConfigFilesInPath(opts.WorkingDir, opts.TerragruntOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	units := make(map[string][]string)
+
+	for _, configPath := range configPaths {
+		location, err := stateLocation(ctx, opts, configPath)
+		if err != nil {
+			opts.Logger.Warnf("Skipping %s, failed to parse its remote state: %v", configPath, err)
+			continue
+		}
+
+		if location == "" {
+			continue
+		}
+
+		unit := filepath.Dir(configPath)
+		if relPath, err := util.GetPathRelativeTo(unit, opts.WorkingDir); err == nil {
+			unit = relPath
+		}
+
+		units[location] = append(units[location], unit)
+	}
+
+	var collisions []Collision
+
+	for location, paths := range units {
+		if len(paths) < 2 { //nolint:mnd
+			continue
+		}
+
+		sort.Strings(paths)
+		collisions = append(collisions, Collision{Location: location, Units: paths})
+	}
+
+	sort.Slice(collisions, func(i, j int) bool { return collisions[i].Location < collisions[j].Location })
+
+	return collisions, nil
+}
+
+// stateLocation returns the location of the remote state of the unit with the given config, or an empty string if the
+// unit has no remote state or its location can't be compared with the others.
+func stateLocation(ctx context.Context, opts *Options, configPath string) (string, error) {
+	unitOpts, err := opts.Clone(configPath)
+	if err != nil {
+		return "", err
+	}
+
+	parsingCtx := config.NewParsingContext(ctx, unitOpts).WithDecodeList(config.RemoteStateBlock)
+
+	cfg, err := config.PartialParseConfigFile(parsingCtx, configPath, nil)
+	if err != nil {
+		return "", err
+	}
+
+	if cfg.RemoteState == nil {
+		return "", nil
+	}
+
+	return cfg.RemoteState.StateLocation(), nil
+}
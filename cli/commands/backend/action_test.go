@@ -0,0 +1,69 @@
+package backend_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/backend"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+func TestRunCheckCollisions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name               string
+		fixture            string
+		expectedCollisions []backend.Collision
+	}{
+		{
+			name:    "collision",
+			fixture: "collision",
+			expectedCollisions: []backend.Collision{
+				{Location: "s3://acme-terraform-state/app/terraform.tfstate", Units: []string{"app", "db"}},
+			},
+		},
+		{
+			name:               "clean",
+			fixture:            "clean",
+			expectedCollisions: []backend.Collision{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			workingDir, err := filepath.Abs(filepath.Join("testdata", "fixtures", tc.fixture))
+			require.NoError(t, err)
+
+			generalOpts, err := options.NewTerragruntOptionsForTest("")
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+
+			generalOpts.WorkingDir = workingDir
+			generalOpts.Writer = &out
+
+			opts := backend.NewOptions(generalOpts)
+			opts.CheckCollisionsJSON = true
+
+			err = backend.RunCheckCollisions(context.Background(), opts)
+			if len(tc.expectedCollisions) > 0 {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			var collisions []backend.Collision
+			require.NoError(t, json.Unmarshal(out.Bytes(), &collisions))
+			assert.Equal(t, tc.expectedCollisions, collisions)
+		})
+	}
+}
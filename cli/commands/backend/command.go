@@ -0,0 +1,58 @@
+// Package backend provides the `backend` command to inspect the remote state backends of the units.
+//
+// `backend check-collisions` finds the units in the working directory whose `remote_state` blocks resolve to the same
+// state location, e.g. the same bucket and key of the s3 backend, which usually comes from a copied config whose `key`
+// has not been updated. Such units overwrite each other's state, so they are reported and the command fails.
+package backend
+
+import (
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/cli"
+)
+
+const (
+	CommandName                = "backend"
+	CheckCollisionsCommandName = "check-collisions"
+
+	JSONFlagName = "json"
+)
+
+func NewCheckCollisionsFlags(opts *Options) cli.Flags {
+	return cli.Flags{
+		&cli.BoolFlag{
+			Name:        JSONFlagName,
+			Destination: &opts.CheckCollisionsJSON,
+			Usage:       "Print the collisions as JSON.",
+		},
+	}
+}
+
+func NewCommand(generalOpts *options.TerragruntOptions) *cli.Command {
+	opts := NewOptions(generalOpts)
+
+	return &cli.Command{
+		Name:  CommandName,
+		Usage: "Inspect the remote state backends of the units.",
+		Subcommands: cli.Commands{
+			newCheckCollisionsCommand(opts),
+		},
+		Action: func(ctx *cli.Context) error {
+			if name := ctx.Args().CommandName(); name != "" {
+				return errors.Errorf("unknown subcommand %q of the %s command", name, CommandName)
+			}
+
+			return errors.Errorf("the %s command requires a subcommand, e.g. `terragrunt %s %s`", CommandName, CommandName, CheckCollisionsCommandName)
+		},
+	}
+}
+
+func newCheckCollisionsCommand(opts *Options) *cli.Command {
+	return &cli.Command{
+		Name:                   CheckCollisionsCommandName,
+		Usage:                  "Report the units whose remote state is stored at the same location.",
+		DisallowUndefinedFlags: true,
+		Flags:                  NewCheckCollisionsFlags(opts).Sort(),
+		Action:                 func(ctx *cli.Context) error { return RunCheckCollisions(ctx, opts) },
+	}
+}
@@ -0,0 +1,16 @@
+package backend
+
+import "github.com/gruntwork-io/terragrunt/options"
+
+type Options struct {
+	*options.TerragruntOptions
+
+	// CheckCollisionsJSON prints the collisions found by the `check-collisions` subcommand as JSON.
+	CheckCollisionsJSON bool
+}
+
+func NewOptions(general *options.TerragruntOptions) *Options {
+	return &Options{
+		TerragruntOptions: general,
+	}
+}
@@ -0,0 +1,8 @@
+remote_state {
+  backend = "s3"
+  config = {
+    bucket = "acme-terraform-state"
+    key    = "app/terraform.tfstate"
+    region = "us-east-1"
+  }
+}
@@ -0,0 +1,6 @@
+remote_state {
+  backend = "local"
+  config = {
+    path = "terraform.tfstate"
+  }
+}
@@ -12,6 +12,7 @@ import (
 const (
 	mdExt   = ".md"
 	adocExt = ".adoc"
+	rstExt  = ".rst"
 
 	docTitle docDataKey = iota
 	docDescription
@@ -22,13 +23,17 @@ const (
 )
 
 var (
-	// `strings.EqualFold` is used (case insensitive) while comparing
-	docFiles = []string{"README.md", "README.adoc"}
+	// `strings.EqualFold` is used (case insensitive) while comparing, in order of priority
+	docFiles = []string{"README.md", "README.adoc", "README.rst"}
 
 	frontmatterKeys = map[string]docDataKey{
 		"name":        docTitle,
 		"description": docDescription,
 	}
+
+	// a period followed by whitespace and a capital letter, so that the periods of URLs, versions or abbreviations,
+	// such as `e.g.`, are not taken for the end of a sentence
+	sentenceEndReg = regexp.MustCompile(`\.\s+[A-Z]`)
 )
 
 type docDataKey byte
@@ -53,7 +58,8 @@ type Doc struct {
 	tagStripRegs DocRegs
 
 	frontmatterCache map[docDataKey]string
-	frontmatterReg   *regexp.Regexp
+	// in order of priority
+	frontmatterRegs DocRegs
 }
 
 func NewDoc(rawContent, fileExt string) *Doc {
@@ -61,8 +67,13 @@ func NewDoc(rawContent, fileExt string) *Doc {
 		rawContent: rawContent,
 		fileExt:    fileExt,
 
-		tagRegs:        make(map[docTagName]*regexp.Regexp),
-		frontmatterReg: regexp.MustCompile(`(?i)^[\s\n]*<!-- frontmatter[\s\n]*([\S\s]*?)[\s\n]*-->`),
+		tagRegs: make(map[docTagName]*regexp.Regexp),
+		frontmatterRegs: DocRegs{
+			// html comment, ex. <!-- frontmatter ... -->
+			regexp.MustCompile(`(?i)^[\s\n]*<!-- frontmatter[\s\n]*([\S\s]*?)[\s\n]*-->`),
+			// yaml, ex. ---\n ... \n---
+			regexp.MustCompile(`^[\s\n]*---[ \t]*\r?\n([\S\s]*?)\r?\n---[ \t]*(?:\r?\n|$)`),
+		},
 	}
 
 	switch fileExt {
@@ -128,13 +139,45 @@ func NewDoc(rawContent, fileExt string) *Doc {
 			// multiple line break
 			regexp.MustCompile(`((?:\r\n?|\n){2})(?:\r\n?|\n)*`),
 		}
+
+	case rstExt:
+		// section titles are underlined, and optionally overlined, with `=` for H1 and `-` for H2, the H2 title itself
+		// is left out as only its body is used
+		doc.tagRegs[tagH1Block] = regexp.MustCompile(`(?:^|\n)(?:={3,}\r?\n)?([^\s=][^\r\n]*\r?\n={3,}[\S\s]+?)(?:[\r\n]+[^\r\n]+\r?\n[=\-]{3,}(?:[\r\n]|$)|[\r\n]*$)`)
+		doc.tagRegs[tagH2Block] = regexp.MustCompile(`(?:^|\n)(?:-{3,}\r?\n)?[^\s\-][^\r\n]*\r?\n-{3,}([\S\s]+?)(?:[\r\n]+[^\r\n]+\r?\n[=\-]{3,}(?:[\r\n]|$)|[\r\n]*$)`)
+		doc.tagStripRegs = DocRegs{
+			// directive, comment, hyperlink target, ex. .. image:: url
+			regexp.MustCompile(`(?m)^\.\.\s.*$`),
+			// directive option, ex. :alt: text
+			regexp.MustCompile(`(?m)^[ \t]+:[-\w]+:.*$`),
+			// substitution reference, ex. |badge|
+			regexp.MustCompile(`\|[^|\s][^|]*\|_{0,2}`),
+			// role, ex. :ref:`text <target>`
+			regexp.MustCompile(":[-\\w]+:`([^`<]+?)\\s*(?:<[^>]*>)?`"),
+			// link, ex. `text <url>`_
+			regexp.MustCompile("`([^`<]+?)\\s*(?:<[^>]*>)?`_{1,2}"),
+			// code
+			regexp.MustCompile("``([^`]+)``"),
+			regexp.MustCompile("`([^`]+)`"),
+			// bold
+			regexp.MustCompile(`\*\*([^*]+)\*\*`),
+			// italic
+			regexp.MustCompile(`\*([^*]+)\*`),
+			// section title underline and overline
+			regexp.MustCompile(`(?m)^[=\-~^"'#*+]{3,}\s*$`),
+			// multiple line break
+			regexp.MustCompile(`((?:\r\n?|\n){2})(?:\r\n?|\n)*`),
+		}
 	}
 
 	return doc
 }
 
 func FindDoc(dir string) (*Doc, error) {
-	var filePath, fileExt string
+	var (
+		filePath, fileExt string
+		priority          = len(docFiles)
+	)
 
 	files, err := os.ReadDir(dir)
 	if err != nil {
@@ -146,19 +189,16 @@ func FindDoc(dir string) (*Doc, error) {
 			continue
 		}
 
-		for _, readmeFile := range docFiles {
+		// `md` files have priority over `adoc` files, which have priority over `rst` files
+		for i, readmeFile := range docFiles[:priority] {
 			if strings.EqualFold(readmeFile, file.Name()) {
 				filePath = filepath.Join(dir, file.Name())
-				fileExt = filepath.Ext(filePath)
+				fileExt = strings.ToLower(filepath.Ext(filePath))
+				priority = i
 
 				break
 			}
 		}
-
-		// `md` files have priority over `adoc` files
-		if strings.EqualFold(fileExt, mdExt) {
-			break
-		}
 	}
 
 	if filePath == "" {
@@ -190,29 +230,27 @@ func (doc *Doc) Description(maxLenght int) string {
 		desc = doc.parseTag(docDescription)
 	}
 
-	if maxLenght == 0 {
+	if maxLenght == 0 || len(desc) <= maxLenght {
 		return desc
 	}
 
-	var (
-		sentences = strings.Split(desc, ".")
-		symbols   int
-	)
-
-	for i, sentence := range sentences {
-		symbols += len(sentence)
+	// truncate the description at the end of the last sentence that fits, or of the first sentence
+	cut := len(desc)
 
-		if symbols > maxLenght {
-			if i == 0 {
-				desc = sentence
-			} else {
-				desc = strings.Join(sentences[:i], ".")
-			}
-
-			desc += "."
+	for i, loc := range sentenceEndReg.FindAllStringIndex(desc, -1) {
+		end := loc[0] + 1
 
+		if i > 0 && end > maxLenght {
 			break
 		}
+
+		cut = end
+	}
+
+	desc = desc[:cut]
+
+	if !strings.HasSuffix(desc, ".") {
+		desc += "."
 	}
 
 	return desc
@@ -231,15 +269,23 @@ func (doc *Doc) IsMarkDown() bool {
 }
 
 // parseFrontmatter parses Markdown files with frontmatter, which we use as the preferred title/description source.
+// Both the `<!-- frontmatter ... -->` HTML comment and the `---` fenced YAML forms are supported, the former has priority.
 func (doc *Doc) parseFrontmatter(key docDataKey) string {
-	if doc.frontmatterReg == nil {
+	if doc.frontmatterRegs == nil {
 		return ""
 	}
 
 	if doc.frontmatterCache == nil {
 		doc.frontmatterCache = make(map[docDataKey]string)
 
-		match := doc.frontmatterReg.FindStringSubmatch(doc.rawContent)
+		var match []string
+
+		for _, reg := range doc.frontmatterRegs {
+			if match = reg.FindStringSubmatch(doc.rawContent); len(match) > 0 {
+				break
+			}
+		}
+
 		if len(match) == 0 {
 			return ""
 		}
@@ -247,9 +293,9 @@ func (doc *Doc) parseFrontmatter(key docDataKey) string {
 		lines := strings.Split(match[1], "\n")
 
 		for _, line := range lines {
-			if parts := strings.Split(line, ":"); len(parts) > 1 {
+			if parts := strings.SplitN(line, ":", 2); len(parts) > 1 {
 				key := strings.ToLower(strings.TrimSpace(parts[0]))
-				val := strings.TrimSpace(parts[1])
+				val := unquote(strings.TrimSpace(parts[1]))
 
 				if key, ok := frontmatterKeys[key]; ok {
 					doc.frontmatterCache[key] = val
@@ -261,7 +307,16 @@ func (doc *Doc) parseFrontmatter(key docDataKey) string {
 	return doc.frontmatterCache[key]
 }
 
-// parseTag parses Markdown/AsciiDoc files, stips tags and extracts the H1 header as the title and the H1+H2 bodies as the description.
+// unquote removes the quotes around a YAML string value, if any.
+func unquote(val string) string {
+	if len(val) > 1 && (val[0] == '"' || val[0] == '\'') && val[len(val)-1] == val[0] {
+		return val[1 : len(val)-1]
+	}
+
+	return val
+}
+
+// parseTag parses Markdown/AsciiDoc/reStructuredText files, stips tags and extracts the H1 header as the title and the H1+H2 bodies as the description.
 func (doc *Doc) parseTag(key docDataKey) string {
 	if doc.tagRegs == nil {
 		return ""
@@ -43,6 +43,30 @@ built-with: terraform
 [![Maintained by Gruntwork](https://img.shields.io/badge/maintained%20by-gruntwork.io-%235849a6.svg)](https://gruntwork.io)
 `
 
+var testYAMLFrontmatterEksCluster = `---
+type: service
+name: "Amazon EKS Cluster"
+description: 'Deploy an Amazon EKS Cluster: the control plane and the worker nodes.'
+cloud: aws
+---
+
+# Amazon EKS Cluster
+
+[![Maintained by Gruntwork](https://img.shields.io/badge/maintained%20by-gruntwork.io-%235849a6.svg)](https://gruntwork.io)
+`
+
+var testBothFrontmattersEcsService = `
+<!-- Frontmatter
+name: Amazon ECS Service
+description: Deploy an Amazon ECS Service.
+-->
+---
+name: ECS Service
+description: Deploy an ECS Service.
+---
+# Amazon ECS Service
+`
+
 func TestFrontmatter(t *testing.T) {
 	t.Parallel()
 
@@ -61,6 +85,16 @@ func TestFrontmatter(t *testing.T) {
 			"Auto Scaling Group (ASG)",
 			"Deploy an AMI across an Auto Scaling Group (ASG), with support for zero-downtime, rolling deployment, load balancing, health checks, service discovery, and auto scaling.",
 		},
+		{
+			testYAMLFrontmatterEksCluster,
+			"Amazon EKS Cluster",
+			"Deploy an Amazon EKS Cluster: the control plane and the worker nodes.",
+		},
+		{
+			testBothFrontmattersEcsService,
+			"Amazon ECS Service",
+			"Deploy an Amazon ECS Service.",
+		},
 	}
 
 	for i, testCase := range testCases {
@@ -178,6 +212,35 @@ toc::[]
 * Track automatically generated ` + "`aws-auth`" + ` source ` + "`ConfigMaps`" + ` that are generated by EKS.
 `
 
+var testH1EksK8sClusterAutoscaler = `
+.. image:: https://img.shields.io/badge/maintained%20by-gruntwork.io-%235849a6.svg
+   :target: https://gruntwork.io/?ref=repo_aws_eks
+   :alt: Maintained by Gruntwork
+
+|tf-version|
+
+=================================
+K8S Cluster Autoscaler IAM Policy
+=================================
+
+This Terraform Module defines an ` + "`IAM policy <https://docs.aws.amazon.com/IAM/latest/UserGuide/access_policies.html>`_" + `
+that defines the minimal set of permissions necessary for the ` + "``cluster-autoscaler``" + ` application. This policy
+can be attached to the EC2 instances of the worker nodes, or to an IAM role used with :ref:` + "`IRSA <irsa>`" + `.
+
+.. |tf-version| image:: https://img.shields.io/badge/tf-%3E%3D1.1.0-blue.svg
+
+Attaching IAM policy to workers
+-------------------------------
+
+To allow the ` + "``cluster-autoscaler``" + ` app to manage the Auto Scaling Groups, it needs IAM permissions.
+`
+
+var testH1NoFrontmatterVpc = `
+# VPC Module
+
+This module creates a VPC with public and private subnets.
+`
+
 func TestElement(t *testing.T) {
 	t.Parallel()
 
@@ -216,6 +279,20 @@ func TestElement(t *testing.T) {
 			"EKS AWS Auth Merger",
 			"This module contains a go CLI, docker container, and terraform module for deploying a Kubernetes controller for managing mappings between AWS IAM roles and users to RBAC groups in Kubernetes.",
 		},
+		{
+			testH1EksK8sClusterAutoscaler,
+			".rst",
+			200,
+			"K8S Cluster Autoscaler IAM Policy",
+			"This Terraform Module defines an IAM policy that defines the minimal set of permissions necessary for the cluster-autoscaler application.",
+		},
+		{
+			testH1NoFrontmatterVpc,
+			".md",
+			200,
+			"VPC Module",
+			"This module creates a VPC with public and private subnets.",
+		},
 	}
 
 	for i, testCase := range testCases {
@@ -232,3 +309,51 @@ func TestElement(t *testing.T) {
 	}
 
 }
+
+func TestDescriptionTruncation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name                 string
+		content              string
+		maxDescriptionLength int
+		expectedDescription  string
+	}{
+		{
+			"url",
+			"# VPC\nThis module creates a VPC, see https://docs.aws.amazon.com/vpc/v1.2/userguide for details. It also creates the subnets.",
+			100,
+			"This module creates a VPC, see https://docs.aws.amazon.com/vpc/v1.2/userguide for details.",
+		},
+		{
+			"abbreviation",
+			"# VPC\nThis module creates the network resources, i.e. the VPC and its subnets. It also creates the route tables and the NAT gateways.",
+			100,
+			"This module creates the network resources, i.e. the VPC and its subnets.",
+		},
+		{
+			"first sentence too long",
+			"# VPC\nThis module creates the network resources, e.g. the VPC and its subnets. It also creates the route tables.",
+			40,
+			"This module creates the network resources, e.g. the VPC and its subnets.",
+		},
+		{
+			"fits",
+			"# VPC\nThis module creates a VPC, e.g. for an EKS cluster.",
+			100,
+			"This module creates a VPC, e.g. for an EKS cluster.",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc := module.NewDoc(testCase.content, ".md")
+
+			assert.Equal(t, testCase.expectedDescription, doc.Description(testCase.maxDescriptionLength))
+		})
+	}
+}
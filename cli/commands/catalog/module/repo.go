@@ -90,7 +90,9 @@ func (repo *Repo) FindModules(ctx context.Context) (Modules, error) {
 
 		walkFunc := filepath.Walk
 		if repo.walkWithSymlinks {
-			walkFunc = util.WalkWithSymlinks
+			walkFunc = func(root string, fn filepath.WalkFunc) error {
+				return util.WalkWithSymlinks(repo.logger, root, fn)
+			}
 		}
 
 		err := walkFunc(modulesPath,
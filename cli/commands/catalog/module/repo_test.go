@@ -48,6 +48,12 @@ func TestFindModules(t *testing.T) {
 					description: "This module contains a go CLI, docker container, and terraform module for deploying a Kubernetes controller for managing mappings between AWS IAM roles and users to RBAC groups in Kubernetes.",
 					url:         "https://github.com/gruntwork-io/terraform-aws-eks/tree/master/modules/eks-aws-auth-merger",
 					moduleDir:   "modules/eks-aws-auth-merger",
+				},
+				{
+					title:       "K8S External DNS Module",
+					description: "This Terraform Module installs and configures External DNS on an EKS cluster, so that you can configure Route 53 records using Ingress and Service resources.",
+					url:         "https://github.com/gruntwork-io/terraform-aws-eks/tree/master/modules/eks-k8s-external-dns",
+					moduleDir:   "modules/eks-k8s-external-dns",
 				}},
 			nil,
 		},
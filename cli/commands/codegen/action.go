@@ -0,0 +1,112 @@
+package codegen
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/gruntwork-io/terragrunt/codegen"
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// RunClean removes the generated files of the unit in the given directory, or the working directory if unitDir is
+// empty, and prints the removed files.
+func RunClean(ctx context.Context, opts *Options, unitDir string) error {
+	if unitDir == "" {
+		unitDir = opts.WorkingDir
+	} else if !filepath.IsAbs(unitDir) {
+		unitDir = filepath.Join(opts.WorkingDir, unitDir)
+	}
+
+	if !util.IsDir(unitDir) {
+		return errors.Errorf("the unit directory %s does not exist", unitDir)
+	}
+
+	removed, err := Clean(ctx, opts, unitDir)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range removed {
+		if relPath, err := util.GetPathRelativeTo(path, opts.WorkingDir); err == nil {
+			path = relPath
+		}
+
+		if _, err := fmt.Fprintln(opts.Writer, path); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	if opts.CleanDryRun {
+		opts.Logger.Infof("%d generated files would be removed from %s", len(removed), unitDir)
+	} else {
+		opts.Logger.Infof("Removed %d generated files from %s", len(removed), unitDir)
+	}
+
+	return nil
+}
+
+// Clean removes the files bearing the Terragrunt signature that the config of the unit in the given directory
+// generates, or all the signed files of the unit if CleanAll is set, and returns the removed files.
+func Clean(ctx context.Context, opts *Options, unitDir string) ([]string, error) {
+	var (
+		paths []string
+		err   error
+	)
+
+	if opts.CleanAll {
+		paths, err = codegen.FindGeneratedFiles(unitDir)
+	} else {
+		paths, err = configGeneratedFiles(ctx, opts, unitDir)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return codegen.RemoveGeneratedFiles(opts.TerragruntOptions, paths, opts.CleanDryRun)
+}
+
+// configGeneratedFiles returns the paths of the files that the config of the unit generates with its `generate` blocks
+// and the `generate` attribute of its `remote_state` block.
+func configGeneratedFiles(ctx context.Context, opts *Options, unitDir string) ([]string, error) {
+	configPath := config.GetDefaultConfigPath(unitDir)
+	if !util.FileExists(configPath) {
+		return nil, errors.Errorf("no Terragrunt config found in %s, use --%s to remove all the generated files of the directory", unitDir, AllFlagName)
+	}
+
+	unitOpts, err := opts.Clone(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	parsingCtx := config.NewParsingContext(ctx, unitOpts).WithDecodeList(config.GenerateBlock, config.RemoteStateBlock)
+
+	cfg, err := config.PartialParseConfigFile(parsingCtx, configPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+
+	for _, genConfig := range cfg.GenerateConfigs {
+		paths = append(paths, genConfig.Path)
+	}
+
+	if cfg.RemoteState != nil && cfg.RemoteState.Generate != nil {
+		paths = append(paths, cfg.RemoteState.Generate.Path)
+	}
+
+	for i, path := range paths {
+		if !filepath.IsAbs(path) {
+			paths[i] = filepath.Join(unitDir, path)
+		}
+	}
+
+	sort.Strings(paths)
+
+	return paths, nil
+}
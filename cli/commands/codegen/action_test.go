@@ -0,0 +1,134 @@
+package codegen_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/codegen"
+	tgcodegen "github.com/gruntwork-io/terragrunt/codegen"
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `
+generate "provider" {
+  path      = "provider.tf"
+  if_exists = "overwrite_terragrunt"
+  contents  = "provider \"aws\" {}"
+}
+
+generate "versions" {
+  path      = "nested/versions.tf"
+  if_exists = "overwrite_terragrunt"
+  contents  = "terraform {}"
+}
+`
+
+func generateUnit(t *testing.T) string {
+	t.Helper()
+
+	unitDir := t.TempDir()
+
+	configPath := filepath.Join(unitDir, config.DefaultTerragruntConfigPath)
+	require.NoError(t, os.WriteFile(configPath, []byte(testConfig), 0644))
+
+	opts, err := options.NewTerragruntOptionsForTest(configPath)
+	require.NoError(t, err)
+
+	cfg, err := config.ReadTerragruntConfig(context.Background(), opts, config.DefaultParserOptions(opts))
+	require.NoError(t, err)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(unitDir, "nested"), os.ModePerm))
+
+	for _, genConfig := range cfg.GenerateConfigs {
+		require.NoError(t, tgcodegen.WriteToFile(opts, unitDir, genConfig))
+	}
+
+	files := map[string]string{
+		// Signed file that is no longer generated by the config, e.g. its generate block was removed.
+		"backend.tf": "# Generated by Terragrunt. Sig: nIlQXj57tbuaRZEa\nterraform {}\n",
+		// Unsigned files written by hand.
+		"main.tf":                 "resource \"null_resource\" \"this\" {}\n",
+		"nested/variables.tf":     "variable \"name\" {}\n",
+		".terraform/generated.tf": "# Generated by Terragrunt. Sig: nIlQXj57tbuaRZEa\n",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(unitDir, filepath.FromSlash(name))
+
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	return unitDir
+}
+
+func TestClean(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		all       bool
+		dryRun    bool
+		removed   []string
+		preserved []string
+	}{
+		{
+			name:      "files generated by the config",
+			removed:   []string{"nested/versions.tf", "provider.tf"},
+			preserved: []string{"backend.tf", "main.tf", "nested/variables.tf", ".terraform/generated.tf", "terragrunt.hcl"},
+		},
+		{
+			name:      "all signed files",
+			all:       true,
+			removed:   []string{"backend.tf", "nested/versions.tf", "provider.tf"},
+			preserved: []string{"main.tf", "nested/variables.tf", ".terraform/generated.tf", "terragrunt.hcl"},
+		},
+		{
+			name:      "dry run",
+			all:       true,
+			dryRun:    true,
+			removed:   []string{"backend.tf", "nested/versions.tf", "provider.tf"},
+			preserved: []string{"backend.tf", "nested/versions.tf", "provider.tf", "main.tf"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			unitDir := generateUnit(t)
+
+			generalOpts, err := options.NewTerragruntOptionsForTest(filepath.Join(unitDir, config.DefaultTerragruntConfigPath))
+			require.NoError(t, err)
+
+			opts := codegen.NewOptions(generalOpts)
+			opts.CleanAll = tc.all
+			opts.CleanDryRun = tc.dryRun
+
+			removed, err := codegen.Clean(context.Background(), opts, unitDir)
+			require.NoError(t, err)
+
+			expected := make([]string, 0, len(tc.removed))
+			for _, name := range tc.removed {
+				expected = append(expected, filepath.Join(unitDir, filepath.FromSlash(name)))
+			}
+
+			assert.ElementsMatch(t, expected, removed)
+
+			if !tc.dryRun {
+				for _, path := range expected {
+					assert.NoFileExists(t, path)
+				}
+			}
+
+			for _, name := range tc.preserved {
+				assert.FileExists(t, filepath.Join(unitDir, filepath.FromSlash(name)))
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Package codegen provides the `codegen` command to manage the files generated by Terragrunt.
+//
+// `codegen clean` removes the files bearing the Terragrunt signature that the config of a unit generates with its
+// `generate` blocks and the `generate` attribute of its `remote_state` block, or all the signed files of the unit with
+// `--all`. This is useful to clean up the generated files that linger after a unit, or one of its `generate` blocks,
+// is removed.
+package codegen
+
+import (
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/cli"
+)
+
+const (
+	CommandName      = "codegen"
+	CleanCommandName = "clean"
+
+	AllFlagName    = "all"
+	DryRunFlagName = "dry-run"
+)
+
+func NewCleanFlags(opts *Options) cli.Flags {
+	return cli.Flags{
+		&cli.BoolFlag{
+			Name:        AllFlagName,
+			Destination: &opts.CleanAll,
+			Usage:       "Remove all the files bearing the Terragrunt signature in the unit, not only the ones its config generates.",
+		},
+		&cli.BoolFlag{
+			Name:        DryRunFlagName,
+			Destination: &opts.CleanDryRun,
+			Usage:       "Print the generated files that would be removed without removing them.",
+		},
+	}
+}
+
+func NewCommand(generalOpts *options.TerragruntOptions) *cli.Command {
+	opts := NewOptions(generalOpts)
+
+	return &cli.Command{
+		Name:  CommandName,
+		Usage: "Manage the files generated by Terragrunt.",
+		Subcommands: cli.Commands{
+			newCleanCommand(opts),
+		},
+		Action: func(ctx *cli.Context) error {
+			if name := ctx.Args().CommandName(); name != "" {
+				return errors.Errorf("unknown subcommand %q of the %s command", name, CommandName)
+			}
+
+			return errors.Errorf("the %s command requires a subcommand, e.g. `terragrunt %s %s`", CommandName, CommandName, CleanCommandName)
+		},
+	}
+}
+
+func newCleanCommand(opts *Options) *cli.Command {
+	return &cli.Command{
+		Name:                   CleanCommandName,
+		Usage:                  "Remove the files generated by Terragrunt in the given unit, or the working directory.",
+		DisallowUndefinedFlags: true,
+		Flags:                  NewCleanFlags(opts).Sort(),
+		Action:                 func(ctx *cli.Context) error { return RunClean(ctx, opts, ctx.Args().Get(0)) },
+	}
+}
@@ -0,0 +1,20 @@
+package codegen
+
+import "github.com/gruntwork-io/terragrunt/options"
+
+type Options struct {
+	*options.TerragruntOptions
+
+	// CleanAll removes all the files bearing the Terragrunt signature with the `clean` subcommand, instead of only the
+	// ones the config of the unit generates.
+	CleanAll bool
+
+	// CleanDryRun prints the files that would be removed by the `clean` subcommand without removing them.
+	CleanDryRun bool
+}
+
+func NewOptions(general *options.TerragruntOptions) *Options {
+	return &Options{
+		TerragruntOptions: general,
+	}
+}
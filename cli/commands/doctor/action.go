@@ -0,0 +1,315 @@
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/backend"
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// Severity is how serious an issue is, only errors make the command fail.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// The names of the checks, as reported with the issues they find.
+const (
+	CheckBackendCollision = "backend-collision"
+	CheckDependencyCycle  = "dependency-cycle"
+	CheckProviderDrift    = "provider-drift"
+	CheckLocalState       = "local-state"
+	CheckDuplicateName    = "duplicate-name"
+)
+
+var severityOrder = map[Severity]int{
+	SeverityError:   0,
+	SeverityWarning: 1,
+	SeverityInfo:    2, //nolint:mnd
+}
+
+// Issue is an issue found by one of the checks.
+type Issue struct {
+	Check    string   `json:"check"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	// Units are the paths of the units involved, relative to the working directory.
+	Units []string `json:"units"`
+}
+
+// Run checks the units of the working directory, prints the issues found and returns an error if any of them is an
+// error.
+func Run(ctx context.Context, opts *Options) error {
+	issues, err := Diagnose(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.JSONOutput {
+		err = writeJSON(opts, issues)
+	} else {
+		err = writeText(opts, issues)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var errorsCount int
+
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			errorsCount++
+		}
+	}
+
+	if errorsCount > 0 {
+		return errors.Errorf("found %d errors in the units of %s", errorsCount, opts.WorkingDir)
+	}
+
+	if len(issues) == 0 {
+		opts.Logger.Infof("No issues found in the units of %s", opts.WorkingDir)
+	}
+
+	return nil
+}
+
+// Diagnose runs all the checks over the units of the working directory and returns the issues found, sorted by
+// severity, from errors to information.
+func Diagnose(ctx context.Context, opts *Options) ([]Issue, error) {
+	issues, err := checkBackendCollisions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	stackIssues, err := checkStack(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	issues = append(issues, stackIssues...)
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return severityOrder[issues[i].Severity] < severityOrder[issues[j].Severity]
+	})
+
+	return issues, nil
+}
+
+// checkBackendCollisions reports the units whose remote states are stored at the same location, as they overwrite
+// each other's state.
+func checkBackendCollisions(ctx context.Context, opts *Options) ([]Issue, error) {
+	collisions, err := backend.FindCollisions(ctx, backend.NewOptions(opts.TerragruntOptions))
+	if err != nil {
+		return nil, err
+	}
+
+	issues := make([]Issue, 0, len(collisions))
+
+	for _, collision := range collisions {
+		issues = append(issues, Issue{
+			Check:    CheckBackendCollision,
+			Severity: SeverityError,
+			Message:  "The remote state of the units is stored at the same location " + collision.Location,
+			Units:    collision.Units,
+		})
+	}
+
+	return issues, nil
+}
+
+// checkStack resolves the units of the working directory as the `*-all` commands do, and reports the dependency
+// cycles, the providers pinned to different versions, the units storing their state locally and the units with the
+// same name.
+func checkStack(ctx context.Context, opts *Options) ([]Issue, error) {
+	stackOpts, err := opts.Clone(opts.TerragruntConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// parse the `remote_state` and `generate` blocks to find the units storing their state locally, and do not prompt
+	// for the external dependencies, which are only checked from the units of the working directory
+	stackOpts.WarnLocalState = true
+	stackOpts.IgnoreExternalDependencies = true
+
+	configPaths, err := config.FindConfigFilesInPath(opts.WorkingDir, stackOpts)
+	if err != nil || len(configPaths) == 0 {
+		return nil, err
+	}
+
+	stack := configstack.NewStack(stackOpts)
+
+	// the modules are resolved without creating the stack with FindStackInSubfolders, which fails on the cycles
+	stack.Modules, err = stack.ResolveTerraformModules(ctx, configPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []Issue
+
+	if err := stack.Modules.CheckForCycles(); err != nil {
+		var cycle configstack.DependencyCycleError
+		if !errors.As(err, &cycle) {
+			return nil, err
+		}
+
+		units := relativePaths(opts, cycle)
+
+		issues = append(issues, Issue{
+			Check:    CheckDependencyCycle,
+			Severity: SeverityError,
+			Message:  "The units depend on each other: " + strings.Join(units, " -> "),
+			Units:    units,
+		})
+	}
+
+	// the units of the conflicts are already relative to the working directory
+	conflicts, err := stack.ProviderVersionConflicts(opts.TerragruntOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, conflict := range conflicts {
+		var units []string
+
+		for _, paths := range conflict.Units {
+			units = append(units, paths...)
+		}
+
+		sort.Strings(units)
+
+		issues = append(issues, Issue{
+			Check:    CheckProviderDrift,
+			Severity: SeverityWarning,
+			Message:  "The provider is pinned to different versions: " + conflict.String(),
+			Units:    units,
+		})
+	}
+
+	localStateUnits := relativePaths(opts, modulePaths(stack.LocalStateModules()))
+	sort.Strings(localStateUnits)
+
+	for _, unit := range localStateUnits {
+		issues = append(issues, Issue{
+			Check:    CheckLocalState,
+			Severity: SeverityWarning,
+			Message:  "The unit has neither a remote_state block nor a generate block configuring a backend, its state is stored locally",
+			Units:    []string{unit},
+		})
+	}
+
+	issues = append(issues, checkDuplicateNames(opts, stack.Modules)...)
+
+	return issues, nil
+}
+
+// checkDuplicateNames reports the units with the same name, i.e. the same directory name, which can't be told apart in
+// places showing only the name of the units.
+func checkDuplicateNames(opts *Options, modules configstack.TerraformModules) []Issue {
+	unitsByName := make(map[string][]string)
+
+	for _, module := range modules {
+		if module.FlagExcluded || module.AssumeAlreadyApplied {
+			continue
+		}
+
+		name := filepath.Base(module.Path)
+		unitsByName[name] = append(unitsByName[name], relativePaths(opts, []string{module.Path})...)
+	}
+
+	names := make([]string, 0, len(unitsByName))
+
+	for name, units := range unitsByName {
+		if len(units) > 1 {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	issues := make([]Issue, 0, len(names))
+
+	for _, name := range names {
+		units := unitsByName[name]
+		sort.Strings(units)
+
+		issues = append(issues, Issue{
+			Check:    CheckDuplicateName,
+			Severity: SeverityInfo,
+			Message:  fmt.Sprintf("The units are all named %q", name),
+			Units:    units,
+		})
+	}
+
+	return issues
+}
+
+func modulePaths(modules configstack.TerraformModules) []string {
+	paths := make([]string, 0, len(modules))
+
+	for _, module := range modules {
+		paths = append(paths, module.Path)
+	}
+
+	return paths
+}
+
+// relativePaths returns the given paths relative to the working directory, where possible.
+func relativePaths(opts *Options, paths []string) []string {
+	relPaths := make([]string, 0, len(paths))
+
+	for _, path := range paths {
+		if relPath, err := util.GetPathRelativeTo(path, opts.WorkingDir); err == nil {
+			path = relPath
+		}
+
+		relPaths = append(relPaths, path)
+	}
+
+	return relPaths
+}
+
+func writeJSON(opts *Options, issues []Issue) error {
+	if issues == nil {
+		issues = []Issue{}
+	}
+
+	jsonBytes, err := json.MarshalIndent(issues, "", "  ")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if _, err := fmt.Fprintln(opts.Writer, string(jsonBytes)); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+func writeText(opts *Options, issues []Issue) error {
+	w := tabwriter.NewWriter(opts.Writer, 0, 0, 2, ' ', 0) //nolint:mnd
+
+	for _, issue := range issues {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", issue.Severity, issue.Check, strings.Join(issue.Units, ", "), issue.Message); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package doctor_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/doctor"
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	// the working directory differs from the current directory, as with --terragrunt-working-dir
+	workingDir, err := filepath.Abs(filepath.Join("testdata", "fixtures", "issues"))
+	require.NoError(t, err)
+
+	generalOpts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, config.DefaultTerragruntConfigPath))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+
+	generalOpts.WorkingDir = workingDir
+	generalOpts.Writer = &out
+
+	opts := doctor.NewOptions(generalOpts)
+	opts.JSONOutput = true
+
+	err = doctor.Run(context.Background(), opts)
+	require.EqualError(t, err, "found 2 errors in the units of "+workingDir)
+
+	var issues []doctor.Issue
+
+	require.NoError(t, json.Unmarshal(out.Bytes(), &issues))
+
+	expected := []doctor.Issue{
+		{
+			Check:    doctor.CheckBackendCollision,
+			Severity: doctor.SeverityError,
+			Message:  "The remote state of the units is stored at the same location s3://acme-terraform-state/app/terraform.tfstate",
+			Units:    []string{"app", "app-copy"},
+		},
+		{
+			Check:    doctor.CheckDependencyCycle,
+			Severity: doctor.SeverityError,
+			Message:  "The units depend on each other: cycle-a -> cycle-b -> cycle-a",
+			Units:    []string{"cycle-a", "cycle-b", "cycle-a"},
+		},
+		{
+			Check:    doctor.CheckProviderDrift,
+			Severity: doctor.SeverityWarning,
+			Message:  "The provider is pinned to different versions: hashicorp/aws: 5.0.0 (prod/vpc), 5.1.0 (stage/vpc)",
+			Units:    []string{"prod/vpc", "stage/vpc"},
+		},
+		{
+			Check:    doctor.CheckLocalState,
+			Severity: doctor.SeverityWarning,
+			Message:  "The unit has neither a remote_state block nor a generate block configuring a backend, its state is stored locally",
+			Units:    []string{"legacy"},
+		},
+		{
+			Check:    doctor.CheckDuplicateName,
+			Severity: doctor.SeverityInfo,
+			Message:  `The units are all named "vpc"`,
+			Units:    []string{"prod/vpc", "stage/vpc"},
+		},
+	}
+	assert.Equal(t, expected, issues)
+}
+
+func TestRunNoIssues(t *testing.T) {
+	t.Parallel()
+
+	workingDir, err := filepath.Abs(filepath.Join("testdata", "fixtures", "issues", "prod"))
+	require.NoError(t, err)
+
+	generalOpts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+
+	generalOpts.WorkingDir = workingDir
+	generalOpts.Writer = &out
+
+	require.NoError(t, doctor.Run(context.Background(), doctor.NewOptions(generalOpts)))
+	assert.Empty(t, out.String())
+}
@@ -0,0 +1,41 @@
+// Package doctor provides the `doctor` command for Terragrunt.
+//
+// `doctor` command runs a set of checks over the units of the working directory and prints a consolidated report of
+// the issues found, each with a severity: units sharing a remote state location and dependency cycles are errors,
+// providers pinned to different versions and units storing their state locally are warnings, and units with the same
+// name are reported for information. The command fails if any error is found.
+package doctor
+
+import (
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/cli"
+)
+
+const (
+	CommandName = "doctor"
+
+	JSONOutputFlagName = "terragrunt-doctor-json"
+	JSONOutputEnvName  = "TERRAGRUNT_DOCTOR_JSON"
+)
+
+func NewFlags(opts *Options) cli.Flags {
+	return cli.Flags{
+		&cli.BoolFlag{
+			Name:        JSONOutputFlagName,
+			EnvVar:      JSONOutputEnvName,
+			Destination: &opts.JSONOutput,
+			Usage:       "Output the report in JSON format.",
+		},
+	}
+}
+
+func NewCommand(generalOpts *options.TerragruntOptions) *cli.Command {
+	opts := NewOptions(generalOpts)
+
+	return &cli.Command{
+		Name:   CommandName,
+		Usage:  "Check the units of the working directory for common issues and report them.",
+		Flags:  NewFlags(opts).Sort(),
+		Action: func(ctx *cli.Context) error { return Run(ctx, opts) },
+	}
+}
@@ -0,0 +1 @@
+# Intentionally empty, the state is stored locally
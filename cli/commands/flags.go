@@ -39,6 +39,9 @@ const (
 	TerragruntWorkingDirFlagName = "terragrunt-working-dir"
 	TerragruntWorkingDirEnvName  = "TERRAGRUNT_WORKING_DIR"
 
+	TerragruntConfigSearchUpFlagName = "terragrunt-config-search-up"
+	TerragruntConfigSearchUpEnvName  = "TERRAGRUNT_CONFIG_SEARCH_UP"
+
 	TerragruntDownloadDirFlagName = "terragrunt-download-dir"
 	TerragruntDownloadDirEnvName  = "TERRAGRUNT_DOWNLOAD"
 
@@ -72,6 +75,18 @@ const (
 	TerragruntModulesThatIncludeFlagName = "terragrunt-modules-that-include"
 	TerragruntModulesThatIncludeEnvName  = "TERRAGRUNT_MODULES_THAT_INCLUDE"
 
+	TerragruntAllowedFunctionsFlagName = "terragrunt-allowed-functions"
+	TerragruntAllowedFunctionsEnvName  = "TERRAGRUNT_ALLOWED_FUNCTIONS"
+
+	TerragruntDeniedFunctionsFlagName = "terragrunt-denied-functions"
+	TerragruntDeniedFunctionsEnvName  = "TERRAGRUNT_DENIED_FUNCTIONS"
+
+	TerragruntSkipOutputsFlagName = "terragrunt-skip-outputs"
+	TerragruntSkipOutputsEnvName  = "TERRAGRUNT_SKIP_OUTPUTS"
+
+	TerragruntMockOutputFlagName = "terragrunt-mock-output"
+	TerragruntMockOutputEnvName  = "TERRAGRUNT_MOCK_OUTPUT"
+
 	TerragruntFetchDependencyOutputFromStateFlagName = "terragrunt-fetch-dependency-output-from-state"
 	TerragruntFetchDependencyOutputFromStateEnvName  = "TERRAGRUNT_FETCH_DEPENDENCY_OUTPUT_FROM_STATE"
 
@@ -87,6 +102,15 @@ const (
 	TerragruntDisableCommandValidationFlagName = "terragrunt-disable-command-validation"
 	TerragruntDisableCommandValidationEnvName  = "TERRAGRUNT_DISABLE_COMMAND_VALIDATION"
 
+	TerragruntLoadDotEnvFlagName = "terragrunt-load-dotenv"
+	TerragruntLoadDotEnvEnvName  = "TERRAGRUNT_LOAD_DOTENV"
+
+	TerragruntOutputMergeFlagName = "terragrunt-output-merge"
+	TerragruntOutputMergeEnvName  = "TERRAGRUNT_OUTPUT_MERGE"
+
+	TerragruntRestrictIncludesToRepoFlagName = "terragrunt-restrict-includes-to-repo"
+	TerragruntRestrictIncludesToRepoEnvName  = "TERRAGRUNT_RESTRICT_INCLUDES_TO_REPO"
+
 	TerragruntAuthProviderCmdFlagName = "terragrunt-auth-provider-cmd"
 	TerragruntAuthProviderCmdEnvName  = "TERRAGRUNT_AUTH_PROVIDER_CMD"
 
@@ -96,6 +120,48 @@ const (
 	TerragruntJSONOutDirFlagEnvName = "TERRAGRUNT_JSON_OUT_DIR"
 	TerragruntJSONOutDirFlagName    = "terragrunt-json-out-dir"
 
+	TerragruntUnitLogsDirFlagEnvName = "TERRAGRUNT_UNIT_LOGS_DIR"
+	TerragruntUnitLogsDirFlagName    = "terragrunt-unit-logs-dir"
+
+	TerragruntOutputModeFlagEnvName = "TERRAGRUNT_OUTPUT_MODE"
+	TerragruntOutputModeFlagName    = "terragrunt-output-mode"
+
+	TerragruntOutputOrderFlagEnvName = "TERRAGRUNT_OUTPUT_ORDER"
+	TerragruntOutputOrderFlagName    = "terragrunt-output-order"
+
+	TerragruntOutputSpillThresholdFlagEnvName = "TERRAGRUNT_OUTPUT_SPILL_THRESHOLD"
+	TerragruntOutputSpillThresholdFlagName    = "terragrunt-output-spill-threshold"
+
+	TerragruntCheckProviderConsistencyFlagEnvName = "TERRAGRUNT_CHECK_PROVIDER_CONSISTENCY"
+	TerragruntCheckProviderConsistencyFlagName    = "terragrunt-check-provider-consistency"
+
+	TerragruntSkipNoChangesFlagEnvName = "TERRAGRUNT_SKIP_NO_CHANGES"
+	TerragruntSkipNoChangesFlagName    = "terragrunt-skip-no-changes"
+
+	TerragruntPrintExecutionPlanFlagEnvName = "TERRAGRUNT_PRINT_EXECUTION_PLAN"
+	TerragruntPrintExecutionPlanFlagName    = "terragrunt-print-execution-plan"
+
+	TerragruntExecutionPlanOnlyFlagEnvName = "TERRAGRUNT_EXECUTION_PLAN_ONLY"
+	TerragruntExecutionPlanOnlyFlagName    = "terragrunt-execution-plan-only"
+
+	TerragruntMetricsFileFlagEnvName = "TERRAGRUNT_METRICS_FILE"
+	TerragruntMetricsFileFlagName    = "terragrunt-metrics-file"
+
+	TerragruntEventsOutFlagEnvName = "TERRAGRUNT_EVENTS_OUT"
+	TerragruntEventsOutFlagName    = "terragrunt-events-out"
+
+	TerragruntWarnLocalStateFlagEnvName = "TERRAGRUNT_WARN_LOCAL_STATE"
+	TerragruntWarnLocalStateFlagName    = "terragrunt-warn-local-state"
+
+	TerragruntQueueShuffleFlagEnvName = "TERRAGRUNT_QUEUE_SHUFFLE"
+	TerragruntQueueShuffleFlagName    = "terragrunt-queue-shuffle"
+
+	TerragruntQueueShuffleSeedFlagEnvName = "TERRAGRUNT_QUEUE_SHUFFLE_SEED"
+	TerragruntQueueShuffleSeedFlagName    = "terragrunt-queue-shuffle-seed"
+
+	TerragruntSequentialFlagEnvName = "TERRAGRUNT_SEQUENTIAL"
+	TerragruntSequentialFlagName    = "terragrunt-sequential"
+
 	TerragruntNoDestroyDependenciesCheckFlagEnvName = "TERRAGRUNT_NO_DESTROY_DEPENDENCIES_CHECK"
 	TerragruntNoDestroyDependenciesCheckFlagName    = "terragrunt-no-destroy-dependencies-check"
 
@@ -113,21 +179,39 @@ const (
 	TerragruntIncludeExternalDependenciesFlagName = "terragrunt-include-external-dependencies"
 	TerragruntIncludeExternalDependenciesEnvName  = "TERRAGRUNT_INCLUDE_EXTERNAL_DEPENDENCIES"
 
+	TerragruntExternalDependenciesOutputsOnlyFlagName = "terragrunt-external-dependencies-outputs-only"
+	TerragruntExternalDependenciesOutputsOnlyEnvName  = "TERRAGRUNT_EXTERNAL_DEPENDENCIES_OUTPUTS_ONLY"
+
 	TerragruntExcludesFileFlagName = "terragrunt-excludes-file"
 	TerragruntExcludesFileEnvName  = "TERRAGRUNT_EXCLUDES_FILE"
 
+	TerragruntIncludesFileFlagName = "terragrunt-includes-file"
+	TerragruntIncludesFileEnvName  = "TERRAGRUNT_INCLUDES_FILE"
+
+	TerragruntIgnoreFileFlagName = "terragrunt-ignore-file"
+	TerragruntIgnoreFileEnvName  = "TERRAGRUNT_IGNORE_FILE"
+
+	TerragruntConfigFilenameOverrideFlagName = "terragrunt-config-filename-override"
+	TerragruntConfigFilenameOverrideEnvName  = "TERRAGRUNT_CONFIG_FILENAME_OVERRIDE"
+
 	TerragruntExcludeDirFlagName = "terragrunt-exclude-dir"
 	TerragruntExcludeDirEnvName  = "TERRAGRUNT_EXCLUDE_DIR"
 
 	TerragruntIncludeDirFlagName = "terragrunt-include-dir"
 	TerragruntIncludeDirEnvName  = "TERRAGRUNT_INCLUDE_DIR"
 
+	TerragruntMaxFolderDepthFlagName = "terragrunt-max-folder-depth"
+	TerragruntMaxFolderDepthEnvName  = "TERRAGRUNT_MAX_FOLDER_DEPTH"
+
 	TerragruntStrictIncludeFlagName = "terragrunt-strict-include"
 	TerragruntStrictIncludeEnvName  = "TERRAGRUNT_STRICT_INCLUDE"
 
 	TerragruntUnitsReadingFlagName = "terragrunt-queue-include-units-reading"
 	TerragruntUnitsReadingEnvName  = "TERRAGRUNT_QUEUE_INCLUDE_UNITS_READING"
 
+	TerragruntGraphCacheFlagName = "terragrunt-graph-cache"
+	TerragruntGraphCacheEnvName  = "TERRAGRUNT_GRAPH_CACHE"
+
 	// Logs related flags/envs
 
 	TerragruntLogLevelFlagName = "terragrunt-log-level"
@@ -136,6 +220,9 @@ const (
 	TerragruntLogDisableFlagName = "terragrunt-log-disable"
 	TerragruntLogDisableEnvName  = "TERRAGRUNT_LOG_DISABLE"
 
+	TerragruntQuietFlagName = "terragrunt-quiet"
+	TerragruntQuietEnvName  = "TERRAGRUNT_QUIET"
+
 	TerragruntNoColorFlagName = "terragrunt-no-color"
 	TerragruntNoColorEnvName  = "TERRAGRUNT_NO_COLOR"
 
@@ -145,6 +232,9 @@ const (
 	TerragruntForwardTFStdoutFlagName = "terragrunt-forward-tf-stdout"
 	TerragruntForwardTFStdoutEnvName  = "TERRAGRUNT_FORWARD_TF_STDOUT"
 
+	TerragruntTFWarningsAsErrorsFlagName = "terragrunt-tf-warnings-as-errors"
+	TerragruntTFWarningsAsErrorsEnvName  = "TERRAGRUNT_TF_WARNINGS_AS_ERRORS"
+
 	TerragruntLogFormatFlagName = "terragrunt-log-format"
 	TerragruntLogFormatEnvName  = "TERRAGRUNT_LOG_FORMAT"
 
@@ -182,12 +272,33 @@ const (
 	TerragruntProviderCachePortFlagName = "terragrunt-provider-cache-port"
 	TerragruntProviderCachePortEnvName  = "TERRAGRUNT_PROVIDER_CACHE_PORT"
 
+	TerragruntProviderCacheAddrFlagName = "terragrunt-provider-cache-addr"
+	TerragruntProviderCacheAddrEnvName  = "TERRAGRUNT_PROVIDER_CACHE_ADDR"
+
 	TerragruntProviderCacheTokenFlagName = "terragrunt-provider-cache-token"
 	TerragruntProviderCacheTokenEnvName  = "TERRAGRUNT_PROVIDER_CACHE_TOKEN"
 
 	TerragruntProviderCacheRegistryNamesFlagName = "terragrunt-provider-cache-registry-names"
 	TerragruntProviderCacheRegistryNamesEnvName  = "TERRAGRUNT_PROVIDER_CACHE_REGISTRY_NAMES"
 
+	TerragruntProviderCacheExcludeFlagName = "terragrunt-provider-cache-exclude"
+	TerragruntProviderCacheExcludeEnvName  = "TERRAGRUNT_PROVIDER_CACHE_EXCLUDE"
+
+	TerragruntProviderCacheFilterFlagName = "terragrunt-provider-cache-filter"
+	TerragruntProviderCacheFilterEnvName  = "TERRAGRUNT_PROVIDER_CACHE_FILTER"
+
+	TerragruntProviderCacheMaxBytesFlagName = "terragrunt-provider-cache-max-bytes"
+	TerragruntProviderCacheMaxBytesEnvName  = "TERRAGRUNT_PROVIDER_CACHE_MAX_BYTES"
+
+	TerragruntProviderCacheTLSCertFileFlagName = "terragrunt-provider-cache-tls-cert-file"
+	TerragruntProviderCacheTLSCertFileEnvName  = "TERRAGRUNT_PROVIDER_CACHE_TLS_CERT_FILE"
+
+	TerragruntProviderCacheTLSKeyFileFlagName = "terragrunt-provider-cache-tls-key-file"
+	TerragruntProviderCacheTLSKeyFileEnvName  = "TERRAGRUNT_PROVIDER_CACHE_TLS_KEY_FILE"
+
+	TerragruntProviderCacheTLSClientCAFileFlagName = "terragrunt-provider-cache-tls-client-ca-file"
+	TerragruntProviderCacheTLSClientCAFileEnvName  = "TERRAGRUNT_PROVIDER_CACHE_TLS_CLIENT_CA_FILE"
+
 	TerragruntFeatureMapFlagName = "feature"
 	TerragruntFeatureMapEnvName  = "TERRAGRUNT_FEATURE"
 
@@ -250,6 +361,12 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 			Destination: &opts.WorkingDir,
 			Usage:       "The path to the directory of Terragrunt configurations. Default is current directory.",
 		},
+		&cli.BoolFlag{
+			Name:        TerragruntConfigSearchUpFlagName,
+			EnvVar:      TerragruntConfigSearchUpEnvName,
+			Destination: &opts.ConfigSearchUp,
+			Usage:       "If the working dir has no Terragrunt config, use the directory of the nearest config in the parent directories, up to the root of the git repository, as the working dir.",
+		},
 		&cli.GenericFlag[string]{
 			Name:        TerragruntDownloadDirFlagName,
 			EnvVar:      TerragruntDownloadDirEnvName,
@@ -323,6 +440,12 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 			Destination: &opts.IncludeExternalDependencies,
 			Usage:       "*-all commands will include external dependencies",
 		},
+		&cli.BoolFlag{
+			Name:        TerragruntExternalDependenciesOutputsOnlyFlagName,
+			EnvVar:      TerragruntExternalDependenciesOutputsOnlyEnvName,
+			Destination: &opts.ExternalDependencyOutputsOnly,
+			Usage:       "*-all commands will not run external dependencies, but will read their outputs from the existing state instead of using mock outputs.",
+		},
 		&cli.GenericFlag[int]{
 			Name:        TerragruntParallelismFlagName,
 			EnvVar:      TerragruntParallelismEnvName,
@@ -335,6 +458,25 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 			Destination: &opts.ExcludesFile,
 			Usage:       "Path to a file with a list of directories that need to be excluded when running *-all commands.",
 		},
+		&cli.GenericFlag[string]{
+			Name:        TerragruntIncludesFileFlagName,
+			EnvVar:      TerragruntIncludesFileEnvName,
+			Destination: &opts.IncludesFile,
+			Usage:       "Path to a file with a list of directories that need to be included when running *-all commands.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        TerragruntIgnoreFileFlagName,
+			EnvVar:      TerragruntIgnoreFileEnvName,
+			Destination: &opts.IgnoreFile,
+			Usage:       "Name of the files with gitignore-style patterns of directories to skip when looking for modules in *-all commands.",
+		},
+		&cli.MapFlag[string, string]{
+			Name:        TerragruntConfigFilenameOverrideFlagName,
+			EnvVar:      TerragruntConfigFilenameOverrideEnvName,
+			Destination: &opts.ConfigFilenameOverrides,
+			Usage:       "Look for the config file with the given name in the directories matching the glob, relative to the working dir, in the form of <glob>=<filename>, when looking for modules in *-all commands. Can be specified multiple times.",
+			Splitter:    util.SplitComma,
+		},
 		&cli.SliceFlag[string]{
 			Name:        TerragruntExcludeDirFlagName,
 			EnvVar:      TerragruntExcludeDirEnvName,
@@ -347,6 +489,12 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 			Destination: &opts.IncludeDirs,
 			Usage:       "Unix-style glob of directories to include when running *-all commands",
 		},
+		&cli.GenericFlag[int]{
+			Name:        TerragruntMaxFolderDepthFlagName,
+			EnvVar:      TerragruntMaxFolderDepthEnvName,
+			Destination: &opts.MaxFolderDepth,
+			Usage:       "*-all commands will not look for modules more than N folders below the working directory. 0 means unlimited.",
+		},
 		&cli.BoolFlag{
 			Name:        TerragruntDebugFlagName,
 			EnvVar:      TerragruntDebugEnvName,
@@ -377,6 +525,11 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 					return cli.NewExitError(errors.Errorf("flag --%s, %w", TerragruntLogLevelFlagName, err), 1)
 				}
 
+				// --terragrunt-quiet sets the level whatever the order of the flags.
+				if opts.Quiet {
+					return nil
+				}
+
 				opts.Logger.SetOptions(log.WithLevel(level))
 				opts.LogLevel = level
 				return nil
@@ -393,6 +546,23 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 				return nil
 			},
 		},
+		&cli.BoolFlag{
+			Name:        TerragruntQuietFlagName,
+			EnvVar:      TerragruntQuietEnvName,
+			Usage:       "Only log warnings and errors, suppressing info and debug logs and the OpenTofu/Terraform stdout integrated into the log.",
+			Destination: &opts.Quiet,
+			Action: func(_ *cli.Context, quiet bool) error {
+				if !quiet {
+					return nil
+				}
+
+				opts.Logger.SetOptions(log.WithLevel(log.WarnLevel))
+				opts.LogLevel = log.WarnLevel
+				opts.LogFormatter.DisableLevels(log.StdoutLevel)
+
+				return nil
+			},
+		},
 		&cli.BoolFlag{
 			Name:        TerragruntShowLogAbsPathsFlagName,
 			EnvVar:      TerragruntShowLogAbsPathsEnvName,
@@ -421,12 +591,43 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 			Destination: &opts.FetchDependencyOutputFromState,
 			Usage:       "The option fetches dependency output directly from the state file instead of init dependencies and running terraform on them.",
 		},
+		&cli.SliceFlag[string]{
+			Name:        TerragruntAllowedFunctionsFlagName,
+			EnvVar:      TerragruntAllowedFunctionsEnvName,
+			Destination: &opts.AllowedFunctions,
+			Usage:       "If flag is set, only the specified HCL functions can be called in the Terragrunt configuration.",
+		},
+		&cli.SliceFlag[string]{
+			Name:        TerragruntDeniedFunctionsFlagName,
+			EnvVar:      TerragruntDeniedFunctionsEnvName,
+			Destination: &opts.DeniedFunctions,
+			Usage:       "The HCL functions that cannot be called in the Terragrunt configuration, e.g. run_cmd.",
+		},
+		&cli.BoolFlag{
+			Name:        TerragruntSkipOutputsFlagName,
+			EnvVar:      TerragruntSkipOutputsEnvName,
+			Destination: &opts.SkipOutput,
+			Usage:       "Skip retrieving the outputs of all dependencies, the same as setting skip_outputs on every dependency block.",
+		},
+		&cli.MapFlag[string, string]{
+			Name:        TerragruntMockOutputFlagName,
+			EnvVar:      TerragruntMockOutputEnvName,
+			Destination: &opts.MockOutputs,
+			Usage:       "Set a mock output for a dependency in the form of <dependency name>.<output name>=<value>. Overrides the mock_outputs set in the config.",
+			Splitter:    util.SplitComma,
+		},
 		&cli.BoolFlag{
 			Name:        TerragruntForwardTFStdoutFlagName,
 			EnvVar:      TerragruntForwardTFStdoutEnvName,
 			Destination: &opts.ForwardTFStdout,
 			Usage:       "If specified, the output of OpenTofu/Terraform commands will be printed as is, without being integrated into the Terragrunt log.",
 		},
+		&cli.BoolFlag{
+			Name:        TerragruntTFWarningsAsErrorsFlagName,
+			EnvVar:      TerragruntTFWarningsAsErrorsEnvName,
+			Destination: &opts.TFWarningsAsErrors,
+			Usage:       "Fail the OpenTofu/Terraform commands run with -json whose diagnostics contain warnings.",
+		},
 		&cli.GenericFlag[string]{
 			Name:   TerragruntLogFormatFlagName,
 			EnvVar: TerragruntLogFormatEnvName,
@@ -486,6 +687,12 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 			Destination: &opts.UnitsReading,
 			Usage:       "If flag is set, 'run-all' will only run the command against Terragrunt units that read the specified file via an HCL function.",
 		},
+		&cli.BoolFlag{
+			Name:        TerragruntGraphCacheFlagName,
+			EnvVar:      TerragruntGraphCacheEnvName,
+			Destination: &opts.GraphCache,
+			Usage:       "Reuse the dependency graph resolved by a previous 'run-all' in the same process as long as the options, feature flags, environment variables and configurations are unchanged.",
+		},
 		&cli.BoolFlag{
 			Name:        TerragruntFailOnStateBucketCreationFlagName,
 			EnvVar:      TerragruntFailOnStateBucketCreationEnvName,
@@ -504,6 +711,24 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 			Destination: &opts.DisableCommandValidation,
 			Usage:       "When this flag is set, Terragrunt will not validate the terraform command.",
 		},
+		&cli.BoolFlag{
+			Name:        TerragruntLoadDotEnvFlagName,
+			EnvVar:      TerragruntLoadDotEnvEnvName,
+			Destination: &opts.LoadDotEnv,
+			Usage:       "Load the environment variables of the .env file next to the terragrunt.hcl of the unit into the tofu/terraform process.",
+		},
+		&cli.BoolFlag{
+			Name:        TerragruntOutputMergeFlagName,
+			EnvVar:      TerragruntOutputMergeEnvName,
+			Destination: &opts.OutputMerge,
+			Usage:       "Make the output command print the outputs of the unit and of all of its dependencies, keyed by the path of the unit, as JSON.",
+		},
+		&cli.BoolFlag{
+			Name:        TerragruntRestrictIncludesToRepoFlagName,
+			EnvVar:      TerragruntRestrictIncludesToRepoEnvName,
+			Destination: &opts.RestrictIncludesToRepo,
+			Usage:       "Fail if an include or find_in_parent_folders resolves to a file outside the git repository of the config.",
+		},
 		&cli.BoolFlag{
 			Name:        TerragruntNoDestroyDependenciesCheckFlagName,
 			EnvVar:      TerragruntNoDestroyDependenciesCheckFlagEnvName,
@@ -597,12 +822,54 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 			EnvVar:      TerragruntProviderCachePortEnvName,
 			Usage:       "The port of the Terragrunt Provider Cache server. By default, assigned automatically.",
 		},
+		&cli.GenericFlag[string]{
+			Name:        TerragruntProviderCacheAddrFlagName,
+			Destination: &opts.ProviderCacheAddr,
+			EnvVar:      TerragruntProviderCacheAddrEnvName,
+			Usage:       "The 'host:port' address the Terragrunt Provider Cache server binds to, e.g. '0.0.0.0:5758'. Takes precedence over the hostname and port for binding.",
+		},
 		&cli.SliceFlag[string]{
 			Name:        TerragruntProviderCacheRegistryNamesFlagName,
 			Destination: &opts.ProviderCacheRegistryNames,
 			EnvVar:      TerragruntProviderCacheRegistryNamesEnvName,
 			Usage:       "The list of remote registries to cached by Terragrunt Provider Cache server. By default, 'registry.terraform.io', 'registry.opentofu.org'.",
 		},
+		&cli.SliceFlag[string]{
+			Name:        TerragruntProviderCacheExcludeFlagName,
+			Destination: &opts.ProviderCacheExcludes,
+			EnvVar:      TerragruntProviderCacheExcludeEnvName,
+			Usage:       "A provider, as a 'registry/namespace/name' pattern, that is not cached by Terragrunt Provider Cache server but downloaded from the upstream registry. Can be specified multiple times.",
+		},
+		&cli.SliceFlag[string]{
+			Name:        TerragruntProviderCacheFilterFlagName,
+			Destination: &opts.ProviderCacheFilters,
+			EnvVar:      TerragruntProviderCacheFilterEnvName,
+			Usage:       "A glob pattern of the unit paths, relative to the working directory, that use Terragrunt Provider Cache server, the other units download their providers directly. Can be specified multiple times.",
+		},
+		&cli.GenericFlag[int64]{
+			Name:        TerragruntProviderCacheMaxBytesFlagName,
+			Destination: &opts.ProviderCacheMaxBytes,
+			EnvVar:      TerragruntProviderCacheMaxBytesEnvName,
+			Usage:       "The maximum size in bytes of the provider cache directory, beyond which the least recently used providers are evicted once a provider is cached.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        TerragruntProviderCacheTLSCertFileFlagName,
+			Destination: &opts.ProviderCacheTLSCertFile,
+			EnvVar:      TerragruntProviderCacheTLSCertFileEnvName,
+			Usage:       "The PEM encoded certificate file to serve the Terragrunt Provider Cache server over HTTPS. Requires the key file to be set as well.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        TerragruntProviderCacheTLSKeyFileFlagName,
+			Destination: &opts.ProviderCacheTLSKeyFile,
+			EnvVar:      TerragruntProviderCacheTLSKeyFileEnvName,
+			Usage:       "The PEM encoded key file of the certificate to serve the Terragrunt Provider Cache server over HTTPS.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        TerragruntProviderCacheTLSClientCAFileFlagName,
+			Destination: &opts.ProviderCacheTLSClientCAFile,
+			EnvVar:      TerragruntProviderCacheTLSClientCAFileEnvName,
+			Usage:       "The PEM encoded CA file to require the clients of the Terragrunt Provider Cache server to present a certificate signed by. Requires HTTPS.",
+		},
 		&cli.GenericFlag[string]{
 			Name:        TerragruntAuthProviderCmdFlagName,
 			Destination: &opts.AuthProviderCmd,
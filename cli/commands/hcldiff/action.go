@@ -0,0 +1,230 @@
+package hcldiff
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// Difference is an attribute, or a block, whose value differs between the two configurations.
+type Difference struct {
+	// Path is the path of the value in the configuration, e.g. `inputs.instance_type`.
+	Path string `json:"path"`
+	// A is the value in the first configuration, null if it is only set in the second one.
+	A json.RawMessage `json:"a"`
+	// B is the value in the second configuration, null if it is only set in the first one.
+	B json.RawMessage `json:"b"`
+}
+
+// Run prints the differences between the two configurations, given as config files or unit directories.
+func Run(ctx context.Context, opts *Options, pathA, pathB string) error {
+	diffs, err := Diff(ctx, opts, pathA, pathB)
+	if err != nil {
+		return err
+	}
+
+	if opts.JSONOutput {
+		return writeJSON(opts, diffs)
+	}
+
+	if len(diffs) == 0 {
+		opts.Logger.Infof("No differences between %s and %s", pathA, pathB)
+		return nil
+	}
+
+	return writeText(opts, diffs)
+}
+
+// Diff parses the two configurations, given as config files or unit directories, and returns the values that differ
+// between them, sorted by path. The maps and objects, such as `inputs`, are compared key by key, the other values as
+// a whole.
+func Diff(ctx context.Context, opts *Options, pathA, pathB string) ([]Difference, error) {
+	valA, err := parseConfig(ctx, opts, pathA)
+	if err != nil {
+		return nil, err
+	}
+
+	valB, err := parseConfig(ctx, opts, pathB)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := []Difference{}
+
+	if err := diffValues("", valA, valB, &diffs); err != nil {
+		return nil, err
+	}
+
+	return diffs, nil
+}
+
+// parseConfig parses the configuration in the given config file, or unit directory, and returns its cty representation,
+// the one of `render-json`.
+func parseConfig(ctx context.Context, opts *Options, path string) (cty.Value, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(opts.WorkingDir, path)
+	}
+
+	if util.IsDir(path) {
+		path = config.GetDefaultConfigPath(path)
+	}
+
+	if !util.FileExists(path) {
+		return cty.NilVal, errors.Errorf("the configuration %s does not exist", path)
+	}
+
+	configOpts, err := opts.Clone(path)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	// Parse the configurations statically, the outputs of the dependencies are not read so that Terraform is not run.
+	configOpts.SkipOutput = true
+
+	cfg, err := config.ReadTerragruntConfig(ctx, configOpts, config.DefaultParserOptions(configOpts))
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	return config.TerragruntConfigAsCty(cfg)
+}
+
+// diffValues appends the differences between the two values at the given path to diffs, recursing into the maps and
+// objects set in both values.
+func diffValues(path string, valA, valB cty.Value, diffs *[]Difference) error {
+	if valA.RawEquals(valB) {
+		return nil
+	}
+
+	if isMapOrObject(valA) && isMapOrObject(valB) {
+		mapA, mapB := valA.AsValueMap(), valB.AsValueMap()
+
+		keys := make([]string, 0, len(mapA)+len(mapB))
+
+		for key := range mapA {
+			keys = append(keys, key)
+		}
+
+		for key := range mapB {
+			if _, ok := mapA[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			childA, ok := mapA[key]
+			if !ok {
+				childA = cty.NullVal(cty.DynamicPseudoType)
+			}
+
+			childB, ok := mapB[key]
+			if !ok {
+				childB = cty.NullVal(cty.DynamicPseudoType)
+			}
+
+			if err := diffValues(joinPath(path, key), childA, childB, diffs); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	jsonA, err := marshalValue(valA)
+	if err != nil {
+		return err
+	}
+
+	jsonB, err := marshalValue(valB)
+	if err != nil {
+		return err
+	}
+
+	// Values of different types may still render the same, e.g. a list and a tuple of the same elements.
+	if string(jsonA) == string(jsonB) {
+		return nil
+	}
+
+	*diffs = append(*diffs, Difference{Path: path, A: jsonA, B: jsonB})
+
+	return nil
+}
+
+func isMapOrObject(val cty.Value) bool {
+	if val.IsNull() || !val.IsKnown() {
+		return false
+	}
+
+	return val.Type().IsObjectType() || val.Type().IsMapType()
+}
+
+// joinPath returns the path of the given key of the value at path, e.g. `inputs.name`, or `inputs["my-name"]` if the
+// key isn't a valid identifier.
+func joinPath(path, key string) string {
+	if !hclsyntax.ValidIdentifier(key) {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func marshalValue(val cty.Value) (json.RawMessage, error) {
+	jsonBytes, err := ctyjson.SimpleJSONValue{Value: val}.MarshalJSON()
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return jsonBytes, nil
+}
+
+func writeJSON(opts *Options, diffs []Difference) error {
+	jsonBytes, err := json.MarshalIndent(diffs, "", "  ")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if _, err := fmt.Fprintln(opts.Writer, string(jsonBytes)); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// writeText prints each difference on a line, prefixed with `+` if the value is only set in the second configuration,
+// `-` if it is only set in the first one, and `~` if it is changed.
+func writeText(opts *Options, diffs []Difference) error {
+	for _, diff := range diffs {
+		var line string
+
+		switch {
+		case string(diff.A) == "null":
+			line = fmt.Sprintf("+ %s: %s", diff.Path, diff.B)
+		case string(diff.B) == "null":
+			line = fmt.Sprintf("- %s: %s", diff.Path, diff.A)
+		default:
+			line = fmt.Sprintf("~ %s: %s -> %s", diff.Path, diff.A, diff.B)
+		}
+
+		if _, err := fmt.Fprintln(opts.Writer, line); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package hcldiff_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/hcldiff"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+func TestHCLDiff(t *testing.T) {
+	t.Parallel()
+
+	fixturePath, err := filepath.Abs("testdata/fixtures")
+	require.NoError(t, err)
+
+	generalOpts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	var stdout bytes.Buffer
+
+	generalOpts.WorkingDir = fixturePath
+	generalOpts.Writer = &stdout
+
+	opts := hcldiff.NewOptions(generalOpts)
+
+	require.NoError(t, hcldiff.Run(context.Background(), opts, "a", "b"))
+
+	// only the input value is reported, not the formatting or the order of the attributes
+	assert.Equal(t, "~ inputs.instance_type: \"t3.micro\" -> \"t3.small\"\n", stdout.String())
+
+	stdout.Reset()
+
+	opts.JSONOutput = true
+
+	require.NoError(t, hcldiff.Run(context.Background(), opts, "a", "a"))
+
+	var diffs []hcldiff.Difference
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &diffs))
+	assert.Empty(t, diffs)
+}
@@ -0,0 +1,48 @@
+// Package hcldiff provides the `hcldiff` command for Terragrunt.
+//
+// `hcldiff` command compares two Terragrunt configurations semantically: both configurations are parsed, with their
+// includes merged and their expressions evaluated, and the attributes and blocks whose values differ are reported.
+// Unlike a textual diff, formatting, comments and the order of the attributes are ignored.
+package hcldiff
+
+import (
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/cli"
+)
+
+const (
+	CommandName = "hcldiff"
+
+	JSONOutputFlagName = "terragrunt-hcldiff-json"
+	JSONOutputEnvName  = "TERRAGRUNT_HCLDIFF_JSON"
+)
+
+func NewFlags(opts *Options) cli.Flags {
+	return cli.Flags{
+		&cli.BoolFlag{
+			Name:        JSONOutputFlagName,
+			EnvVar:      JSONOutputEnvName,
+			Destination: &opts.JSONOutput,
+			Usage:       "Output the differences in JSON format.",
+		},
+	}
+}
+
+func NewCommand(generalOpts *options.TerragruntOptions) *cli.Command {
+	opts := NewOptions(generalOpts)
+
+	return &cli.Command{
+		Name:      CommandName,
+		Usage:     "Compare two Terragrunt configurations semantically and print the differences.",
+		UsageText: "terragrunt hcldiff [options] <a> <b>",
+		Flags:     NewFlags(opts).Sort(),
+		Action: func(ctx *cli.Context) error {
+			if ctx.Args().Len() != 2 { //nolint:mnd
+				return errors.Errorf("the %s command requires the two configurations to compare, e.g. `terragrunt %s live/stage/app live/prod/app`", CommandName, CommandName)
+			}
+
+			return Run(ctx, opts, ctx.Args().First(), ctx.Args().Second())
+		},
+	}
+}
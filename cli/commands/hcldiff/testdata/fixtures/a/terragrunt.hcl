@@ -0,0 +1,15 @@
+locals {
+  env = "stage"
+}
+
+terraform {
+  source = "git::https://github.com/acme/infrastructure-modules.git//app?ref=v1.0.0"
+}
+
+inputs = {
+  name          = "app-${local.env}"
+  instance_type = "t3.micro"
+  tags = {
+    team = "platform"
+  }
+}
@@ -0,0 +1,12 @@
+# Same configuration as ../a, reformatted, with the attributes in another order and a different instance type.
+locals { env = "stage" }
+
+inputs = {
+  tags          = { team = "platform" }
+  instance_type = "t3.small"
+  name          = "app-${local.env}"
+}
+
+terraform {
+  source = "git::https://github.com/acme/infrastructure-modules.git//app?ref=v1.0.0"
+}
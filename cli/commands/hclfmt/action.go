@@ -6,11 +6,12 @@ package hclfmt
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/gruntwork-io/terragrunt/pkg/log"
@@ -26,6 +27,9 @@ import (
 	"github.com/gruntwork-io/terragrunt/util"
 )
 
+// jsonFileExtensions are the patterns of the JSON config files formatted with the include JSON flag.
+var jsonFileExtensions = []string{"*.tf.json", "*.hcl.json"}
+
 func Run(opts *options.TerragruntOptions) error {
 	workingDir := opts.WorkingDir
 	targetFile := opts.HclFile
@@ -47,14 +51,26 @@ func Run(opts *options.TerragruntOptions) error {
 
 		opts.Logger.Debugf("Formatting hcl file at: %s.", targetFile)
 
-		return formatTgHCL(opts, targetFile)
+		return formatFile(opts, targetFile)
 	}
 
 	opts.Logger.Debugf("Formatting hcl files from the directory tree %s.", opts.WorkingDir)
-	// zglob normalizes paths to "/"
-	tgHclFiles, err := zglob.Glob(util.JoinPath(workingDir, "**", "*.hcl"))
-	if err != nil {
-		return err
+
+	patterns := []string{"*.hcl"}
+	if opts.HclIncludeJSON {
+		patterns = append(patterns, jsonFileExtensions...)
+	}
+
+	var tgHclFiles []string
+
+	for _, pattern := range patterns {
+		// zglob normalizes paths to "/"
+		files, err := zglob.Glob(util.JoinPath(workingDir, "**", pattern))
+		if err != nil {
+			return err
+		}
+
+		tgHclFiles = append(tgHclFiles, files...)
 	}
 
 	filteredTgHclFiles := []string{}
@@ -90,7 +106,7 @@ func Run(opts *options.TerragruntOptions) error {
 	var formatErrors *errors.MultiError
 
 	for _, tgHclFile := range filteredTgHclFiles {
-		err := formatTgHCL(opts, tgHclFile)
+		err := formatFile(opts, tgHclFile)
 		if err != nil {
 			formatErrors = formatErrors.Append(err)
 		}
@@ -133,9 +149,233 @@ func formatFromStdin(opts *options.TerragruntOptions) error {
 	return nil
 }
 
+// formatFile formats the given file, as JSON if it is a JSON config file and the JSON formatting is enabled,
+// otherwise as HCL.
+func formatFile(opts *options.TerragruntOptions, path string) error {
+	if opts.HclIncludeJSON && isJSONFile(path) {
+		return formatJSON(opts, path)
+	}
+
+	return formatTgHCL(opts, path)
+}
+
 // formatTgHCL uses the hcl2 library to format the hcl file. This will attempt to parse the HCL file first to
 // ensure that there are no syntax errors, before attempting to format it.
 func formatTgHCL(opts *options.TerragruntOptions, tgHclFile string) error {
+	return rewriteFile(opts, tgHclFile, func(contents []byte) ([]byte, error) {
+		if err := checkErrors(opts.Logger, opts.DisableLogColors, contents, tgHclFile); err != nil {
+			opts.Logger.Errorf("Error parsing %s", tgHclFile)
+			return nil, err
+		}
+
+		return hclwrite.Format(contents), nil
+	})
+}
+
+// formatJSON rewrites the JSON config file into a canonical format: object keys sorted and two spaces indentation.
+func formatJSON(opts *options.TerragruntOptions, jsonFile string) error {
+	return rewriteFile(opts, jsonFile, func(contents []byte) ([]byte, error) {
+		newContents, err := canonicalJSON(contents)
+		if err != nil {
+			opts.Logger.Errorf("Error parsing %s", jsonFile)
+			return nil, errors.Errorf("failed to parse %s: %w", jsonFile, err)
+		}
+
+		return newContents, nil
+	})
+}
+
+// canonicalJSON returns the JSON contents with sorted object keys and two spaces indentation.
+// Numbers are kept as written, so that large numbers don't lose precision. The contents are read token by token rather
+// than decoded into maps, since HCL JSON allows an object to repeat a key, e.g. to declare several blocks of the same
+// type, and the repeated keys are kept in the order they are written.
+func canonicalJSON(contents []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(contents))
+	decoder.UseNumber()
+
+	value, err := decodeJSONValue(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.Errorf("unexpected data after the top-level value")
+	}
+
+	var buf bytes.Buffer
+
+	if err := value.write(&buf, ""); err != nil {
+		return nil, err
+	}
+
+	buf.WriteByte('\n')
+
+	return buf.Bytes(), nil
+}
+
+// jsonValue is a JSON value that keeps all the members of the objects, including the ones with repeated keys.
+type jsonValue struct {
+	// scalar is the string, number, bool or nil value, if the value is not an object or an array.
+	scalar  any
+	members []jsonMember
+	items   []*jsonValue
+	kind    json.Delim
+}
+
+// jsonMember is a member of a JSON object.
+type jsonMember struct {
+	value *jsonValue
+	key   string
+}
+
+// decodeJSONValue reads the next value of the decoder.
+func decodeJSONValue(decoder *json.Decoder) (*jsonValue, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return &jsonValue{scalar: token}, nil
+	}
+
+	value := &jsonValue{kind: delim}
+
+	for decoder.More() {
+		if delim == '[' {
+			item, err := decodeJSONValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+
+			value.items = append(value.items, item)
+
+			continue
+		}
+
+		keyToken, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		key, ok := keyToken.(string)
+		if !ok {
+			return nil, errors.Errorf("invalid object key %v", keyToken)
+		}
+
+		member, err := decodeJSONValue(decoder)
+		if err != nil {
+			return nil, err
+		}
+
+		value.members = append(value.members, jsonMember{key: key, value: member})
+	}
+
+	// the closing delimiter
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	// sorted the same way as encoding/json sorts the keys of the maps, keeping the repeated keys in their order
+	sort.SliceStable(value.members, func(i, j int) bool {
+		return value.members[i].key < value.members[j].key
+	})
+
+	return value, nil
+}
+
+// write writes the value with two spaces indentation, the same way as the encoding/json indentation.
+func (value *jsonValue) write(buf *bytes.Buffer, indent string) error {
+	switch value.kind {
+	case '{':
+		if len(value.members) == 0 {
+			buf.WriteString("{}")
+			return nil
+		}
+
+		buf.WriteString("{\n")
+
+		for i, member := range value.members {
+			buf.WriteString(indent + "  ")
+
+			if err := writeJSONScalar(buf, member.key); err != nil {
+				return err
+			}
+
+			buf.WriteString(": ")
+
+			if err := member.value.write(buf, indent+"  "); err != nil {
+				return err
+			}
+
+			if i < len(value.members)-1 {
+				buf.WriteByte(',')
+			}
+
+			buf.WriteByte('\n')
+		}
+
+		buf.WriteString(indent + "}")
+	case '[':
+		if len(value.items) == 0 {
+			buf.WriteString("[]")
+			return nil
+		}
+
+		buf.WriteString("[\n")
+
+		for i, item := range value.items {
+			buf.WriteString(indent + "  ")
+
+			if err := item.write(buf, indent+"  "); err != nil {
+				return err
+			}
+
+			if i < len(value.items)-1 {
+				buf.WriteByte(',')
+			}
+
+			buf.WriteByte('\n')
+		}
+
+		buf.WriteString(indent + "]")
+	default:
+		return writeJSONScalar(buf, value.scalar)
+	}
+
+	return nil
+}
+
+// writeJSONScalar writes the string, number, bool or nil value without escaping the HTML characters.
+func writeJSONScalar(buf *bytes.Buffer, scalar any) error {
+	var scalarBuf bytes.Buffer
+
+	encoder := json.NewEncoder(&scalarBuf)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(scalar); err != nil {
+		return err
+	}
+
+	buf.Write(bytes.TrimSuffix(scalarBuf.Bytes(), []byte("\n")))
+
+	return nil
+}
+
+func isJSONFile(path string) bool {
+	for _, extension := range jsonFileExtensions {
+		if strings.HasSuffix(path, strings.TrimPrefix(extension, "*")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rewriteFile rewrites the file with the contents returned by the format func, showing the diff and failing in check
+// mode if the contents change.
+func rewriteFile(opts *options.TerragruntOptions, tgHclFile string, format func(contents []byte) ([]byte, error)) error {
 	opts.Logger.Debugf("Formatting %s", tgHclFile)
 
 	info, err := os.Stat(tgHclFile)
@@ -152,18 +392,15 @@ func formatTgHCL(opts *options.TerragruntOptions, tgHclFile string) error {
 
 	contents := []byte(contentsStr)
 
-	err = checkErrors(opts.Logger, opts.DisableLogColors, contents, tgHclFile)
+	newContents, err := format(contents)
 	if err != nil {
-		opts.Logger.Errorf("Error parsing %s", tgHclFile)
 		return err
 	}
 
-	newContents := hclwrite.Format(contents)
-
 	fileUpdated := !bytes.Equal(newContents, contents)
 
 	if opts.Diff && fileUpdated {
-		diff, err := bytesDiff(opts, contents, newContents, tgHclFile)
+		diff, err := util.BytesDiff(opts.Logger, contents, newContents, tgHclFile)
 		if err != nil {
 			opts.Logger.Errorf("Failed to generate diff for %s", tgHclFile)
 			return err
@@ -207,53 +444,3 @@ func checkErrors(logger log.Logger, disableColor bool, contents []byte, tgHclFil
 
 	return nil
 }
-
-// bytesDiff uses GNU diff to display the differences between the contents of HCL file before and after formatting
-func bytesDiff(opts *options.TerragruntOptions, b1, b2 []byte, path string) ([]byte, error) {
-	f1, err := os.CreateTemp("", "")
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		if err := f1.Close(); err != nil {
-			opts.Logger.Warnf("Failed to close file %s %v", f1.Name(), err)
-		}
-
-		if err := os.Remove(f1.Name()); err != nil {
-			opts.Logger.Warnf("Failed to remove file %s %v", f1.Name(), err)
-		}
-	}()
-
-	f2, err := os.CreateTemp("", "")
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		if err := f2.Close(); err != nil {
-			opts.Logger.Warnf("Failed to close file %s %v", f2.Name(), err)
-		}
-
-		if err := os.Remove(f2.Name()); err != nil {
-			opts.Logger.Warnf("Failed to remove file %s %v", f2.Name(), err)
-		}
-	}()
-
-	if _, err := f1.Write(b1); err != nil {
-		return nil, err
-	}
-
-	if _, err := f2.Write(b2); err != nil {
-		return nil, err
-	}
-
-	data, err := exec.Command("diff", "--label="+filepath.Join("old", path), "--label="+filepath.Join("new/", path), "-u", f1.Name(), f2.Name()).CombinedOutput()
-	if len(data) > 0 {
-		// diff exits with a non-zero status when the files don't match.
-		// Ignore that failure as long as we get output.
-		err = nil
-	}
-
-	return data, err
-}
@@ -337,3 +337,96 @@ func TestHCLFmtHeredoc(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, expected, actual)
 }
+
+func TestHCLFmtIncludeJSON(t *testing.T) {
+	t.Parallel()
+
+	tmpPath, err := files.CopyFolderToTemp("./testdata/json", t.Name(), func(path string) bool { return true })
+
+	t.Cleanup(func() {
+		os.RemoveAll(tmpPath)
+	})
+
+	require.NoError(t, err)
+
+	tgOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	tgOptions.WorkingDir = tmpPath
+	tgOptions.Check = true
+
+	// JSON files are not formatted by default
+	err = hclfmt.Run(tgOptions)
+	require.NoError(t, err)
+
+	tgOptions.HclIncludeJSON = true
+
+	err = hclfmt.Run(tgOptions)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid file format "+filepath.Join(tmpPath, "main.tf.json"))
+	assert.Contains(t, err.Error(), "invalid file format "+filepath.Join(tmpPath, "a/terragrunt.hcl.json"))
+
+	tgOptions.Check = false
+
+	err = hclfmt.Run(tgOptions)
+	require.NoError(t, err)
+
+	for _, file := range []string{"main.tf.json", "a/terragrunt.hcl.json"} {
+		expected, err := os.ReadFile(filepath.Join("./testdata/expected-json", file))
+		require.NoError(t, err)
+
+		actual, err := os.ReadFile(filepath.Join(tmpPath, file))
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), string(actual), file)
+	}
+
+	// the canonicalized files pass the check
+	tgOptions.Check = true
+
+	err = hclfmt.Run(tgOptions)
+	require.NoError(t, err)
+}
+
+func TestHCLFmtIncludeJSONDuplicateKeys(t *testing.T) {
+	t.Parallel()
+
+	tmpPath := t.TempDir()
+
+	// HCL JSON declares several blocks of the same type by repeating the block type key
+	jsonFile := filepath.Join(tmpPath, "main.tf.json")
+	require.NoError(t, os.WriteFile(jsonFile, []byte(`{"resource": {"null_resource": {"b": {}}}, "output": {"name": {"value": "<b>"}},
+  "resource": {"null_resource": {"a": {"count": 2}}}}`), 0644))
+
+	tgOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	tgOptions.WorkingDir = tmpPath
+	tgOptions.HclIncludeJSON = true
+
+	require.NoError(t, hclfmt.Run(tgOptions))
+
+	expected := `{
+  "output": {
+    "name": {
+      "value": "<b>"
+    }
+  },
+  "resource": {
+    "null_resource": {
+      "b": {}
+    }
+  },
+  "resource": {
+    "null_resource": {
+      "a": {
+        "count": 2
+      }
+    }
+  }
+}
+`
+
+	actual, err := os.ReadFile(jsonFile)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(actual))
+}
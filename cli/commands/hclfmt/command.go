@@ -14,6 +14,7 @@ const (
 	FlagNameTerragruntCheck            = "terragrunt-check"
 	FlagNameTerragruntDiff             = "terragrunt-diff"
 	FlagNameTerragruntHCLFmtStdin      = "terragrunt-hclfmt-stdin"
+	FlagNameTerragruntHCLFmtJSON       = "terragrunt-hclfmt-include-json"
 )
 
 func NewFlags(opts *options.TerragruntOptions) cli.Flags {
@@ -47,6 +48,12 @@ func NewFlags(opts *options.TerragruntOptions) cli.Flags {
 			EnvVar:      "TERRAGRUNT_HCLFMT_STDIN",
 			Usage:       "Format HCL from stdin and print result to stdout.",
 		},
+		&cli.BoolFlag{
+			Name:        FlagNameTerragruntHCLFmtJSON,
+			Destination: &opts.HclIncludeJSON,
+			EnvVar:      "TERRAGRUNT_HCLFMT_INCLUDE_JSON",
+			Usage:       "Also rewrite .tf.json and .hcl.json files into a canonical format.",
+		},
 	}
 }
 
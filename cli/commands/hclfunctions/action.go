@@ -0,0 +1,26 @@
+package hclfunctions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gruntwork-io/terragrunt/config/hclparse"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+// Run parses the Terragrunt configuration and prints the names of the functions called in it, one per line.
+func Run(_ context.Context, opts *options.TerragruntOptions) error {
+	file, err := hclparse.NewParser().ParseFromFile(opts.TerragruntConfigPath)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range file.FunctionCalls() {
+		if _, err := fmt.Fprintln(opts.Writer, name); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package hclfunctions_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/hclfunctions"
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+func TestHCLFunctions(t *testing.T) {
+	t.Parallel()
+
+	cfg := `
+locals {
+  user   = get_env("USER", "nobody")
+  commit = run_cmd("--terragrunt-quiet", "git", "rev-parse", "HEAD")
+}
+
+inputs = {
+  name = upper(local.user)
+}
+`
+
+	configPath := filepath.Join(t.TempDir(), config.DefaultTerragruntConfigPath)
+	require.NoError(t, os.WriteFile(configPath, []byte(cfg), 0644))
+
+	opts, err := options.NewTerragruntOptionsForTest(configPath)
+	require.NoError(t, err)
+
+	var stdout bytes.Buffer
+	opts.Writer = &stdout
+
+	require.NoError(t, hclfunctions.Run(context.Background(), opts))
+	assert.Equal(t, "get_env\nrun_cmd\nupper\n", stdout.String())
+}
@@ -0,0 +1,23 @@
+// Package hclfunctions provides the `hclfunctions` command for Terragrunt.
+//
+// `hclfunctions` command statically analyzes the Terragrunt configuration and lists the functions called in its
+// expressions, without executing them. This is useful to review which functions, such as `run_cmd` or
+// `sops_decrypt_file`, a configuration invokes.
+package hclfunctions
+
+import (
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/cli"
+)
+
+const (
+	CommandName = "hclfunctions"
+)
+
+func NewCommand(opts *options.TerragruntOptions) *cli.Command {
+	return &cli.Command{
+		Name:   CommandName,
+		Usage:  "List the functions called in the Terragrunt configuration without executing them.",
+		Action: func(ctx *cli.Context) error { return Run(ctx, opts.OptionsFromContext(ctx)) },
+	}
+}
@@ -0,0 +1,125 @@
+package hclincludes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// Include is an include block of the configuration, as printed by the command.
+type Include struct {
+	Name          string                   `json:"name"`
+	Path          string                   `json:"path"`
+	MergeStrategy config.MergeStrategyType `json:"merge_strategy"`
+	Expose        bool                     `json:"expose"`
+}
+
+// Includes are the include blocks of the configuration, in the order they are declared, and the names of the includes
+// in the order they are merged into the configuration.
+type Includes struct {
+	ConfigPath string    `json:"config_path"`
+	Includes   []Include `json:"includes"`
+	MergeOrder []string  `json:"merge_order"`
+}
+
+// Run prints the include blocks of the Terragrunt configuration and the order they are merged in.
+func Run(ctx context.Context, opts *Options) error {
+	includes, err := ListIncludes(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.JSONOutput {
+		return writeJSON(opts, includes)
+	}
+
+	return writeText(opts, includes)
+}
+
+// ListIncludes returns the include blocks of the Terragrunt configuration and the order they are merged in.
+func ListIncludes(ctx context.Context, opts *Options) (*Includes, error) {
+	parsingCtx := config.NewParsingContext(ctx, opts.TerragruntOptions)
+
+	includeConfigs, err := config.ParseIncludes(parsingCtx, opts.TerragruntConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	mergeOrder, err := includeConfigs.MergeOrder()
+	if err != nil {
+		return nil, err
+	}
+
+	includes := &Includes{
+		ConfigPath: opts.TerragruntConfigPath,
+		Includes:   []Include{},
+		MergeOrder: []string{},
+	}
+
+	for _, include := range includeConfigs {
+		mergeStrategy, err := include.GetMergeStrategy()
+		if err != nil {
+			return nil, err
+		}
+
+		includes.Includes = append(includes.Includes, Include{
+			Name:          include.Name,
+			Path:          include.Path,
+			MergeStrategy: mergeStrategy,
+			Expose:        include.GetExpose(),
+		})
+	}
+
+	for _, include := range mergeOrder {
+		includes.MergeOrder = append(includes.MergeOrder, include.Name)
+	}
+
+	return includes, nil
+}
+
+func writeJSON(opts *Options, includes *Includes) error {
+	jsonBytes, err := json.MarshalIndent(includes, "", "  ")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if _, err := fmt.Fprintln(opts.Writer, string(jsonBytes)); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+func writeText(opts *Options, includes *Includes) error {
+	w := tabwriter.NewWriter(opts.Writer, 0, 0, 2, ' ', 0) //nolint:mnd
+
+	for _, include := range includes.Includes {
+		path := include.Path
+		if relPath, err := util.GetPathRelativeTo(include.Path, filepath.Dir(includes.ConfigPath)); err == nil {
+			path = relPath
+		}
+
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", include.Name, path, include.MergeStrategy); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return errors.New(err)
+	}
+
+	if len(includes.MergeOrder) > 0 {
+		if _, err := fmt.Fprintf(opts.Writer, "Merge order: %s\n", strings.Join(includes.MergeOrder, ", ")); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
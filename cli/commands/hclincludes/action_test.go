@@ -0,0 +1,70 @@
+package hclincludes_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/hclincludes"
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+func TestHCLIncludes(t *testing.T) {
+	t.Parallel()
+
+	fixturePath, err := filepath.Abs("testdata/fixtures")
+	require.NoError(t, err)
+
+	configPath := filepath.Join(fixturePath, "live", "app", config.DefaultTerragruntConfigPath)
+
+	generalOpts, err := options.NewTerragruntOptionsForTest(configPath)
+	require.NoError(t, err)
+
+	var stdout bytes.Buffer
+	generalOpts.Writer = &stdout
+
+	opts := hclincludes.NewOptions(generalOpts)
+	opts.JSONOutput = true
+
+	require.NoError(t, hclincludes.Run(context.Background(), opts))
+
+	var actual hclincludes.Includes
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &actual))
+
+	expected := hclincludes.Includes{
+		ConfigPath: configPath,
+		Includes: []hclincludes.Include{
+			{Name: "root", Path: filepath.ToSlash(filepath.Join(fixturePath, "root.hcl")), MergeStrategy: config.ShallowMerge},
+			{Name: "env", Path: filepath.ToSlash(filepath.Join(fixturePath, "live", "env.hcl")), MergeStrategy: config.DeepMerge},
+			{Name: "region", Path: filepath.ToSlash(filepath.Join(fixturePath, "live", "region.hcl")), MergeStrategy: config.NoMerge, Expose: true},
+		},
+		// the includes are merged bottom up, and the no_merge includes are not merged at all
+		MergeOrder: []string{"env", "root"},
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestHCLIncludesText(t *testing.T) {
+	t.Parallel()
+
+	generalOpts, err := options.NewTerragruntOptionsForTest(filepath.Join("testdata", "fixtures", "live", "app", config.DefaultTerragruntConfigPath))
+	require.NoError(t, err)
+
+	var stdout bytes.Buffer
+	generalOpts.Writer = &stdout
+
+	require.NoError(t, hclincludes.Run(context.Background(), hclincludes.NewOptions(generalOpts)))
+
+	expected := `root    ../../root.hcl  shallow
+env     ../env.hcl      deep
+region  ../region.hcl   no_merge
+Merge order: env, root
+`
+	assert.Equal(t, expected, stdout.String())
+}
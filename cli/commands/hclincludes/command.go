@@ -0,0 +1,40 @@
+// Package hclincludes provides the `hclincludes` command for Terragrunt.
+//
+// `hclincludes` command lists the include blocks of the Terragrunt configuration, with their resolved paths and merge
+// strategies, and the order in which the included configurations are merged. This is useful to understand where the
+// values of a configuration come from.
+package hclincludes
+
+import (
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/cli"
+)
+
+const (
+	CommandName = "hclincludes"
+
+	JSONOutputFlagName = "terragrunt-hclincludes-json"
+	JSONOutputEnvName  = "TERRAGRUNT_HCLINCLUDES_JSON"
+)
+
+func NewFlags(opts *Options) cli.Flags {
+	return cli.Flags{
+		&cli.BoolFlag{
+			Name:        JSONOutputFlagName,
+			EnvVar:      JSONOutputEnvName,
+			Destination: &opts.JSONOutput,
+			Usage:       "Output the result in JSON format.",
+		},
+	}
+}
+
+func NewCommand(generalOpts *options.TerragruntOptions) *cli.Command {
+	opts := NewOptions(generalOpts)
+
+	return &cli.Command{
+		Name:   CommandName,
+		Usage:  "List the includes of the Terragrunt configuration and the order they are merged in.",
+		Flags:  NewFlags(opts).Sort(),
+		Action: func(ctx *cli.Context) error { return Run(ctx, opts) },
+	}
+}
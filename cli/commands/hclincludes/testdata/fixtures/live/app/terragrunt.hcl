@@ -0,0 +1,18 @@
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+include "env" {
+  path           = "../env.hcl"
+  merge_strategy = "deep"
+}
+
+include "region" {
+  path           = "${get_terragrunt_dir()}/../region.hcl"
+  expose         = true
+  merge_strategy = "no_merge"
+}
+
+inputs = {
+  region = include.region.locals.region
+}
@@ -0,0 +1,3 @@
+inputs = {
+  owner = "platform"
+}
@@ -0,0 +1,697 @@
+package providercache
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/terraform/getproviders"
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+const (
+	// ManifestFilename is the name of the manifest file of an exported provider cache.
+	ManifestFilename = "manifest.json"
+
+	// ArchiveFilename is the name of the archive file of an exported provider cache.
+	ArchiveFilename = "providers.tar.gz"
+
+	// The provider cache has the same file structure as the terraform plugin_cache_dir, the package of a provider is
+	// located at `hostname/namespace/type/version/os_arch`.
+	packageDirDepth = 5
+)
+
+// Manifest lists the providers of an exported provider cache.
+type Manifest struct {
+	Providers []ManifestProvider `json:"providers"`
+}
+
+// ManifestProvider is a cached package of a provider for a single platform.
+type ManifestProvider struct {
+	// Address is the source address of the provider, e.g. `registry.terraform.io/hashicorp/aws`.
+	Address string `json:"address"`
+	// Version is the version of the provider, e.g. `5.36.0`.
+	Version string `json:"version"`
+	// Platform is the platform of the package, e.g. `linux_amd64`.
+	Platform string `json:"platform"`
+	// Hash is the `h1:` hash of the unpacked package, the same as recorded in the `.terraform.lock.hcl` files.
+	Hash getproviders.Hash `json:"hash"`
+}
+
+// Path returns the relative slash-separated path of the package in the provider cache.
+func (provider ManifestProvider) Path() string {
+	return path.Join(provider.Address, provider.Version, provider.Platform)
+}
+
+// validate checks that the address is made of a hostname, a namespace and a type, and that every part of the package
+// path is a single path segment, so that the package path of an untrusted manifest can't point outside of a directory.
+func (provider ManifestProvider) validate() error {
+	addressParts := strings.Split(provider.Address, "/")
+	if len(addressParts) != 3 { //nolint:mnd
+		return errors.Errorf("invalid provider address %q in the manifest, expected hostname/namespace/type", provider.Address)
+	}
+
+	for _, part := range append(addressParts, provider.Version, provider.Platform) {
+		if part == "" || part == "." || part == ".." || strings.ContainsAny(part, `/\`) {
+			return errors.Errorf("invalid path segment %q of the provider %s %s (%s) in the manifest", part, provider.Address, provider.Version, provider.Platform)
+		}
+	}
+
+	return nil
+}
+
+// RunExport writes the manifest and the archive of the providers of the provider cache to the export directory.
+func RunExport(ctx context.Context, opts *Options) error {
+	if opts.ExportDir == "" {
+		return errors.Errorf("the %s %s command requires the --%s flag", CommandName, ExportCommandName, ToFlagName)
+	}
+
+	cacheDir, err := providerCacheDir(opts)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := Export(ctx, cacheDir, opts.ExportDir)
+	if err != nil {
+		return err
+	}
+
+	opts.Logger.Infof("Exported %d provider packages from %s to %s", len(manifest.Providers), cacheDir, opts.ExportDir)
+
+	return nil
+}
+
+// RunImport verifies the providers of the manifest and the archive in the import directory and adds them to the provider cache.
+func RunImport(ctx context.Context, opts *Options) error {
+	if opts.ImportDir == "" {
+		return errors.Errorf("the %s %s command requires the --%s flag", CommandName, ImportCommandName, FromFlagName)
+	}
+
+	cacheDir, err := providerCacheDir(opts)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := Import(ctx, opts.ImportDir, cacheDir)
+	if err != nil {
+		return err
+	}
+
+	opts.Logger.Infof("Imported %d provider packages from %s to %s", len(manifest.Providers), opts.ImportDir, cacheDir)
+
+	return nil
+}
+
+// RunGC removes the providers of the provider cache whose versions are not referenced by any lock file of the working
+// directory, or, with the max age, that have not been accessed within the max age, and prints them.
+func RunGC(ctx context.Context, opts *Options) error {
+	cacheDir, err := providerCacheDir(opts)
+	if err != nil {
+		return err
+	}
+
+	var (
+		providers []ManifestProvider
+		reason    string
+	)
+
+	if opts.GCMaxAge != "" {
+		maxAge, err := parseMaxAge(opts.GCMaxAge)
+		if err != nil {
+			return err
+		}
+
+		reason = "not accessed within " + opts.GCMaxAge
+
+		if providers, err = GC(ctx, cacheDir, maxAge, opts.GCDryRun); err != nil {
+			return err
+		}
+	} else {
+		reason = "not referenced by the lock files of " + opts.WorkingDir
+
+		if providers, err = GCUnreferenced(ctx, cacheDir, opts.WorkingDir, opts.GCDryRun); err != nil {
+			return err
+		}
+	}
+
+	for _, provider := range providers {
+		if _, err := fmt.Fprintf(opts.Writer, "%s %s (%s)\n", provider.Address, provider.Version, provider.Platform); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	if opts.GCDryRun {
+		opts.Logger.Infof("%d provider packages %s would be removed from %s", len(providers), reason, cacheDir)
+	} else {
+		opts.Logger.Infof("Removed %d provider packages %s from %s", len(providers), reason, cacheDir)
+	}
+
+	return nil
+}
+
+// Export writes the manifest and the archive of the providers found in the given cache directory to the export directory.
+func Export(ctx context.Context, cacheDir, exportDir string) (*Manifest, error) {
+	manifest, err := readManifestFromCache(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(exportDir, os.ModePerm); err != nil {
+		return nil, errors.New(err)
+	}
+
+	if err := writeArchive(ctx, cacheDir, filepath.Join(exportDir, ArchiveFilename), manifest); err != nil {
+		return nil, err
+	}
+
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(exportDir, ManifestFilename), append(manifestBytes, '\n'), 0644); err != nil {
+		return nil, errors.New(err)
+	}
+
+	return manifest, nil
+}
+
+// Import extracts the archive of the import directory, verifies that every provider of the manifest is present in the
+// archive with the expected hash, and moves the providers into the given cache directory. Providers that are already
+// cached are left untouched. Nothing is added to the cache if any of the providers fails the verification.
+func Import(ctx context.Context, importDir, cacheDir string) (*Manifest, error) {
+	manifestBytes, err := os.ReadFile(filepath.Join(importDir, ManifestFilename))
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	manifest := new(Manifest)
+	if err := json.Unmarshal(manifestBytes, manifest); err != nil {
+		return nil, errors.Errorf("error parsing the manifest %s: %w", filepath.Join(importDir, ManifestFilename), err)
+	}
+
+	for _, provider := range manifest.Providers {
+		if err := provider.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := os.MkdirAll(cacheDir, os.ModePerm); err != nil {
+		return nil, errors.New(err)
+	}
+
+	// Extract next to the cache directory so that the verified packages can be renamed into the cache.
+	stagingDir, err := os.MkdirTemp(filepath.Dir(cacheDir), ".provider-cache-import-")
+	if err != nil {
+		return nil, errors.New(err)
+	}
+	defer os.RemoveAll(stagingDir) //nolint:errcheck
+
+	if err := extractArchive(ctx, filepath.Join(importDir, ArchiveFilename), stagingDir); err != nil {
+		return nil, err
+	}
+
+	for _, provider := range manifest.Providers {
+		if err := verifyProvider(stagingDir, provider); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, provider := range manifest.Providers {
+		packageDir := filepath.Join(cacheDir, filepath.FromSlash(provider.Path()))
+
+		if util.FileExists(packageDir) {
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(packageDir), os.ModePerm); err != nil {
+			return nil, errors.New(err)
+		}
+
+		if err := os.Rename(filepath.Join(stagingDir, filepath.FromSlash(provider.Path())), packageDir); err != nil {
+			return nil, errors.New(err)
+		}
+	}
+
+	return manifest, nil
+}
+
+// GC removes the providers of the given cache directory that have not been accessed within the max age, and returns them.
+// The last access time of a provider is the modification time of its package directory, which is updated by the provider
+// cache server every time the provider is requested. If dryRun is true, the providers are returned but not removed.
+func GC(ctx context.Context, cacheDir string, maxAge time.Duration, dryRun bool) ([]ManifestProvider, error) {
+	expiredBefore := time.Now().Add(-maxAge)
+
+	return removeProviders(ctx, cacheDir, dryRun, func(provider ManifestProvider, packageDir string) (bool, error) {
+		accessTime, err := packageAccessTime(packageDir)
+		if err != nil {
+			return false, err
+		}
+
+		return accessTime.Before(expiredBefore), nil
+	})
+}
+
+// GCUnreferenced removes the providers of the given cache directory whose versions are not referenced by any
+// `.terraform.lock.hcl` file found in the working directory and its subdirectories, and returns them. All the platforms of
+// a referenced version are kept. If dryRun is true, the providers are returned but not removed.
+func GCUnreferenced(ctx context.Context, cacheDir, workingDir string, dryRun bool) ([]ManifestProvider, error) {
+	lockedVersions, err := findLockedVersions(ctx, workingDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return removeProviders(ctx, cacheDir, dryRun, func(provider ManifestProvider, _ string) (bool, error) {
+		return !lockedVersions[path.Join(provider.Address, provider.Version)], nil
+	})
+}
+
+// removeProviders removes the providers of the given cache directory for which isGarbage returns true, and returns them.
+// If dryRun is true, the providers are returned but not removed.
+func removeProviders(ctx context.Context, cacheDir string, dryRun bool, isGarbage func(provider ManifestProvider, packageDir string) (bool, error)) ([]ManifestProvider, error) {
+	providers, err := listCachedProviders(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	removedProviders := []ManifestProvider{}
+
+	for _, provider := range providers {
+		if err := ctx.Err(); err != nil {
+			return nil, errors.New(err)
+		}
+
+		packageDir := filepath.Join(cacheDir, filepath.FromSlash(provider.Path()))
+
+		garbage, err := isGarbage(provider, packageDir)
+		if err != nil {
+			return nil, err
+		}
+
+		if !garbage {
+			continue
+		}
+
+		removedProviders = append(removedProviders, provider)
+
+		if dryRun {
+			continue
+		}
+
+		// For a symlink to the user plugins directory, only the symlink is removed.
+		if err := os.RemoveAll(packageDir); err != nil {
+			return nil, errors.New(err)
+		}
+
+		if err := removeEmptyParentDirs(cacheDir, packageDir); err != nil {
+			return nil, err
+		}
+	}
+
+	return removedProviders, nil
+}
+
+// findLockedVersions returns the provider versions, as `hostname/namespace/type/version` paths, locked by the
+// `.terraform.lock.hcl` files found in the given directory and its subdirectories.
+func findLockedVersions(ctx context.Context, dir string) (map[string]bool, error) {
+	lockedVersions := make(map[string]bool)
+
+	err := filepath.WalkDir(dir, func(filename string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return errors.New(err)
+		}
+
+		if err := ctx.Err(); err != nil {
+			return errors.New(err)
+		}
+
+		if entry.IsDir() || entry.Name() != util.TerraformLockFile {
+			return nil
+		}
+
+		return readLockedVersions(filename, lockedVersions)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return lockedVersions, nil
+}
+
+// readLockedVersions adds the provider versions locked by the given lock file to lockedVersions.
+func readLockedVersions(filename string, lockedVersions map[string]bool) error {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	file, diags := hclwrite.ParseConfig(content, filename, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return errors.Errorf("error parsing the lock file %s: %w", filename, diags)
+	}
+
+	for _, block := range file.Body().Blocks() {
+		if block.Type() != "provider" || len(block.Labels()) != 1 {
+			continue
+		}
+
+		attr := block.Body().GetAttribute("version")
+		if attr == nil {
+			continue
+		}
+
+		version := strings.Trim(strings.TrimSpace(string(attr.Expr().BuildTokens(nil).Bytes())), `"`)
+		lockedVersions[path.Join(block.Labels()[0], version)] = true
+	}
+
+	return nil
+}
+
+// packageAccessTime returns the last access time of the provider package. A package directory can be a symlink to the
+// user plugins directory, in which case the latest of the symlink creation and the access of its target is returned.
+func packageAccessTime(packageDir string) (time.Time, error) {
+	linkInfo, err := os.Lstat(packageDir)
+	if err != nil {
+		return time.Time{}, errors.New(err)
+	}
+
+	info, err := os.Stat(packageDir)
+	if err != nil {
+		return time.Time{}, errors.New(err)
+	}
+
+	if linkInfo.ModTime().After(info.ModTime()) {
+		return linkInfo.ModTime(), nil
+	}
+
+	return info.ModTime(), nil
+}
+
+// removeEmptyParentDirs removes the parent directories of the removed package directory, up to the cache directory, that are left empty.
+func removeEmptyParentDirs(cacheDir, packageDir string) error {
+	for dir := filepath.Dir(packageDir); dir != cacheDir && util.HasPathPrefix(dir, cacheDir); dir = filepath.Dir(dir) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return errors.New(err)
+		}
+
+		if len(entries) > 0 {
+			return nil
+		}
+
+		if err := os.Remove(dir); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
+
+// parseMaxAge parses the max age given as a Go duration, e.g. `36h`, or as a number of days, e.g. `30d`.
+func parseMaxAge(str string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(str, "d"); ok {
+		if num, err := strconv.ParseUint(days, 10, 32); err == nil {
+			return time.Duration(num) * 24 * time.Hour, nil //nolint:mnd
+		}
+	}
+
+	maxAge, err := time.ParseDuration(str)
+	if err != nil || maxAge < 0 {
+		return 0, errors.Errorf("invalid max age %q, expected a number of days, e.g. 30d, or a duration, e.g. 36h", str)
+	}
+
+	return maxAge, nil
+}
+
+// providerCacheDir returns the provider cache directory, the same as used by the provider cache server.
+func providerCacheDir(opts *Options) (string, error) {
+	cacheDir := opts.ProviderCacheDir
+
+	if cacheDir == "" {
+		userCacheDir, err := util.GetCacheDir()
+		if err != nil {
+			return "", err
+		}
+
+		cacheDir = filepath.Join(userCacheDir, "providers")
+	}
+
+	cacheDir, err := filepath.Abs(cacheDir)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	return cacheDir, nil
+}
+
+func readManifestFromCache(cacheDir string) (*Manifest, error) {
+	providers, err := listCachedProviders(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range providers {
+		packageDir := filepath.Join(cacheDir, filepath.FromSlash(providers[i].Path()))
+
+		if providers[i].Hash, err = getproviders.PackageHashV1(packageDir); err != nil {
+			return nil, errors.Errorf("error hashing the provider package %s: %w", packageDir, err)
+		}
+	}
+
+	return &Manifest{Providers: providers}, nil
+}
+
+// listCachedProviders returns the provider packages found in the given cache directory, without their hashes, sorted by path.
+func listCachedProviders(cacheDir string) ([]ManifestProvider, error) {
+	matches, err := filepath.Glob(filepath.Join(cacheDir, strings.Repeat("*"+string(filepath.Separator), packageDirDepth-1)+"*"))
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	providers := []ManifestProvider{}
+
+	for _, packageDir := range matches {
+		// Package directories can be symlinks to the user plugins directory, `os.Stat` follows them.
+		if info, err := os.Stat(packageDir); err != nil || !info.IsDir() {
+			continue
+		}
+
+		relPath, err := filepath.Rel(cacheDir, packageDir)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		parts := strings.Split(filepath.ToSlash(relPath), "/")
+
+		providers = append(providers, ManifestProvider{
+			Address:  strings.Join(parts[:3], "/"),
+			Version:  parts[3],
+			Platform: parts[4],
+		})
+	}
+
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i].Path() < providers[j].Path()
+	})
+
+	return providers, nil
+}
+
+func writeArchive(ctx context.Context, cacheDir, archivePath string, manifest *Manifest) error {
+	file, err := os.Create(archivePath)
+	if err != nil {
+		return errors.New(err)
+	}
+	defer file.Close() //nolint:errcheck
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for _, provider := range manifest.Providers {
+		if err := ctx.Err(); err != nil {
+			return errors.New(err)
+		}
+
+		packageDir, err := filepath.EvalSymlinks(filepath.Join(cacheDir, filepath.FromSlash(provider.Path())))
+		if err != nil {
+			return errors.New(err)
+		}
+
+		if err := writePackageToArchive(tarWriter, packageDir, provider.Path()); err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return errors.New(err)
+	}
+
+	if err := gzipWriter.Close(); err != nil {
+		return errors.New(err)
+	}
+
+	if err := file.Close(); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+func writePackageToArchive(tarWriter *tar.Writer, packageDir, archiveDir string) error {
+	return filepath.Walk(packageDir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return errors.New(err)
+		}
+
+		relPath, err := filepath.Rel(packageDir, filePath)
+		if err != nil {
+			return errors.New(err)
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			if info, err = os.Stat(filePath); err != nil {
+				return errors.New(err)
+			}
+		}
+
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			return errors.Errorf("unsupported file type of %s in the provider package %s", filePath, packageDir)
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return errors.New(err)
+		}
+
+		header.Name = path.Join(archiveDir, filepath.ToSlash(relPath))
+
+		if info.IsDir() {
+			header.Name += "/"
+		}
+
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return errors.New(err)
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		file, err := os.Open(filePath)
+		if err != nil {
+			return errors.New(err)
+		}
+		defer file.Close() //nolint:errcheck
+
+		if _, err := io.Copy(tarWriter, file); err != nil {
+			return errors.New(err)
+		}
+
+		return nil
+	})
+}
+
+func extractArchive(ctx context.Context, archivePath, dstDir string) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return errors.New(err)
+	}
+	defer file.Close() //nolint:errcheck
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return errors.Errorf("error reading the archive %s: %w", archivePath, err)
+	}
+	defer gzipReader.Close() //nolint:errcheck
+
+	tarReader := tar.NewReader(gzipReader)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return errors.New(err)
+		}
+
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return errors.Errorf("error reading the archive %s: %w", archivePath, err)
+		}
+
+		name := path.Clean(header.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return errors.Errorf("the archive %s contains the file %s outside of the provider cache", archivePath, header.Name)
+		}
+
+		dstPath := filepath.Join(dstDir, filepath.FromSlash(name))
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(dstPath, os.ModePerm); err != nil {
+				return errors.New(err)
+			}
+		case tar.TypeReg:
+			if err := extractFile(tarReader, dstPath, header.FileInfo().Mode().Perm()); err != nil {
+				return err
+			}
+		default:
+			return errors.Errorf("the archive %s contains the unsupported file %s", archivePath, header.Name)
+		}
+	}
+}
+
+func extractFile(reader io.Reader, dstPath string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(dstPath), os.ModePerm); err != nil {
+		return errors.New(err)
+	}
+
+	file, err := os.OpenFile(dstPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return errors.New(err)
+	}
+	defer file.Close() //nolint:errcheck
+
+	if _, err := io.Copy(file, reader); err != nil {
+		return errors.New(err)
+	}
+
+	if err := file.Close(); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+func verifyProvider(stagingDir string, provider ManifestProvider) error {
+	packageDir := filepath.Join(stagingDir, filepath.FromSlash(provider.Path()))
+
+	if !util.FileExists(packageDir) {
+		return errors.Errorf("the provider %s %s (%s) of the manifest is missing from the archive", provider.Address, provider.Version, provider.Platform)
+	}
+
+	hash, err := getproviders.PackageHashV1(packageDir)
+	if err != nil {
+		return errors.Errorf("error hashing the provider package %s: %w", packageDir, err)
+	}
+
+	if hash != provider.Hash {
+		return errors.Errorf("the provider %s %s (%s) has the hash %s, the manifest expects %s", provider.Address, provider.Version, provider.Platform, hash, provider.Hash)
+	}
+
+	return nil
+}
@@ -0,0 +1,302 @@
+package providercache_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	providercache "github.com/gruntwork-io/terragrunt/cli/commands/provider-cache"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/terraform/getproviders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testProviderFiles = map[string]string{
+	"registry.terraform.io/hashicorp/aws/5.36.0/darwin_arm64/terraform-provider-aws_v5.36.0_x5":    "aws darwin",
+	"registry.terraform.io/hashicorp/aws/5.36.0/linux_amd64/terraform-provider-aws_v5.36.0_x5":     "aws linux",
+	"registry.terraform.io/hashicorp/null/3.2.2/linux_amd64/terraform-provider-null_v3.2.2_x5":     "null linux",
+	"registry.terraform.io/hashicorp/null/3.2.2/linux_amd64/LICENSE.txt":                           "license",
+	"registry.opentofu.org/cloudflare/cloudflare/4.24.0/linux_amd64/terraform-provider-cloudflare": "cloudflare linux",
+}
+
+func populateCache(t *testing.T, cacheDir string) {
+	t.Helper()
+
+	for name, content := range testProviderFiles {
+		path := filepath.Join(cacheDir, filepath.FromSlash(name))
+
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0755))
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	t.Parallel()
+
+	srcCacheDir := filepath.Join(t.TempDir(), "providers")
+	populateCache(t, srcCacheDir)
+
+	exportDir := t.TempDir()
+
+	exported, err := providercache.Export(context.Background(), srcCacheDir, exportDir)
+	require.NoError(t, err)
+
+	assert.FileExists(t, filepath.Join(exportDir, providercache.ManifestFilename))
+	assert.FileExists(t, filepath.Join(exportDir, providercache.ArchiveFilename))
+
+	expectedPaths := []string{
+		"registry.opentofu.org/cloudflare/cloudflare/4.24.0/linux_amd64",
+		"registry.terraform.io/hashicorp/aws/5.36.0/darwin_arm64",
+		"registry.terraform.io/hashicorp/aws/5.36.0/linux_amd64",
+		"registry.terraform.io/hashicorp/null/3.2.2/linux_amd64",
+	}
+
+	var actualPaths []string
+	for _, provider := range exported.Providers {
+		actualPaths = append(actualPaths, provider.Path())
+	}
+
+	assert.Equal(t, expectedPaths, actualPaths)
+
+	dstCacheDir := filepath.Join(t.TempDir(), "providers")
+
+	imported, err := providercache.Import(context.Background(), exportDir, dstCacheDir)
+	require.NoError(t, err)
+	assert.Equal(t, exported, imported)
+
+	for _, provider := range exported.Providers {
+		hash, err := getproviders.PackageHashV1(filepath.Join(dstCacheDir, filepath.FromSlash(provider.Path())))
+		require.NoError(t, err)
+		assert.Equal(t, provider.Hash, hash)
+	}
+
+	for name, content := range testProviderFiles {
+		path := filepath.Join(dstCacheDir, filepath.FromSlash(name))
+
+		actual, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(actual))
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+	}
+}
+
+func TestImportHashMismatch(t *testing.T) {
+	t.Parallel()
+
+	srcCacheDir := filepath.Join(t.TempDir(), "providers")
+	populateCache(t, srcCacheDir)
+
+	exportDir := t.TempDir()
+
+	manifest, err := providercache.Export(context.Background(), srcCacheDir, exportDir)
+	require.NoError(t, err)
+
+	manifest.Providers[0].Hash = getproviders.HashScheme1.New("AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=")
+
+	manifestBytes, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(exportDir, providercache.ManifestFilename), manifestBytes, 0644))
+
+	dstCacheDir := filepath.Join(t.TempDir(), "providers")
+
+	_, err = providercache.Import(context.Background(), exportDir, dstCacheDir)
+	require.ErrorContains(t, err, "the manifest expects")
+
+	entries, err := os.ReadDir(dstCacheDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestImportInvalidManifest(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		provider func(provider *providercache.ManifestProvider)
+	}{
+		{"address outside the cache", func(provider *providercache.ManifestProvider) { provider.Address = "../../hashicorp/aws" }},
+		{"address with too many parts", func(provider *providercache.ManifestProvider) { provider.Address += "/../../.." }},
+		{"version outside the cache", func(provider *providercache.ManifestProvider) { provider.Version = "../../../../.." }},
+		{"platform with a separator", func(provider *providercache.ManifestProvider) { provider.Platform = "linux_amd64/.." }},
+		{"empty platform", func(provider *providercache.ManifestProvider) { provider.Platform = "" }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srcCacheDir := filepath.Join(t.TempDir(), "providers")
+			populateCache(t, srcCacheDir)
+
+			exportDir := t.TempDir()
+
+			manifest, err := providercache.Export(context.Background(), srcCacheDir, exportDir)
+			require.NoError(t, err)
+
+			tc.provider(&manifest.Providers[0])
+
+			manifestBytes, err := json.Marshal(manifest)
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(filepath.Join(exportDir, providercache.ManifestFilename), manifestBytes, 0644))
+
+			dstDir := t.TempDir()
+			dstCacheDir := filepath.Join(dstDir, "providers")
+
+			_, err = providercache.Import(context.Background(), exportDir, dstCacheDir)
+			require.ErrorContains(t, err, "in the manifest")
+
+			// nothing is moved into or next to the cache directory
+			entries, err := os.ReadDir(dstDir)
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+		})
+	}
+}
+
+func TestGC(t *testing.T) {
+	t.Parallel()
+
+	cacheDir := filepath.Join(t.TempDir(), "providers")
+	populateCache(t, cacheDir)
+
+	// a fresh symlink to an old package of the user plugins directory counts as recently accessed
+	userPackageDir := filepath.Join(t.TempDir(), "registry.terraform.io/hashicorp/template/2.2.0/darwin_arm64")
+	require.NoError(t, os.MkdirAll(userPackageDir, os.ModePerm))
+
+	symlinkPath := filepath.Join(cacheDir, "registry.terraform.io/hashicorp/template/2.2.0/darwin_arm64")
+	require.NoError(t, os.MkdirAll(filepath.Dir(symlinkPath), os.ModePerm))
+	require.NoError(t, os.Symlink(userPackageDir, symlinkPath))
+
+	oldTime := time.Now().Add(-40 * 24 * time.Hour)
+
+	for _, packageDir := range []string{
+		"registry.terraform.io/hashicorp/aws/5.36.0/darwin_arm64",
+		"registry.terraform.io/hashicorp/null/3.2.2/linux_amd64",
+		userPackageDir,
+	} {
+		if !filepath.IsAbs(packageDir) {
+			packageDir = filepath.Join(cacheDir, packageDir)
+		}
+
+		require.NoError(t, os.Chtimes(packageDir, oldTime, oldTime))
+	}
+
+	generalOpts, err := options.NewTerragruntOptionsForTest(filepath.Join(t.TempDir(), "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	var stdout bytes.Buffer
+	generalOpts.Writer = &stdout
+	generalOpts.ProviderCacheDir = cacheDir
+
+	opts := providercache.NewOptions(generalOpts)
+	opts.GCMaxAge = "30d"
+	opts.GCDryRun = true
+
+	require.NoError(t, providercache.RunGC(context.Background(), opts))
+
+	expectedOutput := `registry.terraform.io/hashicorp/aws 5.36.0 (darwin_arm64)
+registry.terraform.io/hashicorp/null 3.2.2 (linux_amd64)
+`
+	assert.Equal(t, expectedOutput, stdout.String())
+
+	// dry run removes nothing
+	for name := range testProviderFiles {
+		assert.FileExists(t, filepath.Join(cacheDir, filepath.FromSlash(name)))
+	}
+
+	removed, err := providercache.GC(context.Background(), cacheDir, 30*24*time.Hour, false)
+	require.NoError(t, err)
+	assert.Len(t, removed, 2)
+
+	assert.NoDirExists(t, filepath.Join(cacheDir, "registry.terraform.io/hashicorp/aws/5.36.0/darwin_arm64"))
+	assert.NoDirExists(t, filepath.Join(cacheDir, "registry.terraform.io/hashicorp/null"))
+	assert.DirExists(t, filepath.Join(cacheDir, "registry.terraform.io/hashicorp/aws/5.36.0/linux_amd64"))
+	assert.DirExists(t, filepath.Join(cacheDir, "registry.opentofu.org/cloudflare/cloudflare/4.24.0/linux_amd64"))
+	_, err = os.Lstat(symlinkPath)
+	require.NoError(t, err)
+	assert.DirExists(t, cacheDir)
+
+	opts.GCMaxAge = "a month"
+	require.ErrorContains(t, providercache.RunGC(context.Background(), opts), "invalid max age")
+}
+
+func TestGCUnreferenced(t *testing.T) {
+	t.Parallel()
+
+	cacheDir := filepath.Join(t.TempDir(), "providers")
+	populateCache(t, cacheDir)
+
+	// the only version not locked by any unit of the working dir
+	orphanFile := filepath.Join(cacheDir, "registry.terraform.io/hashicorp/aws/5.30.0/linux_amd64/terraform-provider-aws_v5.30.0_x5")
+	require.NoError(t, os.MkdirAll(filepath.Dir(orphanFile), os.ModePerm))
+	require.NoError(t, os.WriteFile(orphanFile, []byte("old aws linux"), 0755))
+
+	workingDir := t.TempDir()
+
+	lockFiles := map[string]string{
+		"app/.terraform.lock.hcl": `
+provider "registry.terraform.io/hashicorp/aws" {
+  version     = "5.36.0"
+  constraints = "~> 5.0"
+  hashes = [
+    "h1:aws",
+  ]
+}
+
+provider "registry.terraform.io/hashicorp/null" {
+  version = "3.2.2"
+  hashes = [
+    "h1:null",
+  ]
+}
+`,
+		"dns/.terragrunt-cache/abc/def/.terraform.lock.hcl": `
+provider "registry.opentofu.org/cloudflare/cloudflare" {
+  version = "4.24.0"
+}
+`,
+	}
+
+	for name, content := range lockFiles {
+		path := filepath.Join(workingDir, filepath.FromSlash(name))
+
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	generalOpts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	var stdout bytes.Buffer
+	generalOpts.Writer = &stdout
+	generalOpts.WorkingDir = workingDir
+	generalOpts.ProviderCacheDir = cacheDir
+
+	opts := providercache.NewOptions(generalOpts)
+	opts.GCDryRun = true
+
+	require.NoError(t, providercache.RunGC(context.Background(), opts))
+	assert.Equal(t, "registry.terraform.io/hashicorp/aws 5.30.0 (linux_amd64)\n", stdout.String())
+	assert.FileExists(t, orphanFile)
+
+	stdout.Reset()
+	opts.GCDryRun = false
+
+	require.NoError(t, providercache.RunGC(context.Background(), opts))
+	assert.Equal(t, "registry.terraform.io/hashicorp/aws 5.30.0 (linux_amd64)\n", stdout.String())
+
+	// only the orphaned version is removed, all the platforms of the locked versions are kept
+	assert.NoDirExists(t, filepath.Join(cacheDir, "registry.terraform.io/hashicorp/aws/5.30.0"))
+
+	for name := range testProviderFiles {
+		assert.FileExists(t, filepath.Join(cacheDir, filepath.FromSlash(name)))
+	}
+}
@@ -0,0 +1,114 @@
+// Package providercache provides the `provider-cache` command to move the contents of the provider cache between
+// machines.
+//
+// `provider-cache export` writes a manifest, listing the cached providers with their platforms and hashes, and an
+// archive of the cached providers. `provider-cache import` verifies the hashes of the archived providers against the
+// manifest and adds them to the provider cache. This allows warming up a cache on a connected machine and using it in
+// an air-gapped environment. `provider-cache gc` removes the providers that are not referenced by any lock file, or that
+// have not been accessed for a while, to keep shared caches from growing unbounded.
+package providercache
+
+import (
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/cli"
+)
+
+const (
+	CommandName       = "provider-cache"
+	ExportCommandName = "export"
+	ImportCommandName = "import"
+	GCCommandName     = "gc"
+
+	ToFlagName   = "to"
+	FromFlagName = "from"
+
+	MaxAgeFlagName = "max-age"
+	DryRunFlagName = "dry-run"
+)
+
+func NewExportFlags(opts *Options) cli.Flags {
+	return cli.Flags{
+		&cli.GenericFlag[string]{
+			Name:        ToFlagName,
+			Destination: &opts.ExportDir,
+			Usage:       "The directory to write the manifest and the archive of the provider cache to.",
+		},
+	}
+}
+
+func NewImportFlags(opts *Options) cli.Flags {
+	return cli.Flags{
+		&cli.GenericFlag[string]{
+			Name:        FromFlagName,
+			Destination: &opts.ImportDir,
+			Usage:       "The directory to read the manifest and the archive of the provider cache from.",
+		},
+	}
+}
+
+func NewGCFlags(opts *Options) cli.Flags {
+	return cli.Flags{
+		&cli.GenericFlag[string]{
+			Name:        MaxAgeFlagName,
+			Destination: &opts.GCMaxAge,
+			Usage:       "Remove the providers that have not been accessed within the age, e.g. 30d or 36h, instead of the providers not referenced by any lock file of the working dir.",
+		},
+		&cli.BoolFlag{
+			Name:        DryRunFlagName,
+			Destination: &opts.GCDryRun,
+			Usage:       "Print the providers that would be removed without removing them.",
+		},
+	}
+}
+
+func NewCommand(generalOpts *options.TerragruntOptions) *cli.Command {
+	opts := NewOptions(generalOpts)
+
+	return &cli.Command{
+		Name:  CommandName,
+		Usage: "Export and import the contents of the provider cache.",
+		Subcommands: cli.Commands{
+			newExportCommand(opts),
+			newImportCommand(opts),
+			newGCCommand(opts),
+		},
+		Action: func(ctx *cli.Context) error {
+			if name := ctx.Args().CommandName(); name != "" {
+				return errors.Errorf("unknown subcommand %q of the %s command", name, CommandName)
+			}
+
+			return errors.Errorf("the %s command requires a subcommand, e.g. `terragrunt %s %s`", CommandName, CommandName, ExportCommandName)
+		},
+	}
+}
+
+func newExportCommand(opts *Options) *cli.Command {
+	return &cli.Command{
+		Name:                   ExportCommandName,
+		Usage:                  "Export the providers of the provider cache to a manifest and an archive.",
+		DisallowUndefinedFlags: true,
+		Flags:                  NewExportFlags(opts).Sort(),
+		Action:                 func(ctx *cli.Context) error { return RunExport(ctx, opts) },
+	}
+}
+
+func newImportCommand(opts *Options) *cli.Command {
+	return &cli.Command{
+		Name:                   ImportCommandName,
+		Usage:                  "Import the providers of an exported manifest and archive into the provider cache.",
+		DisallowUndefinedFlags: true,
+		Flags:                  NewImportFlags(opts).Sort(),
+		Action:                 func(ctx *cli.Context) error { return RunImport(ctx, opts) },
+	}
+}
+
+func newGCCommand(opts *Options) *cli.Command {
+	return &cli.Command{
+		Name:                   GCCommandName,
+		Usage:                  "Remove the providers of the provider cache that are not referenced by any lock file of the working dir, or not accessed within the max age.",
+		DisallowUndefinedFlags: true,
+		Flags:                  NewGCFlags(opts).Sort(),
+		Action:                 func(ctx *cli.Context) error { return RunGC(ctx, opts) },
+	}
+}
@@ -0,0 +1,26 @@
+package providercache
+
+import "github.com/gruntwork-io/terragrunt/options"
+
+type Options struct {
+	*options.TerragruntOptions
+
+	// ExportDir is the directory the manifest and the archive are written to by the `export` subcommand.
+	ExportDir string
+
+	// ImportDir is the directory the manifest and the archive are read from by the `import` subcommand.
+	ImportDir string
+
+	// GCMaxAge is the age, e.g. `30d`, after which the providers that have not been accessed are removed by the `gc`
+	// subcommand. If empty, the providers not referenced by any lock file of the working dir are removed instead.
+	GCMaxAge string
+
+	// GCDryRun prints the providers that would be removed by the `gc` subcommand without removing them.
+	GCDryRun bool
+}
+
+func NewOptions(general *options.TerragruntOptions) *Options {
+	return &Options{
+		TerragruntOptions: general,
+	}
+}
@@ -0,0 +1,72 @@
+// `render-inputs` command takes the inputs of the parsed TerragruntConfig struct and renders them out as a
+// `.tfvars.json` file, which can be passed with `-var-file` to OpenTofu/Terraform to reproduce the run of the unit.
+
+package renderinputs
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/terraform"
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// Run renders the inputs of the unit in the given directory, or the working directory if unitDir is empty.
+func Run(ctx context.Context, opts *options.TerragruntOptions, unitDir string) error {
+	if unitDir != "" {
+		if !filepath.IsAbs(unitDir) {
+			unitDir = filepath.Join(opts.WorkingDir, unitDir)
+		}
+
+		unitOpts, err := opts.Clone(config.GetDefaultConfigPath(unitDir))
+		if err != nil {
+			return err
+		}
+
+		opts = unitOpts
+	}
+
+	target := terraform.NewTarget(terraform.TargetPointParseConfig, runRenderInputs)
+
+	return terraform.RunWithTarget(ctx, opts, target)
+}
+
+func runRenderInputs(ctx context.Context, opts *options.TerragruntOptions, cfg *config.TerragruntConfig) error {
+	if cfg == nil {
+		return errors.New("terragrunt was not able to render the inputs because it received no config. This is almost certainly a bug in Terragrunt. Please open an issue on github.com/gruntwork-io/terragrunt with this message and the contents of your terragrunt.hcl")
+	}
+
+	inputs := cfg.Inputs
+	if inputs == nil {
+		inputs = map[string]interface{}{}
+	}
+
+	jsonBytes, err := json.MarshalIndent(inputs, "", "  ")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	tfvarsOutPath := opts.TFVarsOut
+	if !filepath.IsAbs(tfvarsOutPath) {
+		terragruntConfigDir := filepath.Dir(opts.TerragruntConfigPath)
+		tfvarsOutPath = filepath.Join(terragruntConfigDir, tfvarsOutPath)
+	}
+
+	if err := util.EnsureDirectory(filepath.Dir(tfvarsOutPath)); err != nil {
+		return err
+	}
+
+	opts.Logger.Debugf("Rendering inputs of config %s to tfvars JSON %s", opts.TerragruntConfigPath, tfvarsOutPath)
+
+	const ownerWriteGlobalReadPerms = 0644
+	if err := os.WriteFile(tfvarsOutPath, append(jsonBytes, '\n'), ownerWriteGlobalReadPerms); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+// Package renderinputs provides the command to render the inputs of a terragrunt config, with all variables, includes,
+// and functions resolved, as a tfvars json file.
+package renderinputs
+
+import (
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/cli"
+)
+
+const (
+	CommandName = "render-inputs"
+
+	FlagNameTerragruntTFVarsOut = "terragrunt-tfvars-out"
+)
+
+func NewFlags(opts *options.TerragruntOptions) cli.Flags {
+	return cli.Flags{
+		&cli.GenericFlag[string]{
+			Name:        FlagNameTerragruntTFVarsOut,
+			Destination: &opts.TFVarsOut,
+			Usage:       "The file path that terragrunt should use when rendering the inputs of the terragrunt.hcl config as tfvars json.",
+		},
+	}
+}
+
+func NewCommand(opts *options.TerragruntOptions) *cli.Command {
+	return &cli.Command{
+		Name:        CommandName,
+		Usage:       "Render the inputs of the terragrunt config, with all variables, includes, and functions resolved, as a tfvars json file.",
+		Description: "This is useful for reproducing the run of a unit with plain OpenTofu/Terraform, by passing the rendered file with -var-file.",
+		Flags:       NewFlags(opts).Sort(),
+		Action: func(ctx *cli.Context) error {
+			return Run(ctx, opts.OptionsFromContext(ctx), ctx.Args().Get(0))
+		},
+	}
+}
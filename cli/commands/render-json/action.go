@@ -1,5 +1,6 @@
-// `render-json` command takes the parsed TerragruntConfig struct and renders it out as JSON so that it can be processed by
-// other tools. To make it easier to maintain, this uses the cty representation as an intermediary.
+// `render-json` command takes the parsed TerragruntConfig struct and renders it out as JSON, or YAML, so that it can be
+// processed by other tools. To make it easier to maintain, this uses the cty representation as an intermediary, which is
+// converted to plain Go values shared by both formats.
 // NOTE: An unspecified advantage of using the cty representation is that the final block outputs would be a map
 // representation, which is easier to work with than the list representation that will be returned by a naive go-struct
 // to json conversion.
@@ -11,11 +12,13 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/gruntwork-io/terragrunt/configstack"
 
 	"github.com/zclconf/go-cty/cty"
 	ctyjson "github.com/zclconf/go-cty/cty/json"
+	"gopkg.in/yaml.v3"
 
 	"github.com/gruntwork-io/terragrunt/cli/commands/terraform"
 	"github.com/gruntwork-io/terragrunt/config"
@@ -24,15 +27,215 @@ import (
 	"github.com/gruntwork-io/terragrunt/util"
 )
 
+const ownerWriteGlobalReadPerms = 0644
+
+// BundleUnit is the entry of a unit in the bundle rendered with --all: either the rendered config of the unit, or the
+// error its config failed to render with.
+type BundleUnit struct {
+	Config map[string]any `json:"config,omitempty" yaml:"config,omitempty"`
+	Error  string         `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// NDJSONUnit is a line printed with the ndjson format: the entry of a unit, as in the bundle, along with the path of
+// the unit relative to the root working dir.
+type NDJSONUnit struct {
+	Path string `json:"path"`
+	*BundleUnit
+}
+
 func Run(ctx context.Context, opts *options.TerragruntOptions) error {
+	switch opts.RenderJSONFormat {
+	case FormatJSON, FormatYAML, FormatNDJSON:
+	default:
+		return errors.Errorf("unsupported format %q for --%s, expected %s, %s or %s", opts.RenderJSONFormat, FlagNameFormat, FormatJSON, FormatYAML, FormatNDJSON)
+	}
+
+	if opts.RenderJSONAll {
+		return runRenderJSONBundle(ctx, opts)
+	}
+
 	target := terraform.NewTarget(terraform.TargetPointParseConfig, runRenderJSON)
 
 	return terraform.RunWithTarget(ctx, opts, target)
 }
 
+// runRenderJSONBundle renders the config of all the units in the working dir into a single JSON object keyed by the
+// unit path relative to the working dir. A unit whose config fails to render does not stop the rendering of the
+// others, its error is recorded in the bundle instead.
+func runRenderJSONBundle(ctx context.Context, opts *options.TerragruntOptions) error {
+	configPaths, err := config.FindConfigFilesInPath(opts.WorkingDir, opts)
+	if err != nil {
+		return err
+	}
+
+	bundle := make(map[string]*BundleUnit, len(configPaths))
+
+	for _, configPath := range configPaths {
+		unitPath := filepath.Dir(configPath)
+
+		name, err := filepath.Rel(opts.WorkingDir, unitPath)
+		if err != nil {
+			name = unitPath
+		}
+
+		unitOpts, err := opts.Clone(configPath)
+		if err != nil {
+			return err
+		}
+
+		unit := &BundleUnit{}
+
+		target := terraform.NewTarget(terraform.TargetPointParseConfig, func(ctx context.Context, opts *options.TerragruntOptions, cfg *config.TerragruntConfig) error {
+			configVal, err := renderConfigValue(ctx, opts, cfg)
+			if err != nil {
+				return err
+			}
+
+			unit.Config = configVal
+
+			return nil
+		})
+
+		if err := terraform.RunWithTarget(ctx, unitOpts, target); err != nil {
+			opts.Logger.Warnf("Failed to render the config of %s as json: %v", name, err)
+
+			unit.Config = nil
+			unit.Error = err.Error()
+		}
+
+		// with the ndjson format, each unit is printed as soon as it is rendered, instead of being written in the bundle
+		if opts.RenderJSONFormat == FormatNDJSON {
+			if err := writeNDJSONUnit(opts, filepath.ToSlash(name), unit); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		bundle[filepath.ToSlash(name)] = unit
+	}
+
+	if opts.RenderJSONFormat == FormatNDJSON {
+		return nil
+	}
+
+	bundleBytes, err := marshalRendered(opts, bundle)
+	if err != nil {
+		return err
+	}
+
+	bundleOutPath := renderOutPath(opts, opts.RenderJSONBundleOut, options.DefaultRenderJSONBundleOutName)
+	if !filepath.IsAbs(bundleOutPath) {
+		bundleOutPath = filepath.Join(opts.WorkingDir, bundleOutPath)
+	}
+
+	if err := util.EnsureDirectory(filepath.Dir(bundleOutPath)); err != nil {
+		return err
+	}
+
+	opts.Logger.Debugf("Rendering the config of %d units to %s %s", len(bundle), strings.ToUpper(opts.RenderJSONFormat), bundleOutPath)
+
+	if err := os.WriteFile(bundleOutPath, bundleBytes, ownerWriteGlobalReadPerms); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
 func runRenderJSON(ctx context.Context, opts *options.TerragruntOptions, cfg *config.TerragruntConfig) error {
+	configVal, err := renderConfigValue(ctx, opts, cfg)
+	if err != nil {
+		return err
+	}
+
+	// with the ndjson format, the config is printed as a line to stdout, so that the configs of the units run with
+	// `run-all render-json` are printed as each unit is rendered
+	if opts.RenderJSONFormat == FormatNDJSON {
+		return writeNDJSONUnit(opts, unitPath(opts), &BundleUnit{Config: configVal})
+	}
+
+	configBytes, err := marshalRendered(opts, configVal)
+	if err != nil {
+		return err
+	}
+
+	jsonOutPath := renderOutPath(opts, opts.JSONOut, options.DefaultJSONOutName)
+	if !filepath.IsAbs(jsonOutPath) {
+		terragruntConfigDir := filepath.Dir(opts.TerragruntConfigPath)
+		jsonOutPath = filepath.Join(terragruntConfigDir, jsonOutPath)
+	}
+
+	if err := util.EnsureDirectory(filepath.Dir(jsonOutPath)); err != nil {
+		return err
+	}
+
+	opts.Logger.Debugf("Rendering config %s to %s %s", opts.TerragruntConfigPath, strings.ToUpper(opts.RenderJSONFormat), jsonOutPath)
+
+	if err := os.WriteFile(jsonOutPath, configBytes, ownerWriteGlobalReadPerms); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// writeNDJSONUnit prints the given unit as a single line of JSON to stdout. The line is written at once, so that the lines
+// of units rendered concurrently are not mixed.
+func writeNDJSONUnit(opts *options.TerragruntOptions, path string, unit *BundleUnit) error {
+	line, err := json.Marshal(NDJSONUnit{Path: path, BundleUnit: unit})
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if _, err := opts.Writer.Write(append(line, '\n')); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// unitPath returns the path of the unit relative to the root working dir, e.g. the directory `run-all` is run in.
+func unitPath(opts *options.TerragruntOptions) string {
+	unitDir := filepath.Dir(opts.TerragruntConfigPath)
+
+	path, err := filepath.Rel(opts.RootWorkingDir, unitDir)
+	if err != nil {
+		return filepath.ToSlash(unitDir)
+	}
+
+	return filepath.ToSlash(path)
+}
+
+// renderOutPath returns the given output path, with the `.yaml` extension instead of `.json` if it is the default one
+// and the config is rendered as YAML.
+func renderOutPath(opts *options.TerragruntOptions, outPath, defaultOutPath string) string {
+	if opts.RenderJSONFormat == FormatYAML && outPath == defaultOutPath {
+		return strings.TrimSuffix(outPath, ".json") + ".yaml"
+	}
+
+	return outPath
+}
+
+// marshalRendered marshals the given rendered value in the format of the command, JSON or YAML.
+func marshalRendered(opts *options.TerragruntOptions, val any) ([]byte, error) {
+	var (
+		out []byte
+		err error
+	)
+
+	if opts.RenderJSONFormat == FormatYAML {
+		out, err = yaml.Marshal(val)
+	} else {
+		out, err = json.Marshal(val)
+	}
+
+	return out, errors.New(err)
+}
+
+// renderConfigValue renders the given config as plain Go values, i.e. maps, slices and scalars, that can be marshaled
+// into any format.
+func renderConfigValue(ctx context.Context, opts *options.TerragruntOptions, cfg *config.TerragruntConfig) (map[string]any, error) {
 	if cfg == nil {
-		return errors.New("terragrunt was not able to render the config as json because it received no config. This is almost certainly a bug in Terragrunt. Please open an issue on github.com/gruntwork-io/terragrunt with this message and the contents of your terragrunt.hcl")
+		return nil, errors.New("terragrunt was not able to render the config as json because it received no config. This is almost certainly a bug in Terragrunt. Please open an issue on github.com/gruntwork-io/terragrunt with this message and the contents of your terragrunt.hcl")
 	}
 
 	if !opts.JSONDisableDependentModules {
@@ -52,49 +255,27 @@ func runRenderJSON(ctx context.Context, opts *options.TerragruntOptions, cfg *co
 	if opts.RenderJSONWithMetadata {
 		cty, err := config.TerragruntConfigAsCtyWithMetadata(cfg)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		terragruntConfigCty = cty
 	} else {
 		cty, err := config.TerragruntConfigAsCty(cfg)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		terragruntConfigCty = cty
 	}
 
-	jsonBytes, err := marshalCtyValueJSONWithoutType(terragruntConfigCty)
-	if err != nil {
-		return err
-	}
-
-	jsonOutPath := opts.JSONOut
-	if !filepath.IsAbs(jsonOutPath) {
-		terragruntConfigDir := filepath.Dir(opts.TerragruntConfigPath)
-		jsonOutPath = filepath.Join(terragruntConfigDir, jsonOutPath)
-	}
-
-	if err := util.EnsureDirectory(filepath.Dir(jsonOutPath)); err != nil {
-		return err
-	}
-
-	opts.Logger.Debugf("Rendering config %s to JSON %s", opts.TerragruntConfigPath, jsonOutPath)
-
-	const ownerWriteGlobalReadPerms = 0644
-	if err := os.WriteFile(jsonOutPath, jsonBytes, ownerWriteGlobalReadPerms); err != nil {
-		return errors.New(err)
-	}
-
-	return nil
+	return ctyValueWithoutType(terragruntConfigCty)
 }
 
-// marshalCtyValueJSONWithoutType marshals the given cty.Value object into a JSON object that does not have the type.
-// Using ctyjson directly would render a json object with two attributes, "value" and "type", and this function returns
-// just the "value".
+// ctyValueWithoutType converts the given cty.Value object into plain Go values that do not have the type. Using ctyjson
+// directly would render a json object with two attributes, "value" and "type", and this function returns just the
+// "value".
 // NOTE: We have to do two marshalling passes so that we can extract just the value.
-func marshalCtyValueJSONWithoutType(ctyVal cty.Value) ([]byte, error) {
+func ctyValueWithoutType(ctyVal cty.Value) (map[string]any, error) {
 	jsonBytesIntermediate, err := ctyjson.Marshal(ctyVal, cty.DynamicPseudoType)
 	if err != nil {
 		return nil, errors.New(err)
@@ -105,7 +286,5 @@ func marshalCtyValueJSONWithoutType(ctyVal cty.Value) ([]byte, error) {
 		return nil, errors.New(err)
 	}
 
-	jsonBytes, err := json.Marshal(ctyJSONOutput.Value)
-
-	return jsonBytes, errors.New(err)
+	return ctyJSONOutput.Value, nil
 }
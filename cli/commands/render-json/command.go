@@ -12,6 +12,13 @@ const (
 	FlagNameTerragruntJSONOut       = "terragrunt-json-out"
 	FlagNameWithMetadata            = "with-metadata"
 	FlagNameDisableDependentModules = "terragrunt-json-disable-dependent-modules"
+	FlagNameAll                     = "all"
+	FlagNameOut                     = "out"
+	FlagNameFormat                  = "format"
+
+	FormatJSON   = "json"
+	FormatYAML   = "yaml"
+	FormatNDJSON = "ndjson"
 )
 
 func NewFlags(opts *options.TerragruntOptions) cli.Flags {
@@ -32,6 +39,21 @@ func NewFlags(opts *options.TerragruntOptions) cli.Flags {
 			Destination: &opts.JSONDisableDependentModules,
 			Usage:       "Disable identification of dependent modules rendering json config.",
 		},
+		&cli.BoolFlag{
+			Name:        FlagNameAll,
+			Destination: &opts.RenderJSONAll,
+			Usage:       "Render the config of all the units in the working dir into a single JSON bundle keyed by unit path.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        FlagNameOut,
+			Destination: &opts.RenderJSONBundleOut,
+			Usage:       "The file path that terragrunt should use when rendering the bundle of the configs with --all.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        FlagNameFormat,
+			Destination: &opts.RenderJSONFormat,
+			Usage:       "The format to render the config in: json, yaml, or ndjson to print a line per unit to stdout.",
+		},
 	}
 }
 
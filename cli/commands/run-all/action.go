@@ -2,6 +2,7 @@ package runall
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/gruntwork-io/terragrunt/configstack"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
@@ -57,6 +58,21 @@ func RunAllOnStack(ctx context.Context, opts *options.TerragruntOptions, stack *
 		return err
 	}
 
+	if opts.PrintExecutionPlan || opts.ExecutionPlanOnly {
+		js, err := stack.JSONExecutionPlan(opts.TerraformCommand)
+		if err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprintf(opts.Writer, "%s\n", js); err != nil {
+			return errors.New(err)
+		}
+
+		if opts.ExecutionPlanOnly {
+			return nil
+		}
+	}
+
 	var prompt string
 
 	switch opts.TerraformCommand {
@@ -3,6 +3,8 @@ package runall
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"sort"
 
 	"github.com/gruntwork-io/terragrunt/cli/commands"
@@ -14,6 +16,7 @@ import (
 	"github.com/gruntwork-io/terragrunt/cli/commands/terraform"
 	terragruntinfo "github.com/gruntwork-io/terragrunt/cli/commands/terragrunt-info"
 	validateinputs "github.com/gruntwork-io/terragrunt/cli/commands/validate-inputs"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/pkg/cli"
 )
@@ -47,11 +50,129 @@ func NewFlags(opts *options.TerragruntOptions) cli.Flags {
 			Destination: &opts.JSONOutputFolder,
 			Usage:       "Directory to store json plan files.",
 		},
+		&cli.GenericFlag[string]{
+			Name:        commands.TerragruntUnitLogsDirFlagName,
+			EnvVar:      commands.TerragruntUnitLogsDirFlagEnvName,
+			Destination: &opts.UnitLogsFolder,
+			Usage:       "Directory to store the stdout/stderr of each unit in a separate log file.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        commands.TerragruntOutputModeFlagName,
+			EnvVar:      commands.TerragruntOutputModeFlagEnvName,
+			DefaultText: string(opts.OutputMode),
+			Usage:       fmt.Sprintf("Controls how the output of the units is written. Supported modes: %v", options.AllOutputModes),
+			Action: func(_ *cli.Context, val string) error {
+				mode := options.OutputMode(val)
+
+				if !slices.Contains(options.AllOutputModes, mode) {
+					return cli.NewExitError(errors.Errorf("flag --%s, invalid output mode %q, supported modes: %v", commands.TerragruntOutputModeFlagName, val, options.AllOutputModes), 1)
+				}
+
+				opts.OutputMode = mode
+
+				return nil
+			},
+		},
+		&cli.GenericFlag[string]{
+			Name:        commands.TerragruntOutputOrderFlagName,
+			EnvVar:      commands.TerragruntOutputOrderFlagEnvName,
+			DefaultText: string(opts.OutputOrder),
+			Usage:       fmt.Sprintf("Controls in which order the output of the units is written, e.g. of `output`. Supported orders: %v", options.AllOutputOrders),
+			Action: func(_ *cli.Context, val string) error {
+				order := options.OutputOrder(val)
+
+				if !slices.Contains(options.AllOutputOrders, order) {
+					return cli.NewExitError(errors.Errorf("flag --%s, invalid output order %q, supported orders: %v", commands.TerragruntOutputOrderFlagName, val, options.AllOutputOrders), 1)
+				}
+
+				opts.OutputOrder = order
+
+				return nil
+			},
+		},
+		&cli.GenericFlag[int64]{
+			Name:        commands.TerragruntOutputSpillThresholdFlagName,
+			EnvVar:      commands.TerragruntOutputSpillThresholdFlagEnvName,
+			Destination: &opts.OutputSpillThreshold,
+			Usage:       "Spill the buffered output of a unit to a temporary file on disk once it exceeds this size in bytes, to bound the memory used by large outputs.",
+		},
+		&cli.BoolFlag{
+			Name:        commands.TerragruntCheckProviderConsistencyFlagName,
+			EnvVar:      commands.TerragruntCheckProviderConsistencyFlagEnvName,
+			Destination: &opts.CheckProviderConsistency,
+			Usage:       "Fail if the same provider is pinned to different versions across units.",
+		},
+		&cli.BoolFlag{
+			Name:        commands.TerragruntSkipNoChangesFlagName,
+			EnvVar:      commands.TerragruntSkipNoChangesFlagEnvName,
+			Destination: &opts.SkipNoChanges,
+			Usage:       "Run plan before apply and skip the units without changes, unless one of their dependencies has changes.",
+		},
+		&cli.BoolFlag{
+			Name:        commands.TerragruntPrintExecutionPlanFlagName,
+			EnvVar:      commands.TerragruntPrintExecutionPlanFlagEnvName,
+			Destination: &opts.PrintExecutionPlan,
+			Usage:       "Print the execution plan, the units to run in order with their dependencies and why they are included, as JSON before running them.",
+		},
+		&cli.BoolFlag{
+			Name:        commands.TerragruntExecutionPlanOnlyFlagName,
+			EnvVar:      commands.TerragruntExecutionPlanOnlyFlagEnvName,
+			Destination: &opts.ExecutionPlanOnly,
+			Usage:       "Print the execution plan as JSON and exit without running the units.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        commands.TerragruntMetricsFileFlagName,
+			EnvVar:      commands.TerragruntMetricsFileFlagEnvName,
+			Destination: &opts.MetricsFile,
+			Usage:       "Write the metrics of the run, such as the duration and the result of each unit, to this file in the Prometheus text format.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        commands.TerragruntEventsOutFlagName,
+			EnvVar:      commands.TerragruntEventsOutFlagEnvName,
+			Destination: &opts.EventsOut,
+			Usage:       "Stream the events of the run, such as units starting, finishing and failing, as JSON lines to this file, or to stdout with '-'.",
+		},
+		&cli.BoolFlag{
+			Name:        commands.TerragruntWarnLocalStateFlagName,
+			EnvVar:      commands.TerragruntWarnLocalStateFlagEnvName,
+			Destination: &opts.WarnLocalState,
+			Usage:       "Warn about the units that have neither a remote_state block nor a generate block configuring a backend.",
+		},
+		&cli.BoolFlag{
+			Name:        commands.TerragruntQueueShuffleFlagName,
+			EnvVar:      commands.TerragruntQueueShuffleFlagEnvName,
+			Destination: &opts.QueueShuffle,
+			Usage:       "Randomize the order in which the units are run, while respecting their dependencies. The seed of the shuffle is printed to reproduce the order.",
+		},
+		&cli.GenericFlag[int64]{
+			Name:        commands.TerragruntQueueShuffleSeedFlagName,
+			EnvVar:      commands.TerragruntQueueShuffleSeedFlagEnvName,
+			Destination: &opts.QueueShuffleSeed,
+			Usage:       "The seed of the shuffle of the units, implies --terragrunt-queue-shuffle.",
+			Action: func(_ *cli.Context, _ int64) error {
+				opts.QueueShuffle = true
+				return nil
+			},
+		},
+		&cli.BoolFlag{
+			Name:        commands.TerragruntSequentialFlagName,
+			EnvVar:      commands.TerragruntSequentialFlagEnvName,
+			Destination: &opts.Sequential,
+			Usage:       "Run the units one at a time, in dependency order then sorted by path, to make the run reproducible when debugging.",
+		},
 	}
 }
 
 func action(opts *options.TerragruntOptions) cli.ActionFunc {
 	return func(cliCtx *cli.Context) error {
+		// the subcommands are skipped from running, parse their flags, such as `render-json --format`, before the
+		// options are cloned for each unit
+		if cmd := cliCtx.Command.Subcommand(opts.TerraformCommand); cmd != nil && len(opts.TerraformCliArgs) > 0 {
+			if _, err := cmd.ParseFlags(opts.TerraformCliArgs[1:]); err != nil {
+				return err
+			}
+		}
+
 		opts.RunTerragrunt = func(ctx context.Context, opts *options.TerragruntOptions) error {
 			if cmd := cliCtx.Command.Subcommand(opts.TerraformCommand); cmd != nil {
 				cliCtx := cliCtx.WithValue(options.ContextKey, opts)
@@ -0,0 +1,173 @@
+package source
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// RunBump replaces the `ref` of the module sources of the units in the working directory that are pinned to the
+// `--from` ref with the `--to` ref. Only the ref in the `source` attribute of the `terraform` block is rewritten, the
+// rest of the config files is left untouched.
+func RunBump(opts *options.TerragruntOptions) error {
+	if opts.SourceBumpFrom == "" || opts.SourceBumpTo == "" {
+		return errors.Errorf("the --%s and --%s flags are required", FromFlagName, ToFlagName)
+	}
+
+	refRegexp := regexp.MustCompile(`([?&]ref=)` + regexp.QuoteMeta(opts.SourceBumpFrom) + `(&|\s|$)`)
+
+	configFiles, err := config.FindConfigFilesInPath(opts.WorkingDir, opts)
+	if err != nil {
+		return err
+	}
+
+	sort.Strings(configFiles)
+
+	bumped := 0
+
+	for _, configFile := range configFiles {
+		if filepath.Ext(configFile) == ".json" {
+			opts.Logger.Debugf("Skipping %s, the module sources of JSON configs are not bumped", configFile)
+			continue
+		}
+
+		changes, err := bumpConfigFile(opts, configFile, refRegexp)
+		if err != nil {
+			return err
+		}
+
+		relPath, err := util.GetPathRelativeTo(configFile, opts.WorkingDir)
+		if err != nil {
+			return err
+		}
+
+		for _, change := range changes {
+			if _, err := fmt.Fprintf(opts.Writer, "%s: %s -> %s\n", relPath, change.from, change.to); err != nil {
+				return errors.New(err)
+			}
+		}
+
+		if len(changes) > 0 {
+			bumped++
+		}
+	}
+
+	if opts.SourceBumpDryRun {
+		opts.Logger.Infof("Dry run, %d unit(s) would be bumped from %s to %s", bumped, opts.SourceBumpFrom, opts.SourceBumpTo)
+	} else {
+		opts.Logger.Infof("Bumped %d unit(s) from %s to %s", bumped, opts.SourceBumpFrom, opts.SourceBumpTo)
+	}
+
+	return nil
+}
+
+type sourceChange struct {
+	from, to string
+}
+
+// bumpConfigFile rewrites the refs of the module source of the given config file and returns the changed sources.
+// Only the tokens of the literal parts of the `source` attribute of the `terraform` block are rewritten, so that the
+// formatting and comments of the file are kept.
+func bumpConfigFile(opts *options.TerragruntOptions, configFile string, refRegexp *regexp.Regexp) ([]sourceChange, error) {
+	src, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	file, diags := hclwrite.ParseConfig(src, configFile, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, errors.New(diags)
+	}
+
+	var changes []sourceChange
+
+	for _, block := range file.Body().Blocks() {
+		if block.Type() != config.MetadataTerraform {
+			continue
+		}
+
+		attr := block.Body().GetAttribute("source")
+		if attr == nil {
+			continue
+		}
+
+		// The tokens of the expression are the ones of the file, so updating them updates the file.
+		tokens := attr.Expr().BuildTokens(nil)
+		from := strings.TrimSpace(string(tokens.Bytes()))
+
+		// The literal parts of the source, e.g. `//vpc?ref=v1.0.0` of "${local.repo}//vpc?ref=v1.0.0".
+		for _, token := range tokens {
+			if token.Type != hclsyntax.TokenQuotedLit {
+				continue
+			}
+
+			bumped, err := bumpQuotedLiteral(token.Bytes, refRegexp, opts.SourceBumpTo)
+			if err != nil {
+				return nil, errors.Errorf("error parsing the source of %s: %w", configFile, err)
+			}
+
+			token.Bytes = bumped
+		}
+
+		if to := strings.TrimSpace(string(tokens.Bytes())); to != from {
+			changes = append(changes, sourceChange{from: from, to: to})
+		}
+	}
+
+	if len(changes) == 0 || opts.SourceBumpDryRun {
+		return changes, nil
+	}
+
+	info, err := os.Stat(configFile)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	// Unlike file.Bytes, the tokens are written as they are, without formatting the rest of the file.
+	if err := os.WriteFile(configFile, file.BuildTokens(nil).Bytes(), info.Mode()); err != nil {
+		return nil, errors.New(err)
+	}
+
+	return changes, nil
+}
+
+// bumpQuotedLiteral replaces the ref of the given quoted literal, as written in the file, with the to ref. The ref is
+// matched against the unescaped literal, and the literal is escaped again only if it changes.
+func bumpQuotedLiteral(lit []byte, refRegexp *regexp.Regexp, to string) ([]byte, error) {
+	quoted := append(append([]byte{'"'}, lit...), '"')
+
+	expr, diags := hclsyntax.ParseExpression(quoted, "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	unescaped := val.AsString()
+
+	bumped := refRegexp.ReplaceAllString(unescaped, "${1}"+to+"${2}")
+	if bumped == unescaped {
+		return lit, nil
+	}
+
+	escaped := hclwrite.TokensForValue(cty.StringVal(bumped))
+
+	return bytes.TrimSuffix(bytes.TrimPrefix(escaped.Bytes(), []byte(`"`)), []byte(`"`)), nil
+}
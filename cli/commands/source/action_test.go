@@ -0,0 +1,147 @@
+package source_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/files"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/source"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+func TestRunBump(t *testing.T) {
+	t.Parallel()
+
+	tmpPath, err := files.CopyFolderToTemp("./testdata/fixtures", t.Name(), func(path string) bool { return true })
+
+	t.Cleanup(func() {
+		os.RemoveAll(tmpPath)
+	})
+
+	require.NoError(t, err)
+
+	originals := readConfigFiles(t, tmpPath)
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+
+	opts.WorkingDir = tmpPath
+	opts.Writer = &out
+	opts.SourceBumpFrom = "v1.0.0"
+	opts.SourceBumpTo = "v1.1.0"
+	opts.SourceBumpDryRun = true
+
+	require.NoError(t, source.RunBump(opts))
+
+	expectedOutput := `app/terragrunt.hcl: "${local.repo}//app?ref=v1.0.0&depth=1" -> "${local.repo}//app?ref=v1.1.0&depth=1"
+vpc/terragrunt.hcl: "git::https://github.com/acme/infrastructure-modules.git//vpc?ref=v1.0.0" -> "git::https://github.com/acme/infrastructure-modules.git//vpc?ref=v1.1.0"
+`
+	assert.Equal(t, expectedOutput, out.String())
+
+	// the files are not updated in a dry run
+	assert.Equal(t, originals, readConfigFiles(t, tmpPath))
+
+	out.Reset()
+	opts.SourceBumpDryRun = false
+
+	require.NoError(t, source.RunBump(opts))
+	assert.Equal(t, expectedOutput, out.String())
+
+	bumped := readConfigFiles(t, tmpPath)
+
+	// only the refs are replaced, the formatting and comments are kept
+	for _, unit := range []string{"vpc", "app"} {
+		expected := strings.ReplaceAll(originals[unit], "?ref=v1.0.0", "?ref=v1.1.0")
+		assert.NotEqual(t, originals[unit], expected)
+		assert.Equal(t, expected, bumped[unit], unit)
+	}
+
+	// the units pinned to other refs are untouched
+	for _, unit := range []string{"db", "legacy"} {
+		assert.Equal(t, originals[unit], bumped[unit], unit)
+	}
+}
+
+func TestRunBumpRequiresRefs(t *testing.T) {
+	t.Parallel()
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	opts.WorkingDir = t.TempDir()
+	opts.SourceBumpFrom = "v1.0.0"
+
+	err = source.RunBump(opts)
+	require.EqualError(t, err, "the --from and --to flags are required")
+}
+
+func readConfigFiles(t *testing.T, dir string) map[string]string {
+	t.Helper()
+
+	configs := map[string]string{}
+
+	for _, unit := range []string{"vpc", "app", "db", "legacy"} {
+		contents, err := util.ReadFileAsString(filepath.Join(dir, unit, "terragrunt.hcl"))
+		require.NoError(t, err)
+
+		configs[unit] = contents
+	}
+
+	return configs
+}
+
+func TestRunBumpEscapedSource(t *testing.T) {
+	t.Parallel()
+
+	tmpPath := t.TempDir()
+
+	configPath := filepath.Join(tmpPath, "app", "terragrunt.hcl")
+	require.NoError(t, os.MkdirAll(filepath.Dir(configPath), os.ModePerm))
+	require.NoError(t, os.WriteFile(configPath, []byte(`terraform {
+  # the ref is followed by an escaped "&"
+  source = "git::https://github.com/acme/modules.git//app?ref=v1.0.0\u0026depth=1"
+}
+
+inputs = {
+  name   = "app"
+  region = "us-east-1"
+}
+`), 0644))
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+
+	opts.WorkingDir = tmpPath
+	opts.Writer = &out
+	opts.SourceBumpFrom = "v1.0.0"
+	opts.SourceBumpTo = "v1.1.0"
+
+	require.NoError(t, source.RunBump(opts))
+
+	expected := `terraform {
+  # the ref is followed by an escaped "&"
+  source = "git::https://github.com/acme/modules.git//app?ref=v1.1.0&depth=1"
+}
+
+inputs = {
+  name   = "app"
+  region = "us-east-1"
+}
+`
+
+	actual, err := util.ReadFileAsString(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, `app/terragrunt.hcl: "git::https://github.com/acme/modules.git//app?ref=v1.0.0\u0026depth=1" -> "git::https://github.com/acme/modules.git//app?ref=v1.1.0&depth=1"`+"\n", out.String())
+}
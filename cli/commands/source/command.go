@@ -0,0 +1,66 @@
+// Package source provides the `source` command to manage the module sources of the units.
+package source
+
+import (
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/cli"
+)
+
+const (
+	CommandName     = "source"
+	BumpCommandName = "bump"
+
+	FromFlagName   = "from"
+	ToFlagName     = "to"
+	DryRunFlagName = "dry-run"
+)
+
+func NewBumpFlags(opts *options.TerragruntOptions) cli.Flags {
+	return cli.Flags{
+		&cli.GenericFlag[string]{
+			Name:        FromFlagName,
+			Destination: &opts.SourceBumpFrom,
+			Usage:       "The ref of the module sources to bump, e.g. v1.2.3.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        ToFlagName,
+			Destination: &opts.SourceBumpTo,
+			Usage:       "The ref to bump the module sources to, e.g. v1.3.0.",
+		},
+		&cli.BoolFlag{
+			Name:        DryRunFlagName,
+			Destination: &opts.SourceBumpDryRun,
+			Usage:       "Print the module sources that would be bumped without updating the files.",
+		},
+	}
+}
+
+func NewCommand(opts *options.TerragruntOptions) *cli.Command {
+	return &cli.Command{
+		Name:  CommandName,
+		Usage: "Manage the module sources of the units.",
+		Subcommands: cli.Commands{
+			newBumpCommand(opts),
+		},
+		Action: func(ctx *cli.Context) error {
+			if name := ctx.Args().CommandName(); name != "" {
+				return errors.Errorf("unknown subcommand %q of the %s command", name, CommandName)
+			}
+
+			return errors.Errorf("the %s command requires a subcommand, e.g. `terragrunt %s %s`", CommandName, CommandName, BumpCommandName)
+		},
+	}
+}
+
+func newBumpCommand(opts *options.TerragruntOptions) *cli.Command {
+	return &cli.Command{
+		Name:                   BumpCommandName,
+		Usage:                  "Bump the ref of the module sources of the units in the working directory.",
+		DisallowUndefinedFlags: true,
+		Flags:                  NewBumpFlags(opts).Sort(),
+		Action: func(ctx *cli.Context) error {
+			return RunBump(opts.OptionsFromContext(ctx))
+		},
+	}
+}
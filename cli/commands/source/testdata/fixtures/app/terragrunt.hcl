@@ -0,0 +1,12 @@
+locals {
+  repo = "git::https://github.com/acme/infrastructure-modules.git"
+}
+
+terraform {
+  source = "${local.repo}//app?ref=v1.0.0&depth=1"
+
+  extra_arguments "retry_lock" {
+    commands  = ["plan", "apply"]
+    arguments = ["-lock-timeout=20m"]
+  }
+}
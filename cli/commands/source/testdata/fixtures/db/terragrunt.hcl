@@ -0,0 +1,3 @@
+terraform {
+  source = "git::https://github.com/acme/infrastructure-modules.git//db?ref=v1.0.0-rc1"
+}
@@ -0,0 +1,3 @@
+terraform {
+  source = "git::https://github.com/acme/infrastructure-modules.git//legacy?ref=v0.9.0"
+}
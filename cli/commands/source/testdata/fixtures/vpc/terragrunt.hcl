@@ -0,0 +1,9 @@
+# The network of the environment.
+terraform {
+  source = "git::https://github.com/acme/infrastructure-modules.git//vpc?ref=v1.0.0" # pinned
+}
+
+inputs = {
+  name   = "main"
+  cidr_block = "10.0.0.0/16"
+}
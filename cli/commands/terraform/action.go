@@ -1,6 +1,7 @@
 package terraform
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -22,6 +23,7 @@ import (
 	"github.com/gruntwork-io/go-commons/collections"
 	"github.com/hashicorp/go-multierror"
 	"github.com/mattn/go-zglob"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
 
 	"github.com/gruntwork-io/terragrunt/codegen"
 	"github.com/gruntwork-io/terragrunt/config"
@@ -80,9 +82,48 @@ func Run(ctx context.Context, opts *options.TerragruntOptions) error {
 		return errors.New(MissingCommand{})
 	}
 
+	if opts.OutputMerge && opts.TerraformCommand == terraform.CommandNameOutput {
+		return runOutputMerge(ctx, opts)
+	}
+
 	return runTerraform(ctx, opts, new(Target))
 }
 
+// runOutputMerge prints the outputs of the unit and of all the units it depends on, keyed by the path of the unit.
+func runOutputMerge(ctx context.Context, opts *options.TerragruntOptions) error {
+	// The outputs are read by running `terragrunt output` in the units, which must not merge the outputs again.
+	mergeOpts, err := opts.Clone(opts.TerragruntConfigPath)
+	if err != nil {
+		return err
+	}
+
+	mergeOpts.OutputMerge = false
+
+	outputs, err := config.MergedOutputs(ctx, mergeOpts)
+	if err != nil {
+		return err
+	}
+
+	jsonBytes, err := ctyjson.Marshal(outputs, outputs.Type())
+	if err != nil {
+		return errors.New(err)
+	}
+
+	var out bytes.Buffer
+
+	if err := json.Indent(&out, jsonBytes, "", "  "); err != nil {
+		return errors.New(err)
+	}
+
+	out.WriteString("\n")
+
+	if _, err := opts.Writer.Write(out.Bytes()); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
 func RunWithTarget(ctx context.Context, opts *options.TerragruntOptions, target *Target) error {
 	return runTerraform(ctx, opts, target)
 }
@@ -287,12 +328,20 @@ func generateConfig(terragruntConfig *config.TerragruntConfig, updatedTerragrunt
 // This function takes in the "original" terragrunt options which has the unmodified 'WorkingDir' from before downloading the code from the source URL,
 // and the "updated" terragrunt options that will contain the updated 'WorkingDir' into which the code has been downloaded
 func runTerragruntWithConfig(ctx context.Context, originalTerragruntOptions *options.TerragruntOptions, terragruntOptions *options.TerragruntOptions, terragruntConfig *config.TerragruntConfig, target *Target) error {
-	// Add extra_arguments to the command
-	if terragruntConfig.Terraform != nil && terragruntConfig.Terraform.ExtraArgs != nil && len(terragruntConfig.Terraform.ExtraArgs) > 0 {
-		args := FilterTerraformExtraArgs(terragruntOptions, terragruntConfig)
-
+	// Add global_extra_arguments and extra_arguments to the command
+	if args := FilterTerraformExtraArgs(terragruntOptions, terragruntConfig); len(args) > 0 {
 		terragruntOptions.InsertTerraformCliArgs(args...)
+	}
 
+	// The variables of the .env file don't override the ones of the process environment,
+	// but are overridden by the env_vars of the extra_arguments.
+	if terragruntOptions.LoadDotEnv {
+		if err := setDotEnvVars(terragruntOptions); err != nil {
+			return err
+		}
+	}
+
+	if terragruntConfig.Terraform != nil && len(terragruntConfig.Terraform.ExtraArgs) > 0 {
 		for k, v := range filterTerraformEnvVarsFromExtraArgs(terragruntOptions, terragruntConfig) {
 			terragruntOptions.Env[k] = v
 		}
@@ -442,6 +491,35 @@ func runActionWithHooks(ctx context.Context, description string, terragruntOptio
 	return allErrors.ErrorOrNil()
 }
 
+// setDotEnvVars sets the variables of the .env file next to the terragrunt.hcl of the unit, if any, as environment
+// variables for tofu/terraform, unless they are already set.
+func setDotEnvVars(terragruntOptions *options.TerragruntOptions) error {
+	dotEnvFile := filepath.Join(filepath.Dir(terragruntOptions.TerragruntConfigPath), util.DotEnvFileName)
+	if !util.FileExists(dotEnvFile) {
+		return nil
+	}
+
+	contents, err := util.ReadFileAsString(dotEnvFile)
+	if err != nil {
+		return err
+	}
+
+	envs, err := util.ParseDotEnv(contents)
+	if err != nil {
+		return errors.Errorf("failed to parse %s: %w", dotEnvFile, err)
+	}
+
+	terragruntOptions.Logger.Debugf("Loading %d environment variables from %s", len(envs), dotEnvFile)
+
+	for key, value := range envs {
+		if _, envVarAlreadySet := terragruntOptions.Env[key]; !envVarAlreadySet {
+			terragruntOptions.Env[key] = value
+		}
+	}
+
+	return nil
+}
+
 // SetTerragruntInputsAsEnvVars sets the inputs from Terragrunt configurations to TF_VAR_* environment variables for
 // OpenTofu/Terraform.
 func SetTerragruntInputsAsEnvVars(terragruntOptions *options.TerragruntOptions, terragruntConfig *config.TerragruntConfig) error {
@@ -482,13 +560,27 @@ func RunTerraformWithRetry(ctx context.Context, terragruntOptions *options.Terra
 				}
 			}
 		} else {
-			return nil
+			return checkTerraformWarnings(terragruntOptions, out)
 		}
 	}
 
 	return errors.New(MaxRetriesExceeded{terragruntOptions})
 }
 
+// checkTerraformWarnings fails the command whose JSON diagnostics contain warnings, if TFWarningsAsErrors is set.
+func checkTerraformWarnings(opts *options.TerragruntOptions, out *util.CmdOutput) error {
+	if !opts.TFWarningsAsErrors || out == nil {
+		return nil
+	}
+
+	// When -json is enabled, Terraform will send all output, diagnostics included, to stdout.
+	if warnings := FindTerraformWarnings(out.Stdout.String()); len(warnings) > 0 {
+		return errors.New(TerraformWarningsError{WorkingDir: opts.WorkingDir, Warnings: warnings})
+	}
+
+	return nil
+}
+
 // IsRetryable checks whether there was an error and if the output matches any of the configured RetryableErrors
 func IsRetryable(opts *options.TerragruntOptions, out *util.CmdOutput) bool {
 	if !opts.AutoRetry {
@@ -593,6 +685,19 @@ func prepareNonInitCommand(ctx context.Context, originalTerragruntOptions *optio
 		}
 	}
 
+	if workspace := terragruntConfig.Terraform.GetWorkspace(); workspace != "" && needsWorkspace(terragruntOptions) {
+		// Terraform refuses to select a workspace while TF_WORKSPACE overrides it, e.g. when the outputs of another
+		// workspace are read for the `workspace` attribute of a dependency block.
+		if override, ok := terragruntOptions.Env[terraform.EnvNameTFWorkspace]; ok && override != "" {
+			terragruntOptions.Logger.Debugf("Not selecting workspace %s, %s is set to %s", workspace, terraform.EnvNameTFWorkspace, override)
+			return nil
+		}
+
+		if err := selectWorkspace(ctx, terragruntOptions, workspace, terragruntConfig.Terraform.GetCreateWorkspace()); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -745,10 +850,26 @@ func checkProtectedModule(terragruntOptions *options.TerragruntOptions, terragru
 	return nil
 }
 
+// FilterTerraformExtraArgs returns the arguments of the global_extra_arguments attribute and the extra_arguments blocks
+// that apply to the current command. The global arguments come first, so the extra_arguments blocks take precedence.
 func FilterTerraformExtraArgs(terragruntOptions *options.TerragruntOptions, terragruntConfig *config.TerragruntConfig) []string {
 	out := []string{}
 	cmd := util.FirstArg(terragruntOptions.TerraformCliArgs)
 
+	for _, arg := range terragruntConfig.GlobalExtraArgs[cmd] {
+		// Same as for extra_arguments, skip vars if the command is applying a plan file.
+		if (cmd == terraform.CommandNameApply || cmd == terraform.CommandNameDestroy) &&
+			util.IsFile(util.LastArg(terragruntOptions.TerraformCliArgs)) && strings.HasPrefix(arg, "-var") {
+			continue
+		}
+
+		out = append(out, arg)
+	}
+
+	if terragruntConfig.Terraform == nil {
+		return out
+	}
+
 	for _, arg := range terragruntConfig.Terraform.ExtraArgs {
 		for _, argCmd := range arg.Commands {
 			if cmd == argCmd {
@@ -3,6 +3,7 @@ package terraform_test
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -11,7 +12,9 @@ import (
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/cli"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/shell"
 	"github.com/gruntwork-io/terragrunt/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -254,6 +257,54 @@ func TestTerragruntHandlesCatastrophicTerraformFailure(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestTerraformWarningsAsErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		fixture          string
+		expectedWarnings []string
+	}{
+		{"plan-with-warnings.jsonl", []string{"Value for undeclared variable"}},
+		{"validate-with-warnings.json", []string{"Argument is deprecated"}},
+		{"plan-without-warnings.jsonl", nil},
+		{"plan-human-readable.txt", nil},
+	}
+
+	for _, tc := range testCases {
+		for _, warningsAsErrors := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s warnings as errors %t", tc.fixture, warningsAsErrors), func(t *testing.T) {
+				t.Parallel()
+
+				output, err := os.ReadFile(filepath.Join("test-fixtures", "tf-warnings", tc.fixture))
+				require.NoError(t, err)
+
+				tgOptions, err := options.NewTerragruntOptionsForTest("")
+				require.NoError(t, err)
+
+				tgOptions.TFWarningsAsErrors = warningsAsErrors
+
+				// Simulate the OpenTofu/Terraform command, printing the output of the fixture.
+				ctx := shell.ContextWithTerraformCommandHook(context.Background(), func(_ context.Context, _ *options.TerragruntOptions, _ cli.Args) (*util.CmdOutput, error) {
+					out := &util.CmdOutput{}
+					out.Stdout.Write(output)
+
+					return out, nil
+				})
+
+				err = terraform.RunTerraformWithRetry(ctx, tgOptions)
+				if !warningsAsErrors || len(tc.expectedWarnings) == 0 {
+					require.NoError(t, err)
+					return
+				}
+
+				var warningsErr terraform.TerraformWarningsError
+				require.ErrorAs(t, err, &warningsErr)
+				assert.Equal(t, tc.expectedWarnings, warningsErr.Warnings)
+			})
+		}
+	}
+}
+
 func TestToTerraformEnvVars(t *testing.T) {
 	t.Parallel()
 
@@ -438,6 +489,54 @@ func TestFilterTerraformExtraArgs(t *testing.T) {
 
 var defaultLogLevel = log.DebugLevel
 
+func TestFilterTerraformExtraArgsWithGlobalExtraArgs(t *testing.T) {
+	t.Parallel()
+
+	workingDir, err := os.Getwd()
+	require.NoError(t, err)
+
+	workingDir = filepath.ToSlash(workingDir)
+
+	testCases := []struct {
+		options      *options.TerragruntOptions
+		config       config.TerragruntConfig
+		expectedArgs []string
+	}{
+		// global extra args only
+		{
+			mockCmdOptions(t, workingDir, []string{"apply"}),
+			config.TerragruntConfig{
+				GlobalExtraArgs: map[string][]string{"apply": {"-lock-timeout=20m"}},
+			},
+			[]string{"-lock-timeout=20m"},
+		},
+		// global extra args of another command
+		{
+			mockCmdOptions(t, workingDir, []string{"plan"}),
+			config.TerragruntConfig{
+				GlobalExtraArgs: map[string][]string{"apply": {"-lock-timeout=20m"}},
+			},
+			[]string{},
+		},
+		// extra_arguments come after the global extra args, so they take precedence
+		{
+			mockCmdOptions(t, workingDir, []string{"apply"}),
+			config.TerragruntConfig{
+				GlobalExtraArgs: map[string][]string{"apply": {"-lock-timeout=20m"}},
+				Terraform: &config.TerraformConfig{ExtraArgs: []config.TerraformExtraArguments{
+					mockExtraArgs([]string{"-lock-timeout=5m"}, []string{"apply"}, []string{}, []string{}),
+				}},
+			},
+			[]string{"-lock-timeout=20m", "-lock-timeout=5m"},
+		},
+	}
+	for _, testCase := range testCases {
+		out := terraform.FilterTerraformExtraArgs(testCase.options, &testCase.config)
+
+		assert.Equal(t, testCase.expectedArgs, out)
+	}
+}
+
 func mockCmdOptions(t *testing.T, workingDir string, terraformCliArgs []string) *options.TerragruntOptions {
 	t.Helper()
 
@@ -4,6 +4,7 @@ package terraform
 import (
 	"strings"
 
+	"github.com/agext/levenshtein"
 	"github.com/gruntwork-io/go-commons/collections"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/options"
@@ -20,6 +21,10 @@ var (
 	nativeTerraformCommands = []string{"apply", "console", "destroy", "env", "fmt", "get", "graph", "import", "init", "login", "logout", "metadata", "output", "plan", "providers", "push", "refresh", "show", "taint", "test", "version", "validate", "untaint", "workspace", "force-unlock", "state"}
 )
 
+// suggestionDistanceRatio is the number of characters of an unknown command allowing for one edit to a native command
+// to suggest it, e.g. a 6 characters command is suggested the native commands within 2 edits.
+const suggestionDistanceRatio = 3
+
 func NewCommand(opts *options.TerragruntOptions) *cli.Command {
 	return &cli.Command{
 		Name:     CommandName,
@@ -47,3 +52,20 @@ func Action(opts *options.TerragruntOptions) cli.ActionFunc {
 		return Run(ctx.Context, opts.OptionsFromContext(ctx))
 	}
 }
+
+// suggestCommand returns the native command closest to the given unknown command, compared by their Levenshtein
+// distance, or an empty string if none of them is close enough.
+func suggestCommand(name string) string {
+	var (
+		suggestion   string
+		bestDistance = max(1, len(name)/suggestionDistanceRatio) + 1
+	)
+
+	for _, command := range nativeTerraformCommands {
+		if distance := levenshtein.Distance(name, command, nil); distance < bestDistance {
+			suggestion, bestDistance = command, distance
+		}
+	}
+
+	return suggestion
+}
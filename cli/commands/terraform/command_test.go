@@ -56,3 +56,41 @@ func TestAction(t *testing.T) {
 		})
 	}
 }
+
+func TestWrongCommandSuggestion(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		err      error
+		expected string
+	}{
+		{
+			err:      terraform.WrongTofuCommand("aply"),
+			expected: `OpenTofu has no command named "aply". Did you mean "apply"? To see all of OpenTofu's top-level commands, run: tofu -help`,
+		},
+		{
+			err:      terraform.WrongTerraformCommand("pln"),
+			expected: `Terraform has no command named "pln". Did you mean "plan"? To see all of Terraform's top-level commands, run: terraform -help`,
+		},
+		{
+			err:      terraform.WrongTofuCommand("destory"),
+			expected: `OpenTofu has no command named "destory". Did you mean "destroy"? To see all of OpenTofu's top-level commands, run: tofu -help`,
+		},
+		{
+			err:      terraform.WrongTofuCommand("workspaces-list"),
+			expected: `OpenTofu has no command named "workspaces-list". To see all of OpenTofu's top-level commands, run: tofu -help`,
+		},
+		{
+			err:      terraform.WrongTofuCommand("foo"),
+			expected: `OpenTofu has no command named "foo". To see all of OpenTofu's top-level commands, run: tofu -help`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			t.Parallel()
+
+			require.EqualError(t, tc.err, tc.expected)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package terraform
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const diagnosticSeverityWarning = "warning"
+
+// terraformDiagnostic is a diagnostic of the machine-readable output of OpenTofu/Terraform.
+type terraformDiagnostic struct {
+	Severity string `json:"severity"`
+	Summary  string `json:"summary"`
+	Detail   string `json:"detail"`
+}
+
+// terraformJSONMessage is either a message of the machine-readable UI, printed one per line by the commands run with
+// -json, such as `plan -json`, or the single object printed by `validate -json`, which lists all the diagnostics.
+type terraformJSONMessage struct {
+	Diagnostic  *terraformDiagnostic  `json:"diagnostic"`
+	Diagnostics []terraformDiagnostic `json:"diagnostics"`
+}
+
+// FindTerraformWarnings returns the summaries of the warnings found in the JSON diagnostics of the given
+// OpenTofu/Terraform output. The lines that are not JSON, e.g. the human-readable output of the commands run without
+// -json, are ignored.
+func FindTerraformWarnings(output string) []string {
+	var messages []terraformJSONMessage
+
+	var message terraformJSONMessage
+	if err := json.Unmarshal([]byte(output), &message); err == nil {
+		messages = append(messages, message)
+	} else {
+		for _, line := range strings.Split(output, "\n") {
+			line = strings.TrimSpace(line)
+			if !strings.HasPrefix(line, "{") {
+				continue
+			}
+
+			var message terraformJSONMessage
+			if err := json.Unmarshal([]byte(line), &message); err == nil {
+				messages = append(messages, message)
+			}
+		}
+	}
+
+	var warnings []string
+
+	for _, message := range messages {
+		diagnostics := message.Diagnostics
+		if message.Diagnostic != nil {
+			diagnostics = append(diagnostics, *message.Diagnostic)
+		}
+
+		for _, diagnostic := range diagnostics {
+			if diagnostic.Severity == diagnosticSeverityWarning {
+				warnings = append(warnings, diagnostic.Summary)
+			}
+		}
+	}
+
+	return warnings
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/gruntwork-io/terragrunt/cli/commands"
 	"github.com/gruntwork-io/terragrunt/options"
 )
 
@@ -18,13 +19,32 @@ func (err MissingCommand) Error() string {
 type WrongTerraformCommand string
 
 func (name WrongTerraformCommand) Error() string {
-	return fmt.Sprintf("Terraform has no command named %q. To see all of Terraform's top-level commands, run: terraform -help", string(name))
+	return fmt.Sprintf("Terraform has no command named %q.%s To see all of Terraform's top-level commands, run: terraform -help", string(name), didYouMean(string(name)))
 }
 
 type WrongTofuCommand string
 
 func (name WrongTofuCommand) Error() string {
-	return fmt.Sprintf("OpenTofu has no command named %q. To see all of OpenTofu's top-level commands, run: tofu -help", string(name))
+	return fmt.Sprintf("OpenTofu has no command named %q.%s To see all of OpenTofu's top-level commands, run: tofu -help", string(name), didYouMean(string(name)))
+}
+
+// didYouMean returns a sentence suggesting the native command closest to the given unknown command, or an empty string
+// if no command is close enough.
+func didYouMean(name string) string {
+	if suggestion := suggestCommand(name); suggestion != "" {
+		return fmt.Sprintf(" Did you mean %q?", suggestion)
+	}
+
+	return ""
+}
+
+type TerraformWarningsError struct {
+	WorkingDir string
+	Warnings   []string
+}
+
+func (err TerraformWarningsError) Error() string {
+	return fmt.Sprintf("OpenTofu/Terraform printed %d warnings in %s, which are errors with --%s: %s", len(err.Warnings), err.WorkingDir, commands.TerragruntTFWarningsAsErrorsFlagName, strings.Join(err.Warnings, "; "))
 }
 
 type BackendNotDefined struct {
@@ -57,3 +77,11 @@ type MaxRetriesExceeded struct {
 func (err MaxRetriesExceeded) Error() string {
 	return fmt.Sprintf("Exhausted retries (%v) for command %v %v", err.Opts.RetryMaxAttempts, err.Opts.TerraformPath, strings.Join(err.Opts.TerraformCliArgs, " "))
 }
+
+type WorkspaceNotExistError struct {
+	Workspace string
+}
+
+func (err WorkspaceNotExistError) Error() string {
+	return fmt.Sprintf("The workspace %q does not exist and its creation is disabled by create_workspace = false in the terraform block.", err.Workspace)
+}
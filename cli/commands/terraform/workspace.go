@@ -0,0 +1,81 @@
+package terraform
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/shell"
+	"github.com/gruntwork-io/terragrunt/terraform"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// needsWorkspace returns true if the workspace of the `terraform` block must be selected before running the command.
+// The `workspace` command itself is left to the user.
+func needsWorkspace(terragruntOptions *options.TerragruntOptions) bool {
+	command := util.FirstArg(terragruntOptions.TerraformCliArgs)
+
+	return command != terraform.CommandNameWorkspace && !util.ListContainsElement(TerraformCommandsThatDoNotNeedInit, command)
+}
+
+// selectWorkspace selects the given workspace in the working directory, if it isn't already the current one. If the
+// workspace doesn't exist, it is created when create is true, otherwise an error is returned.
+func selectWorkspace(ctx context.Context, terragruntOptions *options.TerragruntOptions, workspace string, create bool) error {
+	// The output of the workspace commands is only used to find the current and existing workspaces, so discard it
+	// to not pollute stdout, e.g. for `output -json`.
+	workspaceOptions, err := terragruntOptions.Clone(terragruntOptions.TerragruntConfigPath)
+	if err != nil {
+		return err
+	}
+
+	workspaceOptions.Writer = io.Discard
+
+	output, err := shell.RunTerraformCommandWithOutput(ctx, workspaceOptions, terraform.CommandNameWorkspace, "show")
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(output.Stdout.String()) == workspace {
+		return nil
+	}
+
+	output, err = shell.RunTerraformCommandWithOutput(ctx, workspaceOptions, terraform.CommandNameWorkspace, "list")
+	if err != nil {
+		return err
+	}
+
+	if util.ListContainsElement(parseWorkspaceList(output.Stdout.String()), workspace) {
+		terragruntOptions.Logger.Debugf("Selecting workspace %s", workspace)
+
+		_, err := shell.RunTerraformCommandWithOutput(ctx, workspaceOptions, terraform.CommandNameWorkspace, "select", workspace)
+
+		return err
+	}
+
+	if !create {
+		return errors.New(WorkspaceNotExistError{Workspace: workspace})
+	}
+
+	terragruntOptions.Logger.Infof("Creating workspace %s", workspace)
+
+	// `workspace new` also selects the created workspace.
+	_, err = shell.RunTerraformCommandWithOutput(ctx, workspaceOptions, terraform.CommandNameWorkspace, "new", workspace)
+
+	return err
+}
+
+// parseWorkspaceList returns the workspace names of the `workspace list` output, where the current workspace is
+// prefixed with `*`.
+func parseWorkspaceList(output string) []string {
+	var workspaces []string
+
+	for _, line := range strings.Split(output, "\n") {
+		if name := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "*")); name != "" {
+			workspaces = append(workspaces, name)
+		}
+	}
+
+	return workspaces
+}
@@ -10,9 +10,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/google/shlex"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
 
 	"github.com/gruntwork-io/terragrunt/cli/commands/terraform"
 	"github.com/gruntwork-io/terragrunt/config"
@@ -87,6 +90,12 @@ func runValidateInputs(ctx context.Context, opts *options.TerragruntOptions, cfg
 		opts.Logger.Debug(fmt.Sprintf("Strict mode enabled: %t", opts.ValidateStrict))
 	}
 
+	if opts.WarnRedundantInputs {
+		if err := warnRedundantInputs(opts, cfg); err != nil {
+			return err
+		}
+	}
+
 	// Return an error when there are misaligned inputs. Terragrunt strict mode defaults to false. When it is false,
 	// an error will only be returned if required inputs are missing. When strict mode is true, an error will be
 	// returned if required inputs are missing OR if any unused variables are passed
@@ -99,6 +108,78 @@ func runValidateInputs(ctx context.Context, opts *options.TerragruntOptions, cfg
 	return nil
 }
 
+// warnRedundantInputs warns about the inputs configured in the inputs block that are set to the same value as the
+// default of the corresponding terraform variable, since they can be removed from the configuration.
+func warnRedundantInputs(opts *options.TerragruntOptions, cfg *config.TerragruntConfig) error {
+	defaults, err := tr.ModuleVariablesDefaults(opts.WorkingDir)
+	if err != nil {
+		return err
+	}
+
+	redundantInputs := []string{}
+
+	for inputName, inputValue := range cfg.Inputs {
+		defaultValue, ok := defaults[inputName]
+		if !ok {
+			continue
+		}
+
+		isEqual, err := isEqualValue(inputValue, defaultValue)
+		if err != nil {
+			return err
+		}
+
+		if isEqual {
+			redundantInputs = append(redundantInputs, inputName)
+		}
+	}
+
+	if len(redundantInputs) == 0 {
+		return nil
+	}
+
+	sort.Strings(redundantInputs)
+
+	opts.Logger.Warn("The following inputs passed in by terragrunt are set to the variable default value and can be removed:\n")
+
+	for _, inputName := range redundantInputs {
+		opts.Logger.Warnf("\t- %s", inputName)
+	}
+
+	opts.Logger.Warn("")
+
+	return nil
+}
+
+// isEqualValue converts the given values to cty values through their JSON representation and compares them.
+func isEqualValue(left, right interface{}) (bool, error) {
+	leftVal, err := toCtyValue(left)
+	if err != nil {
+		return false, err
+	}
+
+	rightVal, err := toCtyValue(right)
+	if err != nil {
+		return false, err
+	}
+
+	return leftVal.Equals(rightVal).True(), nil
+}
+
+func toCtyValue(value interface{}) (cty.Value, error) {
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	ctyType, err := ctyjson.ImpliedType(jsonBytes)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	return ctyjson.Unmarshal(jsonBytes, ctyType)
+}
+
 // getDefinedTerragruntInputs will return a list of names of all variables that are configured by terragrunt to be
 // passed into terraform. Terragrunt can pass in inputs from:
 // - var files defined on terraform.extra_arguments blocks.
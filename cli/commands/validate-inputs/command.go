@@ -11,6 +11,8 @@ const (
 	CommandName = "validate-inputs"
 
 	FlagTerragruntStrictValidate = "terragrunt-strict-validate"
+
+	FlagTerragruntWarnRedundantInputs = "terragrunt-warn-redundant-inputs"
 )
 
 func NewFlags(opts *options.TerragruntOptions) cli.Flags {
@@ -20,6 +22,11 @@ func NewFlags(opts *options.TerragruntOptions) cli.Flags {
 			Destination: &opts.ValidateStrict,
 			Usage:       "Sets strict mode for the validate-inputs command. By default, strict mode is off. When this flag is passed, strict mode is turned on. When strict mode is turned off, the validate-inputs command will only return an error if required inputs are missing from all input sources (env vars, var files, etc). When strict mode is turned on, an error will be returned if required inputs are missing OR if unused variables are passed to Terragrunt.",
 		},
+		&cli.BoolFlag{
+			Name:        FlagTerragruntWarnRedundantInputs,
+			Destination: &opts.WarnRedundantInputs,
+			Usage:       "Warn about inputs that are set to the same value as the default of the corresponding variable, as they can be removed from the configuration.",
+		},
 	}
 }
 
@@ -96,7 +96,8 @@ func InitProviderCacheServer(opts *options.TerragruntOptions) (*ProviderCache, e
 		return nil, err
 	}
 
-	providerService := services.NewProviderService(opts.ProviderCacheDir, userProviderDir, cliCfg.CredentialsSource(), opts.Logger)
+	providerService := services.NewProviderService(opts.ProviderCacheDir, userProviderDir, cliCfg.CredentialsSource(), opts.Logger).
+		WithMaxCacheBytes(opts.ProviderCacheMaxBytes)
 
 	var (
 		providerHandlers = make([]handlers.ProviderHandler, 0, len(cliCfg.ProviderInstallation.Methods))
@@ -135,9 +136,13 @@ func InitProviderCacheServer(opts *options.TerragruntOptions) (*ProviderCache, e
 	cache := cache.NewServer(
 		cache.WithHostname(opts.ProviderCacheHostname),
 		cache.WithPort(opts.ProviderCachePort),
+		cache.WithAddr(opts.ProviderCacheAddr),
 		cache.WithToken(opts.ProviderCacheToken),
 		cache.WithServices(providerService),
 		cache.WithProviderHandlers(providerHandlers...),
+		cache.WithCacheExcludes(opts.ProviderCacheExcludes...),
+		cache.WithTLS(opts.ProviderCacheTLSCertFile, opts.ProviderCacheTLSKeyFile),
+		cache.WithTLSClientCA(opts.ProviderCacheTLSClientCAFile),
 		cache.WithLogger(opts.Logger),
 	)
 
@@ -160,6 +165,11 @@ func (cache *ProviderCache) TerraformCommandHook(
 	// To prevent a loop
 	ctx = shell.ContextWithTerraformCommandHook(ctx, nil)
 
+	if !matchesProviderCacheFilters(opts) {
+		opts.Logger.Debugf("Skipping provider cache for %s, the unit does not match the provider cache filters", opts.WorkingDir)
+		return shell.RunTerraformCommandWithOutput(ctx, opts, args...)
+	}
+
 	cliConfigFilename := filepath.Join(opts.WorkingDir, localCLIFilename)
 
 	if !filepath.IsAbs(cliConfigFilename) {
@@ -313,7 +323,8 @@ func (cache *ProviderCache) createLocalCLIConfig(ctx context.Context, opts *opti
 
 	if cacheRequestID == "" {
 		cfg.AddProviderInstallationMethods(
-			cliconfig.NewProviderInstallationFilesystemMirror(opts.ProviderCacheDir, providerInstallationIncludes, nil),
+			// The excluded providers are not in the cache, they are downloaded through the cache server acting as a proxy.
+			cliconfig.NewProviderInstallationFilesystemMirror(opts.ProviderCacheDir, providerInstallationIncludes, opts.ProviderCacheExcludes),
 		)
 	} else {
 		cfg.ProviderInstallation = nil
@@ -366,6 +377,34 @@ func runTerraformCommand(ctx context.Context, opts *options.TerragruntOptions, a
 	return output, err
 }
 
+// matchesProviderCacheFilters returns true if the unit matches one of the glob patterns of `--terragrunt-provider-cache-filter`,
+// or if there are no patterns, meaning that the providers of the unit are cached by the Terragrunt Provider Cache server.
+func matchesProviderCacheFilters(opts *options.TerragruntOptions) bool {
+	if len(opts.ProviderCacheFilters) == 0 {
+		return true
+	}
+
+	unitDir := opts.WorkingDir
+	if opts.TerragruntConfigPath != "" {
+		unitDir = filepath.Dir(opts.TerragruntConfigPath)
+	}
+
+	unitPath, err := filepath.Rel(opts.RootWorkingDir, unitDir)
+	if err != nil {
+		unitPath = unitDir
+	}
+
+	unitPath = filepath.ToSlash(unitPath)
+
+	for _, filter := range opts.ProviderCacheFilters {
+		if util.MatchGlobPath(filepath.ToSlash(filepath.Clean(filter)), unitPath) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // providerCacheEnvironment returns TF_* name/value ENVs, which we use to force terraform processes to make requests through our cache server (proxy) instead of making direct requests to the origin servers.
 func providerCacheEnvironment(opts *options.TerragruntOptions, cliConfigFile string) map[string]string {
 	envs := opts.Env
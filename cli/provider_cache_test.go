@@ -1,15 +1,27 @@
 package cli_test
 
 import (
+	"archive/zip"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
 	"io"
+	"math/big"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gruntwork-io/terragrunt/cli"
@@ -17,8 +29,11 @@ import (
 	"github.com/gruntwork-io/terragrunt/pkg/log"
 	"github.com/gruntwork-io/terragrunt/terraform/cache"
 	"github.com/gruntwork-io/terragrunt/terraform/cache/handlers"
+	"github.com/gruntwork-io/terragrunt/terraform/cache/models"
+	"github.com/gruntwork-io/terragrunt/terraform/cache/router"
 	"github.com/gruntwork-io/terragrunt/terraform/cache/services"
 	"github.com/gruntwork-io/terragrunt/terraform/cliconfig"
+	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
@@ -57,6 +72,7 @@ func TestProviderCache(t *testing.T) {
 		expectedStatusCode int
 		expectedBodyReg    *regexp.Regexp
 		expectedCachePath  string
+		notCachedPath      string
 	}{
 		{
 			opts:               opts,
@@ -99,6 +115,20 @@ func TestProviderCache(t *testing.T) {
 			expectedStatusCode: http.StatusOK,
 			expectedBodyReg:    regexp.MustCompile(`\{.*` + regexp.QuoteMeta(`"download_url":"http://127.0.0.1:`) + `\d+` + regexp.QuoteMeta(`/downloads/releases.hashicorp.com/terraform-provider-aws/5.36.0/terraform-provider-aws_5.36.0_darwin_arm64.zip"`) + `.*\}`),
 		},
+		{
+			// an excluded provider is proxied to the upstream registry instead of being cached
+			opts:               append(opts, cache.WithCacheExcludes("registry.terraform.io/hashicorp/aws")),
+			relURLPath:         "/cache/registry.terraform.io/hashicorp/aws/5.36.0/download/linux/arm64",
+			expectedStatusCode: http.StatusOK,
+			expectedBodyReg:    regexp.MustCompile(`\{.*` + regexp.QuoteMeta(`"download_url":"http://127.0.0.1:`) + `\d+` + regexp.QuoteMeta(`/downloads/releases.hashicorp.com/terraform-provider-aws/5.36.0/terraform-provider-aws_5.36.0_linux_arm64.zip"`) + `.*\}`),
+			notCachedPath:      "registry.terraform.io/hashicorp/aws/5.36.0/linux_arm64",
+		},
+		{
+			opts:               append(opts, cache.WithCacheExcludes("registry.terraform.io/hashicorp/aws")),
+			relURLPath:         "/cache/registry.terraform.io/hashicorp/null/3.2.2/download/linux/arm64",
+			expectedStatusCode: http.StatusLocked,
+			expectedCachePath:  "registry.terraform.io/hashicorp/null/3.2.2/linux_arm64/terraform-provider-null_v3.2.2_x5",
+		},
 	}
 	//
 	for i, testCase := range testCases {
@@ -157,6 +187,10 @@ func TestProviderCache(t *testing.T) {
 				assert.FileExists(t, filepath.Join(providerCacheDir, testCase.expectedCachePath))
 			}
 
+			if testCase.notCachedPath != "" {
+				assert.NoDirExists(t, filepath.Join(providerCacheDir, testCase.notCachedPath))
+			}
+
 			cancel()
 			err = errGroup.Wait()
 			require.NoError(t, err)
@@ -164,6 +198,302 @@ func TestProviderCache(t *testing.T) {
 	}
 }
 
+// fakeProviderHandler records the cache request IDs of the platform requests instead of fetching the providers.
+type fakeProviderHandler struct {
+	*handlers.CommonProviderHandler
+
+	cacheRequestIDs sync.Map
+}
+
+func (handler *fakeProviderHandler) GetVersions(ctx echo.Context, provider *models.Provider) error {
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (handler *fakeProviderHandler) GetPlatform(ctx echo.Context, provider *models.Provider, downloaderController router.Controller, cacheRequestID string) error {
+	handler.cacheRequestIDs.Store(provider.Address(), cacheRequestID)
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (handler *fakeProviderHandler) Download(ctx echo.Context, provider *models.Provider) error {
+	return ctx.NoContent(http.StatusOK)
+}
+
+func TestProviderCacheExcludes(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	token := fmt.Sprintf("%s:%s", cli.APIKeyAuth, uuid.New().String())
+
+	providerService := services.NewProviderService(t.TempDir(), t.TempDir(), nil, log.New())
+	providerHandler := &fakeProviderHandler{CommonProviderHandler: handlers.NewCommonProviderHandler(providerService, nil, nil)}
+
+	server := cache.NewServer(
+		cache.WithToken(token),
+		cache.WithProviderHandlers(providerHandler),
+		cache.WithCacheExcludes("example.com/acme/*", "registry.terraform.io/hashicorp/aws"),
+	)
+	ln, err := server.Listen()
+	require.NoError(t, err)
+	defer ln.Close()
+
+	errGroup, ctx := errgroup.WithContext(ctx)
+	errGroup.Go(func() error {
+		return server.Run(ctx, ln)
+	})
+
+	for _, address := range []string{"example.com/acme/internal", "registry.terraform.io/hashicorp/aws", "registry.terraform.io/hashicorp/null"} {
+		urlPath := server.ProviderController.URL()
+		urlPath.Path += "/cache/" + address + "/1.0.0/download/linux/amd64"
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlPath.String(), nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	cacheRequestID := func(address string) any {
+		id, _ := providerHandler.cacheRequestIDs.Load(address)
+		return id
+	}
+
+	// the excluded providers are proxied without a cache request, the others are cached
+	assert.Equal(t, "", cacheRequestID("example.com/acme/internal"))
+	assert.Equal(t, "", cacheRequestID("registry.terraform.io/hashicorp/aws"))
+	assert.Equal(t, "cache", cacheRequestID("registry.terraform.io/hashicorp/null"))
+
+	cancel()
+	require.NoError(t, errGroup.Wait())
+}
+
+// createTLSFile writes the PEM encoded certificate, signed by the given parent, and its key to the dir and returns their paths.
+func createTLSFile(t *testing.T, dir, name string, template, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (string, string, *x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(certDER)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, name+".crt")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0600))
+
+	keyFile := filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+
+	return certFile, keyFile, cert, key
+}
+
+func TestProviderCacheTLS(t *testing.T) {
+	t.Parallel()
+
+	tlsDir := t.TempDir()
+	notAfter := time.Now().Add(time.Hour)
+
+	caFile, _, caCert, caKey := createTLSFile(t, tlsDir, "ca", &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Terragrunt Test CA"},
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}, nil, nil)
+
+	serverCertFile, serverKeyFile, _, _ := createTLSFile(t, tlsDir, "server", &x509.Certificate{
+		SerialNumber: big.NewInt(2), //nolint:mnd
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, caCert, caKey)
+
+	clientCertFile, clientKeyFile, _, _ := createTLSFile(t, tlsDir, "client", &x509.Certificate{
+		SerialNumber: big.NewInt(3), //nolint:mnd
+		Subject:      pkix.Name{CommonName: "client"},
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, caCert, caKey)
+
+	// the certificate files are validated when the server starts listening
+	_, err := cache.NewServer(cache.WithTLS(serverCertFile, caFile)).Listen()
+	require.ErrorContains(t, err, "error loading the TLS certificate")
+
+	_, err = cache.NewServer(cache.WithTLSClientCA(caFile)).Listen()
+	require.ErrorContains(t, err, "requires a TLS certificate and key")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	token := fmt.Sprintf("%s:%s", cli.APIKeyAuth, uuid.New().String())
+
+	providerService := services.NewProviderService(t.TempDir(), t.TempDir(), nil, log.New())
+	providerHandler := &fakeProviderHandler{CommonProviderHandler: handlers.NewCommonProviderHandler(providerService, nil, nil)}
+
+	server := cache.NewServer(
+		cache.WithToken(token),
+		cache.WithProviderHandlers(providerHandler),
+		cache.WithTLS(serverCertFile, serverKeyFile),
+		cache.WithTLSClientCA(caFile),
+	)
+	ln, err := server.Listen()
+	require.NoError(t, err)
+	defer ln.Close()
+
+	errGroup, ctx := errgroup.WithContext(ctx)
+	errGroup.Go(func() error {
+		return server.Run(ctx, ln)
+	})
+
+	urlPath := server.ProviderController.URL()
+	urlPath.Path += "/cache/registry.terraform.io/hashicorp/null/1.0.0/download/linux/amd64"
+
+	assert.Equal(t, "https", urlPath.Scheme)
+
+	caPEM, err := os.ReadFile(caFile)
+	require.NoError(t, err)
+
+	rootCAs := x509.NewCertPool()
+	require.True(t, rootCAs.AppendCertsFromPEM(caPEM))
+
+	clientCert, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		tlsConfig     *tls.Config
+		expectedError bool
+	}{
+		{
+			name:      "with CA and client certificate",
+			tlsConfig: &tls.Config{RootCAs: rootCAs, Certificates: []tls.Certificate{clientCert}, MinVersion: tls.VersionTLS12},
+		},
+		{
+			name:          "without CA",
+			tlsConfig:     &tls.Config{Certificates: []tls.Certificate{clientCert}, MinVersion: tls.VersionTLS12},
+			expectedError: true,
+		},
+		{
+			name:          "without client certificate",
+			tlsConfig:     &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12},
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: testCase.tlsConfig}}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlPath.String(), nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := client.Do(req)
+		if testCase.expectedError {
+			require.Error(t, err, testCase.name)
+			continue
+		}
+
+		require.NoError(t, err, testCase.name)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode, testCase.name)
+	}
+
+	cacheRequestID, _ := providerHandler.cacheRequestIDs.Load("registry.terraform.io/hashicorp/null")
+	assert.Equal(t, "cache", cacheRequestID)
+
+	cancel()
+	require.NoError(t, errGroup.Wait())
+}
+
+func TestProviderCacheAddr(t *testing.T) {
+	t.Parallel()
+
+	// reserve a free port to pin the server to
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	_, err = cache.NewServer(cache.WithAddr("127.0.0.1")).Listen()
+	require.ErrorContains(t, err, "expected host:port")
+
+	_, err = cache.NewServer(cache.WithAddr("127.0.0.1:70000")).Listen()
+	require.ErrorContains(t, err, "expected a port between 1 and 65535")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	token := fmt.Sprintf("%s:%s", cli.APIKeyAuth, uuid.New().String())
+
+	providerService := services.NewProviderService(t.TempDir(), t.TempDir(), nil, log.New())
+	providerHandler := &fakeProviderHandler{CommonProviderHandler: handlers.NewCommonProviderHandler(providerService, nil, nil)}
+
+	server := cache.NewServer(
+		cache.WithToken(token),
+		cache.WithProviderHandlers(providerHandler),
+		cache.WithAddr(addr),
+	)
+	ln, err = server.Listen()
+	require.NoError(t, err)
+	defer ln.Close()
+
+	_, err = cache.NewServer(cache.WithAddr(addr)).Listen()
+	require.ErrorContains(t, err, "already in use")
+
+	errGroup, ctx := errgroup.WithContext(ctx)
+	errGroup.Go(func() error {
+		return server.Run(ctx, ln)
+	})
+
+	urlPath := server.ProviderController.URL()
+	urlPath.Path += "/cache/registry.terraform.io/hashicorp/null/1.0.0/download/linux/amd64"
+
+	assert.Equal(t, addr, urlPath.Host)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+addr+urlPath.Path, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	cancel()
+	require.NoError(t, errGroup.Wait())
+
+	// listening on all interfaces, the server is advertised with the hostname
+	_, port, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+
+	server = cache.NewServer(cache.WithHostname("localhost"), cache.WithAddr(net.JoinHostPort("0.0.0.0", port)))
+	ln, err = server.Listen()
+	require.NoError(t, err)
+	defer ln.Close()
+
+	assert.Equal(t, "localhost:"+port, server.ProviderController.URL().Host)
+}
+
 func TestProviderCacheWithProviderCacheDir(t *testing.T) {
 	// testing.T can Setenv, but can't Unsetenv
 	unsetEnv := func(t *testing.T, v string) {
@@ -203,3 +533,215 @@ func TestProviderCacheWithProviderCacheDir(t *testing.T) {
 		require.Empty(t, entries, "No new directories should be created at $HOME")
 	})
 }
+
+func TestProviderCacheFilters(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake terraform binary is a shell script")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rootDir := t.TempDir()
+
+	// the fake terraform records the CLI config file it is run with, which routes the providers through the cache
+	terraformPath := filepath.Join(rootDir, "terraform")
+	require.NoError(t, os.WriteFile(terraformPath, []byte("#!/bin/sh\necho \"$TF_CLI_CONFIG_FILE\" >> \"$PWD/cli-config-files\"\n"), 0700)) //nolint:gosec
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(rootDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.RootWorkingDir = rootDir
+	opts.TerraformPath = terraformPath
+	opts.ProviderCacheDir = t.TempDir()
+	opts.ProviderCacheRegistryNames = []string{}
+	opts.ProviderCacheFilters = []string{"live/app", "live/db-*"}
+
+	server, err := cli.InitProviderCacheServer(opts)
+	require.NoError(t, err)
+
+	ln, err := server.Listen()
+	require.NoError(t, err)
+	defer ln.Close()
+
+	errGroup, ctx := errgroup.WithContext(ctx)
+	errGroup.Go(func() error {
+		return server.Run(ctx, ln)
+	})
+
+	testCases := []struct {
+		unit     string
+		expected bool
+	}{
+		{"live/app", true},
+		{"live/db-main", true},
+		{"live/other", false},
+		{"modules/app", false},
+	}
+
+	for _, testCase := range testCases {
+		unitDir := filepath.Join(rootDir, filepath.FromSlash(testCase.unit))
+		require.NoError(t, os.MkdirAll(unitDir, os.ModePerm))
+
+		unitOpts, err := opts.Clone(filepath.Join(unitDir, "terragrunt.hcl"))
+		require.NoError(t, err)
+
+		unitOpts.WorkingDir = unitDir
+		unitOpts.Env = map[string]string{}
+
+		_, err = server.TerraformCommandHook(ctx, unitOpts, []string{"init"})
+		require.NoError(t, err, testCase.unit)
+
+		cliConfigFiles, err := os.ReadFile(filepath.Join(unitDir, "cli-config-files"))
+		require.NoError(t, err, testCase.unit)
+
+		cliConfigFile := filepath.Join(unitDir, ".terraformrc")
+
+		if !testCase.expected {
+			assert.NoFileExists(t, cliConfigFile, testCase.unit)
+			assert.Equal(t, "\n", string(cliConfigFiles), testCase.unit)
+
+			continue
+		}
+
+		// the providers lock and init commands are both run with the CLI config installing the providers from the cache dir
+		assert.Equal(t, cliConfigFile+"\n"+cliConfigFile+"\n", string(cliConfigFiles), testCase.unit)
+
+		cliConfig, err := os.ReadFile(cliConfigFile)
+		require.NoError(t, err, testCase.unit)
+		assert.Contains(t, string(cliConfig), opts.ProviderCacheDir, testCase.unit)
+	}
+
+	cancel()
+	require.NoError(t, errGroup.Wait())
+}
+
+func TestProviderCacheMaxBytes(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const packageSize = 1000
+
+	cacheDir := t.TempDir()
+
+	// the providers cached by previous runs, accessed 5 and 3 hours ago
+	for packagePath, accessedAgo := range map[string]time.Duration{
+		"registry.terraform.io/hashicorp/old/1.0.0/linux_amd64":    5 * time.Hour,
+		"registry.terraform.io/hashicorp/recent/1.0.0/linux_amd64": 3 * time.Hour,
+	} {
+		packageDir := filepath.Join(cacheDir, filepath.FromSlash(packagePath))
+		require.NoError(t, os.MkdirAll(packageDir, os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(packageDir, "terraform-provider"), make([]byte, packageSize), 0755))
+
+		accessTime := time.Now().Add(-accessedAgo)
+		require.NoError(t, os.Chtimes(packageDir, accessTime, accessTime))
+	}
+
+	// the cap is exceeded by a single package once the two requested providers are cached
+	providerService := services.NewProviderService(cacheDir, t.TempDir(), nil, log.New()).WithMaxCacheBytes(packageSize*4 - packageSize/2)
+
+	errGroup, ctx := errgroup.WithContext(ctx)
+	errGroup.Go(func() error {
+		return providerService.Run(ctx)
+	})
+
+	// the pinned provider is being served to an in-flight request, and made the least recently accessed
+	pinnedDir := filepath.Join(cacheDir, "registry.terraform.io/hashicorp/pinned/1.0.0/linux_amd64")
+
+	providerService.CacheProvider(ctx, "pinned-request", newTestProvider(t, "pinned", packageSize))
+	_, err := providerService.WaitForCacheReady("pinned-request")
+	require.NoError(t, err)
+	require.DirExists(t, pinnedDir)
+
+	accessTime := time.Now().Add(-10 * time.Hour)
+	require.NoError(t, os.Chtimes(pinnedDir, accessTime, accessTime))
+
+	providerService.CacheProvider(ctx, "new-request", newTestProvider(t, "new", packageSize))
+	_, err = providerService.WaitForCacheReady("new-request")
+	require.NoError(t, err)
+
+	assert.NoDirExists(t, filepath.Join(cacheDir, "registry.terraform.io/hashicorp/old"))
+	assert.DirExists(t, filepath.Join(cacheDir, "registry.terraform.io/hashicorp/recent/1.0.0/linux_amd64"))
+	assert.DirExists(t, pinnedDir)
+	assert.DirExists(t, filepath.Join(cacheDir, "registry.terraform.io/hashicorp/new/1.0.0/linux_amd64"))
+
+	cancel()
+	require.NoError(t, errGroup.Wait())
+}
+
+func TestProviderCacheMaxBytesKeepsSymlinks(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const packageSize = 1000
+
+	cacheDir := t.TempDir()
+
+	oldDir := filepath.Join(cacheDir, "registry.terraform.io/hashicorp/old/1.0.0/linux_amd64")
+	require.NoError(t, os.MkdirAll(oldDir, os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(oldDir, "terraform-provider"), make([]byte, packageSize), 0755))
+
+	accessTime := time.Now().Add(-5 * time.Hour)
+	require.NoError(t, os.Chtimes(oldDir, accessTime, accessTime))
+
+	// the provider linked from the user plugins directory is accessed before the requested one
+	pluginDir := filepath.Join(t.TempDir(), "linked")
+	require.NoError(t, os.MkdirAll(pluginDir, os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(pluginDir, "terraform-provider"), make([]byte, packageSize), 0755))
+
+	linkedDir := filepath.Join(cacheDir, "registry.terraform.io/hashicorp/linked/1.0.0/linux_amd64")
+	require.NoError(t, os.MkdirAll(filepath.Dir(linkedDir), os.ModePerm))
+	require.NoError(t, os.Symlink(pluginDir, linkedDir))
+
+	// the cap can never be met, so every package that can be evicted is
+	providerService := services.NewProviderService(cacheDir, t.TempDir(), nil, log.New()).WithMaxCacheBytes(1)
+
+	errGroup, ctx := errgroup.WithContext(ctx)
+	errGroup.Go(func() error {
+		return providerService.Run(ctx)
+	})
+
+	providerService.CacheProvider(ctx, "new-request", newTestProvider(t, "new", packageSize))
+	_, err := providerService.WaitForCacheReady("new-request")
+	require.NoError(t, err)
+
+	assert.NoDirExists(t, oldDir)
+	assert.FileExists(t, filepath.Join(linkedDir, "terraform-provider"))
+
+	cancel()
+	require.NoError(t, errGroup.Wait())
+}
+
+// newTestProvider returns a provider whose download URL is a local archive with a package of the given size.
+func newTestProvider(t *testing.T, name string, packageSize int) *models.Provider {
+	t.Helper()
+
+	archivePath := filepath.Join(t.TempDir(), name+".zip")
+
+	file, err := os.Create(archivePath)
+	require.NoError(t, err)
+
+	zipWriter := zip.NewWriter(file)
+	entry, err := zipWriter.Create("terraform-provider")
+	require.NoError(t, err)
+	_, err = entry.Write(make([]byte, packageSize))
+	require.NoError(t, err)
+	require.NoError(t, zipWriter.Close())
+	require.NoError(t, file.Close())
+
+	return &models.Provider{
+		ResponseBody: &models.ResponseBody{DownloadURL: archivePath, Filename: name + ".zip"},
+		RegistryName: "registry.terraform.io",
+		Namespace:    "hashicorp",
+		Name:         name,
+		Version:      "1.0.0",
+		OS:           "linux",
+		Arch:         "amd64",
+	}
+}
@@ -43,3 +43,17 @@ type GenerateFileRemoveError struct {
 func (err GenerateFileRemoveError) Error() string {
 	return "Can not remove terraform file: " + err.path
 }
+
+// ConflictingGenerateOptionsError is returned when an option that only handles the files generated by Terragrunt is
+// used together with disable_signature, without which Terragrunt can't recognize the files it generated.
+type ConflictingGenerateOptionsError struct {
+	Option string
+	Value  string
+}
+
+func (err ConflictingGenerateOptionsError) Error() string {
+	return fmt.Sprintf(
+		"%s = %q can not be used with disable_signature = true: Terragrunt recognizes the files it generated by their signature",
+		err.Option, err.Value,
+	)
+}
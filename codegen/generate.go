@@ -4,6 +4,8 @@ import (
 	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"sort"
@@ -12,6 +14,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsimple"
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
 	ctyjson "github.com/zclconf/go-cty/cty/json"
 
 	"github.com/gruntwork-io/terragrunt/internal/errors"
@@ -75,6 +78,25 @@ type GenerateConfig struct {
 	Disable          bool   `cty:"disable"`
 }
 
+// Validate returns an error if the options of the generate config contradict each other.
+func (config GenerateConfig) Validate() error {
+	if !config.DisableSignature {
+		return nil
+	}
+
+	// Terragrunt recognizes the files it generated by the signature, so the options that only handle generated
+	// files can't work without it.
+	if config.IfExists == ExistsOverwriteTerragrunt {
+		return errors.New(ConflictingGenerateOptionsError{Option: "if_exists", Value: ExistsOverwriteTerragruntStr})
+	}
+
+	if config.IfDisabled == DisabledRemoveTerragrunt {
+		return errors.New(ConflictingGenerateOptionsError{Option: "if_disabled", Value: DisabledRemoveTerragruntStr})
+	}
+
+	return nil
+}
+
 // WriteToFile will generate a new file at the given target path with the given contents. If a file already exists at
 // the target path, the behavior depends on the value of IfExists:
 // - if ExistsError, return an error.
@@ -223,13 +245,88 @@ func fileWasGeneratedByTerragrunt(path string) (bool, error) {
 	reader := bufio.NewReader(file)
 
 	firstLine, err := reader.ReadString('\n')
-	if err != nil {
+	if err != nil && !errors.Is(err, io.EOF) {
 		return false, errors.New(err)
 	}
 
 	return strings.HasSuffix(strings.TrimSpace(firstLine), TerragruntGeneratedSignature), nil
 }
 
+// FindGeneratedFiles returns the files in the given directory and its subdirectories that have the Terragrunt
+// signature. The Terragrunt cache, the OpenTofu/Terraform data directory and hidden directories are skipped.
+func FindGeneratedFiles(dir string) ([]string, error) {
+	var paths []string
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if path != dir && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		wasGenerated, err := fileWasGeneratedByTerragrunt(path)
+		if err != nil {
+			return err
+		}
+
+		if wasGenerated {
+			paths = append(paths, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return paths, nil
+}
+
+// RemoveGeneratedFiles removes the given files that have the Terragrunt signature and returns them. Files that do not
+// exist or were not generated by Terragrunt are left untouched. With dryRun, the files that would be removed are
+// returned without removing them.
+func RemoveGeneratedFiles(terragruntOptions *options.TerragruntOptions, paths []string, dryRun bool) ([]string, error) {
+	var removed []string
+
+	for _, path := range paths {
+		if !util.FileExists(path) || util.IsDir(path) {
+			continue
+		}
+
+		wasGenerated, err := fileWasGeneratedByTerragrunt(path)
+		if err != nil {
+			return removed, err
+		}
+
+		if !wasGenerated {
+			terragruntOptions.Logger.Debugf("Skipping %s because it was not generated by Terragrunt.", path)
+			continue
+		}
+
+		if !dryRun {
+			if err := os.Remove(path); err != nil {
+				return removed, errors.New(err)
+			}
+
+			terragruntOptions.Logger.Debugf("Removed generated file %s.", path)
+		}
+
+		removed = append(removed, path)
+	}
+
+	return removed, nil
+}
+
 // RemoteStateConfigToTerraformCode converts the arbitrary map that represents a remote state config into HCL code to configure that remote state.
 func RemoteStateConfigToTerraformCode(backend string, config map[string]interface{}) ([]byte, error) {
 	f := hclwrite.NewEmptyFile()
@@ -287,6 +384,42 @@ func RemoteStateConfigToTerraformCode(backend string, config map[string]interfac
 	return f.Bytes(), nil
 }
 
+// RequiredProvidersToTerraformCode converts the required Terraform version and the map of provider name to provider
+// requirements (e.g. `source` and `version`) into HCL code of a terraform block that pins them.
+func RequiredProvidersToTerraformCode(requiredVersion string, requiredProviders map[string]map[string]string) ([]byte, error) {
+	f := hclwrite.NewEmptyFile()
+	terraformBlockBody := f.Body().AppendNewBlock("terraform", nil).Body()
+
+	if requiredVersion != "" {
+		terraformBlockBody.SetAttributeValue("required_version", cty.StringVal(requiredVersion))
+	}
+
+	if len(requiredProviders) == 0 {
+		return f.Bytes(), nil
+	}
+
+	requiredProvidersBlockBody := terraformBlockBody.AppendNewBlock("required_providers", nil).Body()
+
+	var providerNames = make([]string, 0, len(requiredProviders))
+
+	for name := range requiredProviders {
+		providerNames = append(providerNames, name)
+	}
+
+	sort.Strings(providerNames)
+
+	for _, name := range providerNames {
+		ctyVal, err := convertValue(requiredProviders[name])
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		requiredProvidersBlockBody.SetAttributeValue(name, ctyVal.Value)
+	}
+
+	return f.Bytes(), nil
+}
+
 func convertValue(v interface{}) (ctyjson.SimpleJSONValue, error) {
 	jsonBytes, err := json.Marshal(v)
 	if err != nil {
@@ -133,3 +133,104 @@ func TestGenerateDisabling(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		name             string
+		ifExists         codegen.GenerateConfigExists
+		ifDisabled       codegen.GenerateConfigDisabled
+		disableSignature bool
+		expectedErr      string
+	}{
+		{
+			"overwrite-terragrunt-with-signature",
+			codegen.ExistsOverwriteTerragrunt,
+			codegen.DisabledRemoveTerragrunt,
+			false,
+			"",
+		},
+		{
+			"overwrite-without-signature",
+			codegen.ExistsOverwrite,
+			codegen.DisabledRemove,
+			true,
+			"",
+		},
+		{
+			"overwrite-terragrunt-without-signature",
+			codegen.ExistsOverwriteTerragrunt,
+			codegen.DisabledSkip,
+			true,
+			`if_exists = "overwrite_terragrunt" can not be used with disable_signature = true`,
+		},
+		{
+			"remove-terragrunt-without-signature",
+			codegen.ExistsSkip,
+			codegen.DisabledRemoveTerragrunt,
+			true,
+			`if_disabled = "remove_terragrunt" can not be used with disable_signature = true`,
+		},
+	}
+
+	for _, tt := range tc {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := codegen.GenerateConfig{
+				Path:             "provider.tf",
+				IfExists:         tt.ifExists,
+				IfDisabled:       tt.ifDisabled,
+				DisableSignature: tt.disableSignature,
+			}
+
+			err := config.Validate()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
+
+func TestRequiredProvidersToTerraformCode(t *testing.T) {
+	t.Parallel()
+
+	requiredProviders := map[string]map[string]string{
+		"null": {"source": "hashicorp/null", "version": "3.2.2"},
+		"aws":  {"version": "~> 5.0", "source": "hashicorp/aws"},
+	}
+
+	expected := []byte(`terraform {
+  required_version = ">= 1.6"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+    null = {
+      source  = "hashicorp/null"
+      version = "3.2.2"
+    }
+  }
+}
+`)
+
+	output, err := codegen.RequiredProvidersToTerraformCode(">= 1.6", requiredProviders)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(output))
+
+	// the output must not depend on the map iteration order
+	for i := 0; i < 20; i++ {
+		actual, err := codegen.RequiredProvidersToTerraformCode(">= 1.6", requiredProviders)
+		require.NoError(t, err)
+		assert.Equal(t, output, actual)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -65,6 +66,8 @@ const (
 	MetadataEngine                      = "engine"
 	MetadataGenerateConfigs             = "generate"
 	MetadataRetryableErrors             = "retryable_errors"
+	MetadataRetryableErrorsMerge        = "retryable_errors_merge"
+	MetadataGlobalExtraArgs             = "global_extra_arguments"
 	MetadataRetryMaxAttempts            = "retry_max_attempts"
 	MetadataRetrySleepIntervalSec       = "retry_sleep_interval_sec"
 	MetadataDependentModules            = "dependent_modules"
@@ -94,6 +97,9 @@ var (
 	}
 
 	DefaultGenerateBlockIfDisabledValueStr = codegen.DisabledSkipStr
+
+	// backendBlockRegexp matches a `backend` or `cloud` block in HCL or JSON OpenTofu/Terraform code.
+	backendBlockRegexp = regexp.MustCompile(`(?m)^\s*(backend\s+"[^"]*"|cloud)\s*\{|"(backend|cloud)"\s*:`)
 )
 
 // DecodedBaseBlocks decoded base blocks struct
@@ -125,6 +131,8 @@ type TerragruntConfig struct {
 	TerragruntDependencies      Dependencies
 	GenerateConfigs             map[string]codegen.GenerateConfig
 	RetryableErrors             []string
+	RetryableErrorsMerge        string
+	GlobalExtraArgs             map[string][]string
 	RetryMaxAttempts            *int
 	RetrySleepIntervalSec       *int
 	Engine                      *EngineConfig
@@ -150,6 +158,23 @@ func (cfg *TerragruntConfig) String() string {
 	return fmt.Sprintf("TerragruntConfig{Terraform = %v, RemoteState = %v, Dependencies = %v, PreventDestroy = %v}", cfg.Terraform, cfg.RemoteState, cfg.Dependencies, cfg.PreventDestroy)
 }
 
+// StoresStateRemotely returns true if the config sets up a backend, with a `remote_state` block or with a generate
+// block generating a `backend` or `cloud` block. Otherwise, the state is stored locally unless the OpenTofu/Terraform
+// code itself configures a backend.
+func (cfg *TerragruntConfig) StoresStateRemotely() bool {
+	if cfg.RemoteState != nil {
+		return true
+	}
+
+	for _, genConfig := range cfg.GenerateConfigs {
+		if !genConfig.Disable && backendBlockRegexp.MatchString(genConfig.Contents) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // GetIAMRoleOptions is a helper function that converts the Terragrunt config IAM role attributes to
 // options.IAMRoleOptions struct.
 func (cfg *TerragruntConfig) GetIAMRoleOptions() options.IAMRoleOptions {
@@ -227,6 +252,18 @@ type terragruntConfigFile struct {
 	RetryMaxAttempts      *int     `hcl:"retry_max_attempts,optional"`
 	RetrySleepIntervalSec *int     `hcl:"retry_sleep_interval_sec,optional"`
 
+	// How the retryable_errors are merged with the ones of the included configs, either `append` or `replace`.
+	RetryableErrorsMerge *string `hcl:"retryable_errors_merge,optional"`
+
+	// Extra arguments passed to every OpenTofu/Terraform command of the given name, e.g.:
+	//
+	// global_extra_arguments = {
+	//   apply = ["-lock-timeout=20m"]
+	// }
+	//
+	// They are passed before the arguments of the `extra_arguments` blocks, so the latter take precedence.
+	GlobalExtraArgs map[string][]string `hcl:"global_extra_arguments,optional"`
+
 	// This struct is used for validating and parsing the entire terragrunt config. Since locals and include are
 	// evaluated in a completely separate cycle, it should not be evaluated here. Otherwise, we can't support self
 	// referencing other elements in the same block.
@@ -249,11 +286,11 @@ type terragruntIncludeIgnore struct {
 
 // Configuration for Terraform remote state as parsed from a terragrunt.hcl config file
 type remoteStateConfigFile struct {
-	Backend                       string                     `hcl:"backend,attr"`
-	DisableInit                   *bool                      `hcl:"disable_init,attr"`
-	DisableDependencyOptimization *bool                      `hcl:"disable_dependency_optimization,attr"`
-	Generate                      *remoteStateConfigGenerate `hcl:"generate,attr"`
-	Config                        cty.Value                  `hcl:"config,attr"`
+	Backend                       string     `hcl:"backend,attr"`
+	DisableInit                   *bool      `hcl:"disable_init,attr"`
+	DisableDependencyOptimization *bool      `hcl:"disable_dependency_optimization,attr"`
+	Generate                      *cty.Value `hcl:"generate,attr"`
+	Config                        cty.Value  `hcl:"config,attr"`
 }
 
 func (remoteState *remoteStateConfigFile) String() string {
@@ -272,9 +309,25 @@ func (remoteState *remoteStateConfigFile) toConfig() (*remote.RemoteState, error
 
 	config.Backend = remoteState.Backend
 	if remoteState.Generate != nil {
-		config.Generate = &remote.RemoteStateGenerate{
-			Path:     remoteState.Generate.Path,
-			IfExists: remoteState.Generate.IfExists,
+		// The generate attribute is decoded through a map, rather than directly with gocty, so that optional keys
+		// such as `partial` can be omitted. Unknown keys are still rejected, so that a typo isn't silently ignored.
+		generateMap, err := ParseCtyValueToMap(*remoteState.Generate)
+		if err != nil {
+			return nil, err
+		}
+
+		config.Generate = &remote.RemoteStateGenerate{}
+
+		decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			Result:      config.Generate,
+			ErrorUnused: true,
+		})
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		if err := decoder.Decode(generateMap); err != nil {
+			return nil, errors.New(err)
 		}
 	}
 
@@ -297,12 +350,6 @@ func (remoteState *remoteStateConfigFile) toConfig() (*remote.RemoteState, error
 	return config, err
 }
 
-type remoteStateConfigGenerate struct {
-	// We use cty instead of hcl, since we are using this type to convert an attr and not a block.
-	Path     string `cty:"path"`
-	IfExists string `cty:"if_exists"`
-}
-
 // Struct used to parse generate blocks. This will later be converted to GenerateConfig structs so that we can go
 // through the codegen routine.
 type terragruntGenerateBlock struct {
@@ -311,9 +358,40 @@ type terragruntGenerateBlock struct {
 	IfExists         string  `hcl:"if_exists,attr" mapstructure:"if_exists"`
 	IfDisabled       *string `hcl:"if_disabled,attr" mapstructure:"if_disabled"`
 	CommentPrefix    *string `hcl:"comment_prefix,attr" mapstructure:"comment_prefix"`
-	Contents         string  `hcl:"contents,attr" mapstructure:"contents"`
+	Contents         *string `hcl:"contents,attr" mapstructure:"contents"`
 	DisableSignature *bool   `hcl:"disable_signature,attr" mapstructure:"disable_signature"`
 	Disable          *bool   `hcl:"disable,attr" mapstructure:"disable"`
+
+	// Instead of the contents, a terraform block pinning the given versions can be generated, e.g. into a `versions.tf`.
+	RequiredVersion   *string                      `hcl:"required_version,attr" mapstructure:"required_version"`
+	RequiredProviders map[string]map[string]string `hcl:"required_providers,optional" mapstructure:"required_providers"`
+}
+
+// contents returns the contents of the file to generate, rendering the terraform block with the required versions if
+// the block doesn't set the contents.
+func (block *terragruntGenerateBlock) contents() (string, error) {
+	hasRequiredVersions := block.RequiredVersion != nil || block.RequiredProviders != nil
+
+	switch {
+	case block.Contents != nil && hasRequiredVersions:
+		return "", errors.New(GenerateBlockContentsConflictError{Name: block.Name})
+	case block.Contents != nil:
+		return *block.Contents, nil
+	case !hasRequiredVersions:
+		return "", errors.New(GenerateBlockContentsMissingError{Name: block.Name})
+	}
+
+	var requiredVersion string
+	if block.RequiredVersion != nil {
+		requiredVersion = *block.RequiredVersion
+	}
+
+	contents, err := codegen.RequiredProvidersToTerraformCode(requiredVersion, block.RequiredProviders)
+	if err != nil {
+		return "", err
+	}
+
+	return string(contents), nil
 }
 
 type IncludeConfigsMap map[string]IncludeConfig
@@ -388,6 +466,14 @@ func (include *IncludeConfig) GetMergeStrategy() (MergeStrategyType, error) {
 
 type MergeStrategyType string
 
+// The ways the retryable_errors of a config are merged with the ones of the included configs.
+const (
+	// RetryableErrorsMergeAppend appends the retryable errors to the ones of the included configs.
+	RetryableErrorsMergeAppend = "append"
+	// RetryableErrorsMergeReplace replaces the retryable errors of the included configs.
+	RetryableErrorsMergeReplace = "replace"
+)
+
 const (
 	NoMerge          MergeStrategyType = "no_merge"
 	ShallowMerge     MergeStrategyType = "shallow"
@@ -459,12 +545,35 @@ type TerraformConfig struct {
 	IncludeInCopy *[]string `hcl:"include_in_copy,attr"`
 
 	CopyTerraformLockFile *bool `hcl:"copy_terraform_lock_file,attr"`
+
+	// The workspace to select before running the terraform commands. It is created if it doesn't exist, unless
+	// CreateWorkspace is false.
+	Workspace       *string `hcl:"workspace,attr"`
+	CreateWorkspace *bool   `hcl:"create_workspace,attr"`
 }
 
 func (cfg *TerraformConfig) String() string {
 	return fmt.Sprintf("TerraformConfig{Source = %v}", cfg.Source)
 }
 
+// GetWorkspace returns the workspace to select before running the terraform commands, or an empty string if not set.
+func (cfg *TerraformConfig) GetWorkspace() string {
+	if cfg == nil || cfg.Workspace == nil {
+		return ""
+	}
+
+	return *cfg.Workspace
+}
+
+// GetCreateWorkspace returns whether the workspace is created if it doesn't exist, true by default.
+func (cfg *TerraformConfig) GetCreateWorkspace() bool {
+	if cfg == nil || cfg.CreateWorkspace == nil {
+		return true
+	}
+
+	return *cfg.CreateWorkspace
+}
+
 func (cfg *TerraformConfig) GetBeforeHooks() []Hook {
 	if cfg == nil {
 		return nil
@@ -655,6 +764,37 @@ func GetDefaultConfigPath(workingDir string) string {
 	return configPath
 }
 
+// FindConfigInParentDirs walks up from the given directory to the nearest directory with a Terragrunt config file and
+// returns the path of that file. If configName is set, the config file is looked up by that name, otherwise by the
+// default config names. The search stops at the root of the git repository containing the directory, or at the
+// filesystem root if the directory is not in a git repository. Returns an empty string if no config file is found.
+func FindConfigInParentDirs(dir, configName string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	for {
+		configPath := GetDefaultConfigPath(dir)
+		if configName != "" {
+			configPath = util.JoinPath(dir, configName)
+		}
+
+		if files.FileExists(configPath) && !files.IsDir(configPath) {
+			return configPath, nil
+		}
+
+		parentDir := filepath.Dir(dir)
+
+		// stop at the root of the git repository or at the filesystem root
+		if files.FileExists(filepath.Join(dir, ".git")) || parentDir == dir {
+			return "", nil
+		}
+
+		dir = parentDir
+	}
+}
+
 // FindConfigFilesInPath returns a list of all Terragrunt config files in the given path or any subfolder of the path. A file is a Terragrunt
 // config file if it has a name as returned by the DefaultConfigPath method
 func FindConfigFilesInPath(rootPath string, opts *options.TerragruntOptions) ([]string, error) {
@@ -664,9 +804,14 @@ func FindConfigFilesInPath(rootPath string, opts *options.TerragruntOptions) ([]
 
 	walkFunc := filepath.Walk
 	if experiment.Evaluate(opts.ExperimentMode) {
-		walkFunc = util.WalkWithSymlinks
+		walkFunc = func(root string, fn filepath.WalkFunc) error {
+			return util.WalkWithSymlinks(opts.Logger, root, fn)
+		}
 	}
 
+	// The rules of the ignore files found so far by directory, each including the rules of its parent directories.
+	ignoreRules := map[string][]ignoreRule{}
+
 	err := walkFunc(rootPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
@@ -676,13 +821,32 @@ func FindConfigFilesInPath(rootPath string, opts *options.TerragruntOptions) ([]
 			return nil
 		}
 
+		if opts.MaxFolderDepth > 0 && folderDepth(rootPath, path) > opts.MaxFolderDepth {
+			return filepath.SkipDir
+		}
+
+		rules := ignoreRules[filepath.Dir(path)]
+		if isIgnored(rules, path) {
+			opts.Logger.Debugf("Skipping %s as it is ignored by %s", path, opts.IgnoreFile)
+			return filepath.SkipDir
+		}
+
+		if opts.IgnoreFile != "" {
+			dirRules, err := readIgnoreFile(path, opts.IgnoreFile)
+			if err != nil {
+				return err
+			}
+
+			ignoreRules[path] = append(slices.Clip(rules), dirRules...)
+		}
+
 		if ok, err := isTerragruntModuleDir(path, opts); err != nil {
 			return err
 		} else if !ok {
 			return filepath.SkipDir
 		}
 
-		for _, configFile := range append(DefaultTerragruntConfigPaths, filepath.Base(opts.TerragruntConfigPath)) {
+		for _, configFile := range configFilenamesForDir(rootPath, path, opts) {
 			if !filepath.IsAbs(configFile) {
 				configFile = util.JoinPath(path, configFile)
 			}
@@ -699,6 +863,79 @@ func FindConfigFilesInPath(rootPath string, opts *options.TerragruntOptions) ([]
 	return configFiles, err
 }
 
+// configFilenamesForDir returns the names of the config files to look for in the given directory. If the directory,
+// relative to the root path, matches one of the globs of the config filename overrides, only the filename of the longest
+// matching glob is looked for, otherwise the default config names and the name of the config passed by the user.
+func configFilenamesForDir(rootPath, dir string, opts *options.TerragruntOptions) []string {
+	if filename := configFilenameOverride(rootPath, dir, opts); filename != "" {
+		return []string{filename}
+	}
+
+	return append(slices.Clip(DefaultTerragruntConfigPaths), filepath.Base(opts.TerragruntConfigPath))
+}
+
+// configFilenameOverride returns the filename of the longest config filename override glob matching the given directory,
+// relative to the root path, or an empty string if no glob matches.
+func configFilenameOverride(rootPath, dir string, opts *options.TerragruntOptions) string {
+	if len(opts.ConfigFilenameOverrides) == 0 {
+		return ""
+	}
+
+	relDir, err := filepath.Rel(rootPath, dir)
+	if err != nil {
+		return ""
+	}
+
+	relDir = filepath.ToSlash(relDir)
+
+	var matchedGlob string
+
+	for glob := range opts.ConfigFilenameOverrides {
+		pattern := filepath.ToSlash(filepath.Clean(glob))
+
+		if !util.MatchGlobPath(pattern, relDir) {
+			continue
+		}
+
+		if len(glob) > len(matchedGlob) || (len(glob) == len(matchedGlob) && glob < matchedGlob) {
+			matchedGlob = glob
+		}
+	}
+
+	if matchedGlob == "" {
+		return ""
+	}
+
+	return opts.ConfigFilenameOverrides[matchedGlob]
+}
+
+// GetConfigPathForDir returns the path of the config file of the unit in the given directory. If the directory, relative
+// to the root working directory, matches one of the globs of --terragrunt-config-filename-override, the config file is
+// looked up by the filename of that glob, as the `*-all` commands do, otherwise by the default config names.
+func GetConfigPathForDir(dir string, opts *options.TerragruntOptions) string {
+	if files.IsDir(dir) {
+		if filename := configFilenameOverride(opts.RootWorkingDir, dir, opts); filename != "" {
+			if filepath.IsAbs(filename) {
+				return filename
+			}
+
+			return util.JoinPath(dir, filename)
+		}
+	}
+
+	return GetDefaultConfigPath(dir)
+}
+
+// folderDepth returns the number of folders the given path is below the root path, 0 for the root path itself.
+func folderDepth(rootPath, path string) int {
+	rel, err := filepath.Rel(rootPath, path)
+	if err != nil || rel == "." {
+		return 0
+	}
+
+	return len(strings.Split(filepath.ToSlash(rel), "/"))
+}
+
 // isTerragruntModuleDir returns true if the given path contains a Terragrunt module and false otherwise. The path
 // can not contain a cache, data, or download dir.
 func isTerragruntModuleDir(path string, terragruntOptions *options.TerragruntOptions) (bool, error) {
@@ -1111,6 +1348,21 @@ func convertToTerragruntConfig(ctx *ParsingContext, configPath string, terragrun
 		terragruntConfig.SetFieldMetadata(MetadataRetryableErrors, defaultMetadata)
 	}
 
+	if terragruntConfigFromFile.RetryableErrorsMerge != nil {
+		switch merge := *terragruntConfigFromFile.RetryableErrorsMerge; merge {
+		case RetryableErrorsMergeAppend, RetryableErrorsMergeReplace:
+			terragruntConfig.RetryableErrorsMerge = merge
+			terragruntConfig.SetFieldMetadata(MetadataRetryableErrorsMerge, defaultMetadata)
+		default:
+			return nil, errors.New(InvalidRetryableErrorsMergeError(merge))
+		}
+	}
+
+	if terragruntConfigFromFile.GlobalExtraArgs != nil {
+		terragruntConfig.GlobalExtraArgs = terragruntConfigFromFile.GlobalExtraArgs
+		terragruntConfig.SetFieldMetadata(MetadataGlobalExtraArgs, defaultMetadata)
+	}
+
 	if terragruntConfigFromFile.RetryMaxAttempts != nil {
 		terragruntConfig.RetryMaxAttempts = terragruntConfigFromFile.RetryMaxAttempts
 		terragruntConfig.SetFieldMetadata(MetadataRetryMaxAttempts, defaultMetadata)
@@ -1227,13 +1479,18 @@ func convertToTerragruntConfig(ctx *ParsingContext, configPath string, terragrun
 			return nil, err
 		}
 
+		contents, err := block.contents()
+		if err != nil {
+			return nil, err
+		}
+
 		genConfig := codegen.GenerateConfig{
 			Path:          block.Path,
 			IfExists:      ifExists,
 			IfExistsStr:   block.IfExists,
 			IfDisabled:    ifDisabled,
 			IfDisabledStr: *block.IfDisabled,
-			Contents:      block.Contents,
+			Contents:      contents,
 		}
 		if block.CommentPrefix == nil {
 			genConfig.CommentPrefix = codegen.DefaultCommentPrefix
@@ -1253,6 +1510,10 @@ func convertToTerragruntConfig(ctx *ParsingContext, configPath string, terragrun
 			genConfig.Disable = *block.Disable
 		}
 
+		if err := genConfig.Validate(); err != nil {
+			return nil, errors.New(InvalidGenerateBlockError{Name: block.Name, Err: err})
+		}
+
 		terragruntConfig.GenerateConfigs[block.Name] = genConfig
 		terragruntConfig.SetFieldMetadataWithType(MetadataGenerateConfigs, block.Name, defaultMetadata)
 	}
@@ -126,6 +126,19 @@ func TerragruntConfigAsCty(config *TerragruntConfig) (cty.Value, error) {
 		output[MetadataRetryableErrors] = retryableCty
 	}
 
+	if config.RetryableErrorsMerge != "" {
+		output[MetadataRetryableErrorsMerge] = gostringToCty(config.RetryableErrorsMerge)
+	}
+
+	globalExtraArgsCty, err := goTypeToCty(config.GlobalExtraArgs)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	if globalExtraArgsCty != cty.NilVal {
+		output[MetadataGlobalExtraArgs] = globalExtraArgsCty
+	}
+
 	iamAssumeRoleDurationCty, err := goTypeToCty(config.IamAssumeRoleDuration)
 	if err != nil {
 		return cty.NilVal, err
@@ -236,6 +249,16 @@ func TerragruntConfigAsCtyWithMetadata(config *TerragruntConfig) (cty.Value, err
 		return cty.NilVal, err
 	}
 
+	if config.RetryableErrorsMerge != "" {
+		if err := wrapWithMetadata(config, config.RetryableErrorsMerge, MetadataRetryableErrorsMerge, &output); err != nil {
+			return cty.NilVal, err
+		}
+	}
+
+	if err := wrapWithMetadata(config, config.GlobalExtraArgs, MetadataGlobalExtraArgs, &output); err != nil {
+		return cty.NilVal, err
+	}
+
 	if err := wrapWithMetadata(config, config.IamAssumeRoleDuration, MetadataIamAssumeRoleDuration, &output); err != nil {
 		return cty.NilVal, err
 	}
@@ -556,6 +579,8 @@ type CtyTerraformConfig struct {
 	Source                *string                            `cty:"source"`
 	IncludeInCopy         *[]string                          `cty:"include_in_copy"`
 	CopyTerraformLockFile *bool                              `cty:"copy_terraform_lock_file"`
+	Workspace             *string                            `cty:"workspace"`
+	CreateWorkspace       *bool                              `cty:"create_workspace"`
 	BeforeHooks           map[string]Hook                    `cty:"before_hook"`
 	AfterHooks            map[string]Hook                    `cty:"after_hook"`
 	ErrorHooks            map[string]ErrorHook               `cty:"error_hook"`
@@ -571,6 +596,8 @@ func terraformConfigAsCty(config *TerraformConfig) (cty.Value, error) {
 		Source:                config.Source,
 		IncludeInCopy:         config.IncludeInCopy,
 		CopyTerraformLockFile: config.CopyTerraformLockFile,
+		Workspace:             config.Workspace,
+		CreateWorkspace:       config.CreateWorkspace,
 		ExtraArgs:             map[string]TerraformExtraArguments{},
 		BeforeHooks:           map[string]Hook{},
 		AfterHooks:            map[string]Hook{},
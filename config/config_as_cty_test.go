@@ -80,10 +80,11 @@ func TestTerragruntConfigAsCtyDrift(t *testing.T) {
 		Dependencies: &config.ModuleDependencies{
 			Paths: []string{"foo"},
 		},
-		DownloadDir:    ".terragrunt-cache",
-		PreventDestroy: &testTrue,
-		Skip:           &testTrue,
-		IamRole:        "terragruntRole",
+		DownloadDir:          ".terragrunt-cache",
+		PreventDestroy:       &testTrue,
+		Skip:                 &testTrue,
+		IamRole:              "terragruntRole",
+		RetryableErrorsMerge: config.RetryableErrorsMergeAppend,
 		Inputs: map[string]interface{}{
 			"aws_region": "us-east-1",
 		},
@@ -138,6 +139,9 @@ func TestTerragruntConfigAsCtyDrift(t *testing.T) {
 			},
 		},
 		Exclude: &config.ExcludeConfig{},
+		GlobalExtraArgs: map[string][]string{
+			"apply": {"-lock-timeout=20m"},
+		},
 	}
 	ctyVal, err := config.TerragruntConfigAsCty(&testConfig)
 	require.NoError(t, err)
@@ -266,6 +270,10 @@ func terragruntConfigStructFieldToMapKey(t *testing.T, fieldName string) (string
 		return "", false
 	case "RetryableErrors":
 		return "retryable_errors", true
+	case "RetryableErrorsMerge":
+		return "retryable_errors_merge", true
+	case "GlobalExtraArgs":
+		return "global_extra_arguments", true
 	case "RetryMaxAttempts":
 		return "retry_max_attempts", true
 	case "RetrySleepIntervalSec":
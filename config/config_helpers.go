@@ -1,6 +1,7 @@
 package config
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -8,6 +9,7 @@ import (
 	"reflect"
 	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 	"unicode/utf8"
 
@@ -19,6 +21,7 @@ import (
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/function"
 	"github.com/zclconf/go-cty/cty/gocty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
 
 	"github.com/gruntwork-io/terragrunt/awshelper"
 	"github.com/gruntwork-io/terragrunt/config/hclparse"
@@ -58,6 +61,7 @@ const (
 	FuncNameGetAWSAccountID                         = "get_aws_account_id"
 	FuncNameGetAWSCallerIdentityArn                 = "get_aws_caller_identity_arn"
 	FuncNameGetAWSCallerIdentityUserID              = "get_aws_caller_identity_user_id"
+	FuncNameGetAWSSecret                            = "get_aws_secret"
 	FuncNameGetTerraformCommandsThatNeedVars        = "get_terraform_commands_that_need_vars"
 	FuncNameGetTerraformCommandsThatNeedLocking     = "get_terraform_commands_that_need_locking"
 	FuncNameGetTerraformCommandsThatNeedInput       = "get_terraform_commands_that_need_input"
@@ -72,8 +76,11 @@ const (
 	FuncNameStrContains                             = "strcontains"
 	FuncNameTimeCmp                                 = "timecmp"
 	FuncNameMarkAsRead                              = "mark_as_read"
+	FuncNameNamePrefix                              = "name_prefix"
+	FuncNameStableSuffix                            = "stable_suffix"
 
-	sopsCacheName = "sopsCache"
+	sopsCacheName      = "sopsCache"
+	awsSecretCacheName = "awsSecretCache"
 )
 
 // TerraformCommandsNeedLocking is a list of terraform commands that accept -lock-timeout
@@ -163,6 +170,7 @@ func createTerragruntEvalContext(ctx *ParsingContext, configPath string) (*hcl.E
 		FuncNameGetAWSAccountID:                         wrapVoidToStringAsFuncImpl(ctx, getAWSAccountID),
 		FuncNameGetAWSCallerIdentityArn:                 wrapVoidToStringAsFuncImpl(ctx, getAWSCallerIdentityARN),
 		FuncNameGetAWSCallerIdentityUserID:              wrapVoidToStringAsFuncImpl(ctx, getAWSCallerIdentityUserID),
+		FuncNameGetAWSSecret:                            getAWSSecretAsFuncImpl(ctx),
 		FuncNameGetTerraformCommandsThatNeedVars:        wrapStaticValueToStringSliceAsFuncImpl(TerraformCommandsNeedVars),
 		FuncNameGetTerraformCommandsThatNeedLocking:     wrapStaticValueToStringSliceAsFuncImpl(TerraformCommandsNeedLocking),
 		FuncNameGetTerraformCommandsThatNeedInput:       wrapStaticValueToStringSliceAsFuncImpl(TerraformCommandsNeedInput),
@@ -173,6 +181,8 @@ func createTerragruntEvalContext(ctx *ParsingContext, configPath string) (*hcl.E
 		FuncNameReadTfvarsFile:                          wrapStringSliceToStringAsFuncImpl(ctx, readTFVarsFile),
 		FuncNameGetWorkingDir:                           wrapVoidToStringAsFuncImpl(ctx, getWorkingDir),
 		FuncNameMarkAsRead:                              wrapStringSliceToStringAsFuncImpl(ctx, markAsRead),
+		FuncNameNamePrefix:                              wrapStringSliceToStringAsFuncImpl(ctx, NamePrefix),
+		FuncNameStableSuffix:                            stableSuffixAsFuncImpl(),
 
 		// Map with HCL functions introduced in Terraform after v0.15.3, since upgrade to a later version is not supported
 		// https://github.com/gruntwork-io/terragrunt/blob/master/go.mod#L22
@@ -195,6 +205,8 @@ func createTerragruntEvalContext(ctx *ParsingContext, configPath string) (*hcl.E
 		functions[k] = v
 	}
 
+	applyFunctionsPolicy(ctx, functions)
+
 	evalCtx := &hcl.EvalContext{
 		Functions: functions,
 	}
@@ -226,6 +238,39 @@ func createTerragruntEvalContext(ctx *ParsingContext, configPath string) (*hcl.E
 	return evalCtx, nil
 }
 
+// applyFunctionsPolicy replaces the functions that are not allowed by the --terragrunt-allowed-functions and
+// --terragrunt-denied-functions flags with a function that always fails, so that using them results in an error
+// pointing to the file and line of the call.
+func applyFunctionsPolicy(ctx *ParsingContext, functions map[string]function.Function) {
+	allowed := ctx.TerragruntOptions.AllowedFunctions
+	denied := ctx.TerragruntOptions.DeniedFunctions
+
+	if len(allowed) == 0 && len(denied) == 0 {
+		return
+	}
+
+	for name := range functions {
+		if (len(allowed) > 0 && !util.ListContainsElement(allowed, name)) || util.ListContainsElement(denied, name) {
+			functions[name] = notAllowedFuncImpl(name)
+		}
+	}
+}
+
+// notAllowedFuncImpl returns a function that accepts any arguments and always fails with FunctionNotAllowedError.
+func notAllowedFuncImpl(name string) function.Function {
+	return function.New(&function.Spec{
+		VarParam: &function.Parameter{
+			Type:             cty.DynamicPseudoType,
+			AllowNull:        true,
+			AllowDynamicType: true,
+		},
+		Type: function.StaticReturnType(cty.DynamicPseudoType),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			return cty.NilVal, errors.New(FunctionNotAllowedError{Name: name})
+		},
+	})
+}
+
 // Return the OS platform
 func getPlatform(ctx *ParsingContext) (string, error) {
 	return runtime.GOOS, nil
@@ -490,6 +535,10 @@ func FindInParentFolders(
 		}
 
 		if util.FileExists(fileToFind) {
+			if err := checkIncludeInRepo(ctx, fileToFind); err != nil {
+				return "", err
+			}
+
 			return fileToFind, nil
 		}
 
@@ -653,6 +702,61 @@ func getAWSCallerIdentityUserID(ctx *ParsingContext) (string, error) {
 	return "", err
 }
 
+// A cache of the secrets read from AWS Secrets Manager, so that a secret referenced multiple times is only fetched once.
+//
+// The cache keys are the secret IDs, prefixed with the IAM role used to read them, and the values are the secret strings.
+var awsSecretCache = cache.NewCache[string](awsSecretCacheName)
+
+// getAWSSecretAsFuncImpl returns the `get_aws_secret` function, which reads a secret from AWS Secrets Manager. The
+// secrets storing a JSON object are returned as an object, the other secrets as a string.
+func getAWSSecretAsFuncImpl(ctx *ParsingContext) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{{Type: cty.String}},
+		// We don't know the return type until we read the secret, so we use a dynamic type
+		Type: function.StaticReturnType(cty.DynamicPseudoType),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			params, err := ctySliceToStringSlice(args)
+			if err != nil {
+				return cty.NilVal, err
+			}
+
+			return getAWSSecret(ctx, params[0])
+		},
+	})
+}
+
+// getAWSSecret reads the secret with the given ID, either its name or its ARN, from AWS Secrets Manager.
+func getAWSSecret(ctx *ParsingContext, secretID string) (cty.Value, error) {
+	cacheKey := ctx.TerragruntOptions.IAMRoleOptions.RoleARN + "|" + secretID
+
+	secret, ok := awsSecretCache.Get(ctx, cacheKey)
+	if !ok {
+		var err error
+
+		if secret, err = awshelper.GetSecretString(ctx, nil, ctx.TerragruntOptions, secretID); err != nil {
+			return cty.NilVal, err
+		}
+
+		awsSecretCache.Put(ctx, cacheKey, secret)
+	}
+
+	if !strings.HasPrefix(strings.TrimSpace(secret), "{") || !json.Valid([]byte(secret)) {
+		return cty.StringVal(secret), nil
+	}
+
+	secretType, err := ctyjson.ImpliedType([]byte(secret))
+	if err != nil {
+		return cty.NilVal, errors.New(err)
+	}
+
+	value, err := ctyjson.Unmarshal([]byte(secret), secretType)
+	if err != nil {
+		return cty.NilVal, errors.New(err)
+	}
+
+	return value, nil
+}
+
 // ParseTerragruntConfig parses the terragrunt config and return a
 // representation that can be used as a reference. If given a default value,
 // this will return the default if the terragrunt config file does not exist.
@@ -660,7 +764,7 @@ func ParseTerragruntConfig(ctx *ParsingContext, configPath string, defaultVal *c
 	// target config check: make sure the target config exists. If the file does not exist, and there is no default val,
 	// return an error. If the file does not exist but there is a default val, return the default val. Otherwise,
 	// proceed to parse the file as a terragrunt config file.
-	targetConfig := getCleanedTargetConfigPath(configPath, ctx.TerragruntOptions.TerragruntConfigPath)
+	targetConfig := getCleanedTargetConfigPath(configPath, ctx.TerragruntOptions.TerragruntConfigPath, ctx.TerragruntOptions)
 
 	targetConfigFileExists := util.FileExists(targetConfig)
 
@@ -744,8 +848,8 @@ func readTerragruntConfigAsFuncImpl(ctx *ParsingContext) function.Function {
 
 // Returns a cleaned path to the target config (the `terragrunt.hcl` or `terragrunt.hcl.json` file), handling relative
 // paths correctly. This will automatically append `terragrunt.hcl` or `terragrunt.hcl.json` to the path if the target
-// path is a directory.
-func getCleanedTargetConfigPath(configPath string, workingPath string) string {
+// path is a directory, or the config filename of the --terragrunt-config-filename-override glob matching the directory.
+func getCleanedTargetConfigPath(configPath string, workingPath string, opts *options.TerragruntOptions) string {
 	cwd := filepath.Dir(workingPath)
 
 	targetConfig := configPath
@@ -754,7 +858,7 @@ func getCleanedTargetConfigPath(configPath string, workingPath string) string {
 	}
 
 	if util.IsDir(targetConfig) {
-		targetConfig = GetDefaultConfigPath(targetConfig)
+		targetConfig = GetConfigPathForDir(targetConfig, opts)
 	}
 
 	return util.CleanPath(targetConfig)
@@ -1116,6 +1220,113 @@ func markAsRead(ctx *ParsingContext, args []string) (string, error) {
 	return file, nil
 }
 
+const (
+	// NamePrefixMaxLength is the maximum length of the prefix returned by name_prefix, the length of a DNS label.
+	NamePrefixMaxLength = 63
+	// namePrefixHashLength is the number of hex characters of the hash appended to truncated prefixes.
+	namePrefixHashLength = 8
+)
+
+var namePrefixInvalidCharsRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+// NamePrefix joins the given parts into a prefix that is safe to use in DNS and resource names: every part is
+// lowercased, runs of characters other than letters and digits are replaced with a single hyphen, leading and trailing
+// hyphens are trimmed, and the non-empty parts are joined with hyphens. If the result is longer than
+// NamePrefixMaxLength, it is truncated and suffixed with a short hash of the full prefix, so that prefixes sharing
+// the same beginning do not collide.
+func NamePrefix(ctx *ParsingContext, args []string) (string, error) {
+	parts := make([]string, 0, len(args))
+
+	for _, arg := range args {
+		part := namePrefixInvalidCharsRegex.ReplaceAllString(strings.ToLower(arg), "-")
+		if part = strings.Trim(part, "-"); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	if len(parts) == 0 {
+		return "", errors.New(EmptyStringNotAllowedError("parameter to the name_prefix function"))
+	}
+
+	prefix := strings.Join(parts, "-")
+	if len(prefix) <= NamePrefixMaxLength {
+		return prefix, nil
+	}
+
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(prefix)))[:namePrefixHashLength]
+	truncated := strings.TrimRight(prefix[:NamePrefixMaxLength-namePrefixHashLength-1], "-")
+
+	return truncated + "-" + hash, nil
+}
+
+const (
+	// StableSuffixMaxLength is the maximum length of the suffix returned by stable_suffix.
+	StableSuffixMaxLength = 64
+	// stableSuffixCharset are the characters of the suffixes returned by stable_suffix, safe to use in DNS and resource
+	// names.
+	stableSuffixCharset = "0123456789abcdefghijklmnopqrstuvwxyz"
+)
+
+// stableSuffixAsFuncImpl returns the stable_suffix function, taking the seed as a string and the length as a number.
+func stableSuffixAsFuncImpl() function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{Name: "seed", Type: cty.String},
+			{Name: "length", Type: cty.Number},
+		},
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			var length int
+			if err := gocty.FromCtyValue(args[1], &length); err != nil {
+				return cty.StringVal(""), errors.New(InvalidStableSuffixLengthError(args[1].AsBigFloat().String()))
+			}
+
+			suffix, err := StableSuffix(args[0].AsString(), length)
+			if err != nil {
+				return cty.StringVal(""), err
+			}
+
+			return cty.StringVal(suffix), nil
+		},
+	})
+}
+
+// StableSuffix returns a suffix of the given length, made of lowercase letters and digits, that is derived from the
+// given seed only: the same seed and length always give the same suffix, on any machine, without storing any state. The
+// characters are drawn from the chain of the SHA-256 hashes of the seed, discarding the bytes that would make some
+// characters more likely than others.
+func StableSuffix(seed string, length int) (string, error) {
+	if seed == "" {
+		return "", errors.New(EmptyStringNotAllowedError("the seed parameter to the stable_suffix function"))
+	}
+
+	if length < 1 || length > StableSuffixMaxLength {
+		return "", errors.New(InvalidStableSuffixLengthError(strconv.Itoa(length)))
+	}
+
+	// the largest multiple of the charset size fitting in a byte, the bytes above are discarded to avoid a modulo bias
+	maxByte := byte(256 / len(stableSuffixCharset) * len(stableSuffixCharset)) //nolint:mnd
+
+	suffix := make([]byte, 0, length)
+	block := sha256.Sum256([]byte(seed))
+
+	for len(suffix) < length {
+		for _, b := range block {
+			if b >= maxByte {
+				continue
+			}
+
+			if suffix = append(suffix, stableSuffixCharset[int(b)%len(stableSuffixCharset)]); len(suffix) == length {
+				break
+			}
+		}
+
+		block = sha256.Sum256(block[:])
+	}
+
+	return string(suffix), nil
+}
+
 // warnWhenFileNotMarkedAsRead warns when a file is not being marked as read, even though a user might expect it to be.
 // Situations where this is the case include:
 // - A user specifies a file in the UnitsReading flag and that file is being read while parsing the inputs attribute.
@@ -5,8 +5,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/gruntwork-io/terragrunt/awshelper"
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/options"
@@ -849,6 +857,101 @@ func TestTerraformBuiltInFunctions(t *testing.T) {
 	}
 }
 
+type mockSecretsManagerClient struct {
+	secretsmanageriface.SecretsManagerAPI
+
+	secrets map[string]string
+	calls   map[string]int
+	mu      sync.Mutex
+}
+
+func (client *mockSecretsManagerClient) GetSecretValueWithContext(_ aws.Context, input *secretsmanager.GetSecretValueInput, _ ...request.Option) (*secretsmanager.GetSecretValueOutput, error) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	client.calls[*input.SecretId]++
+
+	secret, ok := client.secrets[*input.SecretId]
+	if !ok {
+		return nil, awserr.New(secretsmanager.ErrCodeResourceNotFoundException, "Secrets Manager can't find the specified secret.", nil)
+	}
+
+	return &secretsmanager.GetSecretValueOutput{SecretString: aws.String(secret)}, nil
+}
+
+func TestGetAWSSecret(t *testing.T) {
+	t.Parallel()
+
+	client := &mockSecretsManagerClient{
+		secrets: map[string]string{
+			"get-aws-secret-test/string": "hunter2",
+			"get-aws-secret-test/json":   `{"username": "admin", "port": 5432}`,
+			"get-aws-secret-test/cached": "cached",
+		},
+		calls: map[string]int{},
+	}
+
+	tc := []struct {
+		input       string
+		expected    interface{}
+		expectedErr string
+	}{
+		{
+			`get_aws_secret("get-aws-secret-test/string")`,
+			"hunter2",
+			"",
+		},
+		{
+			`get_aws_secret("get-aws-secret-test/json")`,
+			map[string]interface{}{"username": "admin", "port": 5432.},
+			"",
+		},
+		{
+			`get_aws_secret("get-aws-secret-test/json").username`,
+			"admin",
+			"",
+		},
+		{
+			`[get_aws_secret("get-aws-secret-test/cached"), get_aws_secret("get-aws-secret-test/cached")]`,
+			[]interface{}{"cached", "cached"},
+			"",
+		},
+		{
+			`get_aws_secret("get-aws-secret-test/missing")`,
+			nil,
+			"error reading the secret get-aws-secret-test/missing from AWS Secrets Manager: ResourceNotFoundException",
+		},
+	}
+
+	for _, tt := range tc {
+		tt := tt
+
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			terragruntOptions := terragruntOptionsForTest(t, config.DefaultTerragruntConfigPath)
+			configString := fmt.Sprintf("inputs = { test = %s }", tt.input)
+			ctx := config.NewParsingContext(awshelper.ContextWithSecretsManagerClient(context.Background(), client), terragruntOptions)
+
+			actual, err := config.ParseConfigString(ctx, terragruntOptions.TerragruntConfigPath, configString, nil)
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.EqualValues(t, tt.expected, actual.Inputs["test"])
+		})
+	}
+
+	t.Cleanup(func() {
+		// the secrets are cached, so each secret is only read once
+		assert.Equal(t, 1, client.calls["get-aws-secret-test/cached"])
+	})
+}
+
 func TestTerraformOutputJsonToCtyValueMap(t *testing.T) {
 	t.Parallel()
 
@@ -1219,6 +1322,172 @@ func TestStrContains(t *testing.T) {
 	}
 }
 
+func TestNamePrefix(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		args  []string
+		value string
+		err   string
+	}{
+		{[]string{"acme", "prod", "vpc"}, "acme-prod-vpc", ""},
+		{[]string{"ACME", "Prod"}, "acme-prod", ""},
+		{[]string{"acme_corp", "us east/1", "vpc.main"}, "acme-corp-us-east-1-vpc-main", ""},
+		{[]string{"--acme--", "", "  ", "__vpc"}, "acme-vpc", ""},
+		{[]string{"live/prod/us-east-1/vpc"}, "live-prod-us-east-1-vpc", ""},
+		{[]string{"acme", strings.Repeat("a", 58)}, "acme-" + strings.Repeat("a", 58), ""},
+		{[]string{}, "", "Empty string value is not allowed for parameter to the name_prefix function"},
+		{[]string{"--", "_"}, "", "Empty string value is not allowed for parameter to the name_prefix function"},
+	}
+
+	for _, tt := range tc {
+		tt := tt
+
+		t.Run(fmt.Sprintf("NamePrefix %v", tt.args), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := config.NewParsingContext(context.Background(), terragruntOptionsForTest(t, ""))
+			actual, err := config.NamePrefix(ctx, tt.args)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.value, actual)
+		})
+	}
+}
+
+func TestNamePrefixTruncation(t *testing.T) {
+	t.Parallel()
+
+	ctx := config.NewParsingContext(context.Background(), terragruntOptionsForTest(t, ""))
+
+	long := strings.Repeat("a", 60)
+
+	first, err := config.NamePrefix(ctx, []string{"acme", long, "vpc"})
+	require.NoError(t, err)
+
+	second, err := config.NamePrefix(ctx, []string{"acme", long, "rds"})
+	require.NoError(t, err)
+
+	again, err := config.NamePrefix(ctx, []string{"acme", long, "vpc"})
+	require.NoError(t, err)
+
+	assert.Len(t, first, config.NamePrefixMaxLength)
+	assert.Len(t, second, config.NamePrefixMaxLength)
+	assert.True(t, strings.HasPrefix(first, "acme-aaaa"))
+	assert.Regexp(t, `-[0-9a-f]{8}$`, first)
+
+	// Prefixes that only differ after the truncation point must not collide, while the same parts give the same prefix.
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, first, again)
+
+	// A hyphen at the truncation point is trimmed, so the hash is never preceded by two hyphens.
+	hyphenated, err := config.NamePrefix(ctx, []string{strings.Repeat("a", 53), strings.Repeat("b", 20)})
+	require.NoError(t, err)
+	assert.NotContains(t, hyphenated, "--")
+	assert.LessOrEqual(t, len(hyphenated), config.NamePrefixMaxLength)
+}
+
+func TestStableSuffix(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		seed   string
+		length int
+		value  string
+		err    string
+	}{
+		// the suffixes are pinned, as they must be the same on any machine and across Terragrunt versions
+		{"live/prod/vpc-acme", 8, "83xama8k", ""},
+		{"live/prod/vpc-acme", 64, "83xama8k3l20va9v8a9lutx8v4gjnc5ct481gke3nyzigv0j4404nh3wu1q8fki3", ""},
+		{"live/prod/vpc-acme", 0, "", "Invalid length 0 for the stable_suffix function, expected a whole number between 1 and 64"},
+		{"live/prod/vpc-acme", 65, "", "Invalid length 65 for the stable_suffix function, expected a whole number between 1 and 64"},
+		{"", 8, "", "Empty string value is not allowed for the seed parameter to the stable_suffix function"},
+	}
+
+	for _, tt := range tc {
+		tt := tt
+
+		t.Run(fmt.Sprintf("StableSuffix %q %d", tt.seed, tt.length), func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := config.StableSuffix(tt.seed, tt.length)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.value, actual)
+		})
+	}
+}
+
+func TestStableSuffixDeterminism(t *testing.T) {
+	t.Parallel()
+
+	seen := map[string]string{}
+
+	for i := 0; i < 100; i++ {
+		seed := fmt.Sprintf("live/unit-%d-salt", i)
+
+		for length := 1; length <= config.StableSuffixMaxLength; length++ {
+			suffix, err := config.StableSuffix(seed, length)
+			require.NoError(t, err)
+			assert.Regexp(t, fmt.Sprintf("^[0-9a-z]{%d}$", length), suffix)
+
+			again, err := config.StableSuffix(seed, length)
+			require.NoError(t, err)
+			assert.Equal(t, suffix, again)
+		}
+
+		suffix, err := config.StableSuffix(seed, 8)
+		require.NoError(t, err)
+
+		// different seeds give different suffixes
+		other, ok := seen[suffix]
+		assert.False(t, ok, "seeds %q and %q have the same suffix %s", seed, other, suffix)
+		seen[suffix] = seed
+	}
+}
+
+func TestStableSuffixFunction(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		str   string
+		value string
+		err   string
+	}{
+		{`inputs = { suffix = stable_suffix("live/prod/vpc-acme", 8) }`, "83xama8k", ""},
+		{`inputs = { suffix = stable_suffix("live/prod/vpc-acme", 2.5) }`, "", "Invalid length 2.5 for the stable_suffix function"},
+	}
+
+	for _, tt := range tc {
+		tt := tt
+
+		t.Run(tt.str, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := config.NewParsingContext(context.Background(), terragruntOptionsForTest(t, config.DefaultTerragruntConfigPath))
+
+			cfg, err := config.ParseConfigString(ctx, config.DefaultTerragruntConfigPath, tt.str, nil)
+			if tt.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, cfg.Inputs["suffix"])
+		})
+	}
+}
+
 func TestReadTFVarsFiles(t *testing.T) {
 	t.Parallel()
 
@@ -1276,3 +1545,61 @@ func getTrackIncludeFromTestData(includeMap map[string]config.IncludeConfig, par
 	}
 	return trackInclude
 }
+
+func TestFunctionsPolicy(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		name        string
+		str         string
+		allowed     []string
+		denied      []string
+		expectedErr string
+	}{
+		{
+			name:        "denied run_cmd",
+			str:         "locals {\n  foo = run_cmd(\"echo\", \"foo\")\n}",
+			denied:      []string{"run_cmd"},
+			expectedErr: "mock-path-for-test.hcl:2,9-17: Error in function call; Call to function \"run_cmd\" failed: the function run_cmd is not allowed",
+		},
+		{
+			name:        "not in allowlist",
+			str:         "locals {\n  foo = run_cmd(\"echo\", \"foo\")\n}",
+			allowed:     []string{"get_env"},
+			expectedErr: "the function run_cmd is not allowed",
+		},
+		{
+			name:   "denied function not used",
+			str:    `inputs = { foo = get_env("TEST_FUNCTIONS_POLICY", "bar") }`,
+			denied: []string{"run_cmd"},
+		},
+		{
+			name:    "allowed function",
+			str:     `inputs = { foo = upper(get_env("TEST_FUNCTIONS_POLICY", "bar")) }`,
+			allowed: []string{"get_env", "upper"},
+		},
+	}
+
+	for _, tt := range tc {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := terragruntOptionsForTest(t, config.DefaultTerragruntConfigPath)
+			opts.AllowedFunctions = tt.allowed
+			opts.DeniedFunctions = tt.denied
+
+			ctx := config.NewParsingContext(context.Background(), opts)
+			_, err := config.ParseConfigString(ctx, "mock-path-for-test.hcl", tt.str, nil)
+
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
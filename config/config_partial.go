@@ -1,6 +1,7 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/gruntwork-io/terragrunt/config/hclparse"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/util"
 )
 
@@ -34,6 +36,7 @@ const (
 	FeatureFlagsBlock
 	ExcludeBlock
 	ErrorsBlock
+	GenerateBlock
 )
 
 // terragruntIncludeMultiple is a struct that can be used to only decode the include block with labels.
@@ -109,6 +112,13 @@ type terragruntRemoteState struct {
 	Remain      hcl.Body               `hcl:",remain"`
 }
 
+// terragruntGenerate is a struct that can be used to only decode the generate blocks in the terragrunt config
+type terragruntGenerate struct {
+	GenerateAttrs  *cty.Value                `hcl:"generate,optional"`
+	GenerateBlocks []terragruntGenerateBlock `hcl:"generate,block"`
+	Remain         hcl.Body                  `hcl:",remain"`
+}
+
 // terragruntInputs is a struct that can be used to only decode the inputs block.
 type terragruntInputs struct {
 	Inputs *cty.Value `hcl:"inputs,attr"`
@@ -258,6 +268,28 @@ func cliFlagsToCty(ctx *ParsingContext, flagByName map[string]*FeatureFlag) (map
 	return evaluatedFlags, nil
 }
 
+// PartialParse parses the Terragrunt config file at the given path, decoding only the given blocks, which is much
+// faster than a full parse for tooling that only needs some of the config, e.g. just the `dependency` and `terraform`
+// blocks. See PartialParseConfigString for the blocks that can be decoded. Note that the `locals` and `include` blocks
+// are always decoded, but nothing else is evaluated: the functions used in the blocks that are not requested are not
+// called, the outputs of the dependencies are not fetched unless TerragruntInputs is requested, and no files are
+// generated.
+func PartialParse(ctx context.Context, configPath string, blocks []PartialDecodeSectionType, opts *options.TerragruntOptions) (*TerragruntConfig, error) {
+	configPath, err := filepath.Abs(configPath)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	opts, err = opts.Clone(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	parsingCtx := NewParsingContext(ctx, opts).WithDecodeList(blocks...)
+
+	return PartialParseConfigFile(parsingCtx, configPath, nil)
+}
+
 func PartialParseConfigFile(ctx *ParsingContext, configPath string, include *IncludeConfig) (*TerragruntConfig, error) {
 	hclCache := cache.ContextCache[*hclparse.File](ctx, HclCacheContextKey)
 
@@ -327,6 +359,7 @@ func TerragruntConfigFromPartialConfig(ctx *ParsingContext, file *hclparse.File,
 //   - RemoteStateBlock: Parses the `remote_state` block in the config
 //   - FeatureFlagsBlock: Parses the `feature` block in the config
 //   - ExcludeBlock : Parses the `exclude` block in the config
+//   - GenerateBlock: Parses the `generate` blocks in the config
 //
 // Note that the following blocks are always decoded:
 // - locals
@@ -579,6 +612,25 @@ func PartialParseConfig(ctx *ParsingContext, file *hclparse.File, includeFromChi
 				output.Errors = decoded.Errors
 			}
 
+		case GenerateBlock:
+			decoded := terragruntGenerate{}
+
+			if err := file.Decode(&decoded, evalParsingContext); err != nil {
+				return nil, err
+			}
+
+			generateConfig, err := convertToTerragruntConfig(ctx, file.ConfigPath, &terragruntConfigFile{
+				GenerateAttrs:  decoded.GenerateAttrs,
+				GenerateBlocks: decoded.GenerateBlocks,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			for name, genConfig := range generateConfig.GenerateConfigs {
+				output.GenerateConfigs[name] = genConfig
+			}
+
 		default:
 			return nil, InvalidPartialBlockName{decode}
 		}
@@ -635,6 +687,10 @@ func partialParseIncludedConfig(ctx *ParsingContext, includedConfig *IncludeConf
 		includePath = util.JoinPath(filepath.Dir(ctx.TerragruntOptions.TerragruntConfigPath), includePath)
 	}
 
+	if err := checkIncludeInRepo(ctx, includePath); err != nil {
+		return nil, err
+	}
+
 	return PartialParseConfigFile(
 		ctx,
 		includePath,
@@ -2,9 +2,13 @@ package config_test
 
 import (
 	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
 
 	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/zclconf/go-cty/cty"
@@ -358,3 +362,210 @@ dependency "ec2" {
 	require.NoError(t, err)
 	assert.Len(t, terragruntConfig.Dependencies.Paths, 1)
 }
+
+func TestPartialParseOnlyDecodesRequestedBlocks(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	marker := filepath.Join(tmpDir, "marker")
+	configPath := filepath.Join(tmpDir, config.DefaultTerragruntConfigPath)
+
+	cfg := `
+locals {
+  region = "us-east-1"
+}
+
+terraform {
+  source = "../modules/app"
+}
+
+dependency "vpc" {
+  config_path = "../vpc"
+}
+
+prevent_destroy = true
+
+remote_state {
+  backend = "local"
+  config  = {}
+}
+
+inputs = {
+  region = local.region
+  vpc_id = dependency.vpc.outputs.vpc_id
+  marker = run_cmd("touch", "` + filepath.ToSlash(marker) + `")
+}
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(cfg), 0644))
+
+	terragruntConfig, err := config.PartialParse(context.Background(), configPath, []config.PartialDecodeSectionType{config.DependencyBlock, config.TerraformBlock}, mockOptionsForTest(t))
+	require.NoError(t, err)
+	assert.True(t, terragruntConfig.IsPartial)
+
+	require.NotNil(t, terragruntConfig.Terraform)
+	assert.Equal(t, "../modules/app", *terragruntConfig.Terraform.Source)
+	require.Len(t, terragruntConfig.TerragruntDependencies, 1)
+	assert.Equal(t, "vpc", terragruntConfig.TerragruntDependencies[0].Name)
+	assert.Equal(t, []string{"../vpc"}, terragruntConfig.Dependencies.Paths)
+	assert.Equal(t, map[string]interface{}{"region": "us-east-1"}, terragruntConfig.Locals)
+
+	assert.Nil(t, terragruntConfig.PreventDestroy)
+	assert.Nil(t, terragruntConfig.RemoteState)
+	assert.Nil(t, terragruntConfig.Inputs)
+
+	// the inputs are not evaluated, so neither the outputs of the dependency are fetched nor the command is run
+	assert.True(t, util.FileNotExists(marker))
+}
+
+func TestPartialParseMissingFile(t *testing.T) {
+	t.Parallel()
+
+	_, err := config.PartialParse(context.Background(), filepath.Join(t.TempDir(), config.DefaultTerragruntConfigPath), []config.PartialDecodeSectionType{config.TerraformBlock}, mockOptionsForTest(t))
+	assert.Error(t, err)
+}
+
+func TestPartialParseGenerateBlockStoresStateRemotely(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		cfg      string
+		expected bool
+	}{
+		{
+			name:     "no backend",
+			cfg:      `prevent_destroy = true`,
+			expected: false,
+		},
+		{
+			name: "remote_state",
+			cfg: `
+remote_state {
+  backend = "local"
+  config  = {}
+}`,
+			expected: true,
+		},
+		{
+			name: "generated backend",
+			cfg: `
+generate "backend" {
+  path      = "backend.tf"
+  if_exists = "overwrite"
+  contents  = <<EOF
+terraform {
+  backend "s3" {}
+}
+EOF
+}`,
+			expected: true,
+		},
+		{
+			name: "generated cloud block",
+			cfg: `
+generate "backend" {
+  path      = "backend.tf"
+  if_exists = "overwrite"
+  contents  = <<EOF
+terraform {
+  cloud {
+    organization = "example"
+  }
+}
+EOF
+}`,
+			expected: true,
+		},
+		{
+			name: "generated JSON backend",
+			cfg: `
+generate "backend" {
+  path      = "backend.tf.json"
+  if_exists = "overwrite"
+  contents  = jsonencode({ terraform = { backend = { local = {} } } })
+}`,
+			expected: true,
+		},
+		{
+			name: "generated provider",
+			cfg: `
+generate "provider" {
+  path      = "provider.tf"
+  if_exists = "overwrite"
+  contents  = "provider \"aws\" {}"
+}`,
+			expected: false,
+		},
+		{
+			name: "disabled generated backend",
+			cfg: `
+generate "backend" {
+  path      = "backend.tf"
+  if_exists = "overwrite"
+  disable   = true
+  contents  = "terraform {\n  backend \"s3\" {}\n}"
+}`,
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := config.NewParsingContext(context.Background(), mockOptionsForTest(t)).WithDecodeList(config.RemoteStateBlock, config.GenerateBlock)
+			terragruntConfig, err := config.PartialParseConfigString(ctx, config.DefaultTerragruntConfigPath, tc.cfg, nil)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, terragruntConfig.StoresStateRemotely())
+		})
+	}
+}
+
+func TestPartialParseRestrictIncludesToRepo(t *testing.T) {
+	t.Parallel()
+
+	rootPath, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	repoPath := filepath.Join(rootPath, "repo")
+
+	output, err := exec.Command("git", "init", repoPath).CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	outsidePath := filepath.Join(rootPath, "outside.hcl")
+	require.NoError(t, os.WriteFile(outsidePath, []byte(`
+dependencies {
+  paths = ["../vpc"]
+}
+`), 0644))
+
+	configPath := filepath.Join(repoPath, "app", config.DefaultTerragruntConfigPath)
+	require.NoError(t, os.MkdirAll(filepath.Dir(configPath), os.ModePerm))
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+include "root" {
+  path = "../../outside.hcl"
+}
+`), 0644))
+
+	for _, restrict := range []bool{false, true} {
+		opts := mockOptionsForTestWithConfigPath(t, configPath)
+		opts.RestrictIncludesToRepo = restrict
+
+		ctx := config.NewParsingContext(context.Background(), opts).WithDecodeList(config.DependenciesBlock)
+
+		terragruntConfig, err := config.PartialParseConfigFile(ctx, configPath, nil)
+		if !restrict {
+			require.NoError(t, err)
+			assert.Equal(t, []string{"../vpc"}, terragruntConfig.Dependencies.Paths)
+
+			continue
+		}
+
+		var outsideRepoErr config.IncludeOutsideRepoError
+
+		require.ErrorAs(t, err, &outsideRepoErr)
+		assert.Equal(t, outsidePath, outsideRepoErr.IncludePath)
+		assert.Equal(t, repoPath, outsideRepoErr.RepoRoot)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/gruntwork-io/terragrunt/cli/commands"
+	"github.com/gruntwork-io/terragrunt/codegen"
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/options"
@@ -49,6 +50,50 @@ remote_state {
 	}
 }
 
+func TestParseTerragruntConfigRemoteStatePartialGenerate(t *testing.T) {
+	t.Parallel()
+
+	cfg := `
+remote_state {
+  backend = "s3"
+  generate = {
+    path      = "backend.tf"
+    if_exists = "overwrite_terragrunt"
+    partial   = true
+  }
+  config = {}
+}
+`
+
+	ctx := config.NewParsingContext(context.Background(), mockOptionsForTest(t))
+	terragruntConfig, err := config.ParseConfigString(ctx, config.DefaultTerragruntConfigPath, cfg, nil)
+	require.NoError(t, err)
+
+	require.NotNil(t, terragruntConfig.RemoteState)
+	require.NotNil(t, terragruntConfig.RemoteState.Generate)
+	assert.True(t, terragruntConfig.RemoteState.Generate.Partial)
+	assert.True(t, terragruntConfig.RemoteState.IsPartial())
+}
+
+func TestParseTerragruntConfigRemoteStateGenerateUnknownKey(t *testing.T) {
+	t.Parallel()
+
+	cfg := `
+remote_state {
+  backend = "s3"
+  generate = {
+    path     = "backend.tf"
+    if_exist = "overwrite_terragrunt"
+  }
+  config = {}
+}
+`
+
+	ctx := config.NewParsingContext(context.Background(), mockOptionsForTest(t))
+	_, err := config.ParseConfigString(ctx, config.DefaultTerragruntConfigPath, cfg, nil)
+	require.ErrorContains(t, err, "if_exist")
+}
+
 func TestParseTerragruntConfigRemoteStateAttrMinimalConfig(t *testing.T) {
 	t.Parallel()
 
@@ -523,6 +568,51 @@ include {
 
 }
 
+func TestParseTerragruntConfigIncludeRetryableErrors(t *testing.T) {
+	t.Parallel()
+
+	rootErrors := []string{"(?s).*Error installing provider.*", "(?s).*connection reset by peer.*"}
+	unitErrors := []string{"(?s).*RequestLimitExceeded.*"}
+
+	testCases := []struct {
+		unit     string
+		expected []string
+	}{
+		{"inherit", rootErrors},
+		{"append", append(append([]string{}, rootErrors...), unitErrors...)},
+		{"replace", unitErrors},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.unit, func(t *testing.T) {
+			t.Parallel()
+
+			opts := mockOptionsForTestWithConfigPath(t, filepath.Join("../test/fixtures/retryable-errors-include", tc.unit, config.DefaultTerragruntConfigPath))
+
+			terragruntConfig, err := config.ReadTerragruntConfig(context.Background(), opts, config.DefaultParserOptions(opts))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, terragruntConfig.RetryableErrors)
+		})
+	}
+}
+
+func TestParseTerragruntConfigInvalidRetryableErrorsMerge(t *testing.T) {
+	t.Parallel()
+
+	cfg := `
+retryable_errors       = ["my error"]
+retryable_errors_merge = "prepend"
+`
+
+	ctx := config.NewParsingContext(context.Background(), mockOptionsForTest(t))
+	_, err := config.ParseConfigString(ctx, config.DefaultTerragruntConfigPath, cfg, nil)
+	require.Error(t, err)
+
+	var invalidMergeErr config.InvalidRetryableErrorsMergeError
+	require.ErrorAs(t, err, &invalidMergeErr)
+	assert.Equal(t, config.InvalidRetryableErrorsMergeError("prepend"), invalidMergeErr)
+}
+
 func TestParseTerragruntConfigIncludeOverrideRemote(t *testing.T) {
 	t.Parallel()
 
@@ -892,6 +982,69 @@ terraform {
 	}
 }
 
+func TestParseTerragruntConfigGenerateBlockConflictingOptions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		options       string
+		expectedError string
+	}{
+		{
+			name:    "overwrite when disabled",
+			options: `if_exists = "overwrite"` + "\n" + `disable = true`,
+		},
+		{
+			name:    "overwrite_terragrunt with signature",
+			options: `if_exists = "overwrite_terragrunt"` + "\n" + `if_disabled = "remove_terragrunt"`,
+		},
+		{
+			name:    "overwrite without signature",
+			options: `if_exists = "overwrite"` + "\n" + `disable_signature = true`,
+		},
+		{
+			name:          "overwrite_terragrunt without signature",
+			options:       `if_exists = "overwrite_terragrunt"` + "\n" + `disable_signature = true`,
+			expectedError: `Invalid generate block "provider": if_exists = "overwrite_terragrunt" can not be used with disable_signature = true`,
+		},
+		{
+			name:          "remove_terragrunt without signature",
+			options:       `if_exists = "skip"` + "\n" + `if_disabled = "remove_terragrunt"` + "\n" + `disable_signature = true`,
+			expectedError: `Invalid generate block "provider": if_disabled = "remove_terragrunt" can not be used with disable_signature = true`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := fmt.Sprintf(`
+generate "provider" {
+  path     = "provider.tf"
+  contents = "# provider"
+  %s
+}
+`, tc.options)
+
+			ctx := config.NewParsingContext(context.Background(), mockOptionsForTest(t))
+			terragruntConfig, err := config.ParseConfigString(ctx, config.DefaultTerragruntConfigPath, cfg, nil)
+
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+				assert.Contains(t, terragruntConfig.GenerateConfigs, "provider")
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedError)
+
+			var conflictErr codegen.ConflictingGenerateOptionsError
+			assert.ErrorAs(t, err, &conflictErr)
+		})
+	}
+}
+
 func TestParseTerragruntJsonConfigTerraformWithMultipleExtraArguments(t *testing.T) {
 	t.Parallel()
 
@@ -949,6 +1102,61 @@ func TestParseTerragruntJsonConfigTerraformWithMultipleExtraArguments(t *testing
 	}
 }
 
+func TestFindConfigInParentDirs(t *testing.T) {
+	t.Parallel()
+
+	// repo/
+	//   .git/
+	//   terragrunt.hcl
+	//   live/
+	//     custom.hcl
+	//     app/
+	//       terragrunt.hcl.json
+	//       nested/
+	//   no-config/
+	//     nested/
+	tmpDir := t.TempDir()
+	repoDir := filepath.Join(tmpDir, "repo")
+
+	for _, dir := range []string{".git", "live/app/nested", "no-config/nested"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(repoDir, dir), os.ModePerm))
+	}
+
+	for _, file := range []string{"terragrunt.hcl", "live/custom.hcl", "live/app/terragrunt.hcl.json"} {
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, file), []byte(""), 0644))
+	}
+
+	// a config above the root of the repository is never used
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "custom.hcl"), []byte(""), 0644))
+
+	testCases := []struct {
+		dir        string
+		configName string
+		expected   string
+	}{
+		{"live/app", "", "live/app/terragrunt.hcl.json"},
+		{"live/app/nested", "", "live/app/terragrunt.hcl.json"},
+		{"no-config/nested", "", "terragrunt.hcl"},
+		{"live/app/nested", "custom.hcl", "live/custom.hcl"},
+		{"no-config/nested", "custom.hcl", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.dir+"-"+tc.configName, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := config.FindConfigInParentDirs(filepath.Join(repoDir, tc.dir), tc.configName)
+			require.NoError(t, err)
+
+			if tc.expected == "" {
+				assert.Empty(t, actual)
+			} else {
+				assert.Equal(t, filepath.Join(repoDir, tc.expected), filepath.FromSlash(actual))
+			}
+		})
+	}
+}
+
 func TestFindConfigFilesInPathNone(t *testing.T) {
 	t.Parallel()
 
@@ -1005,6 +1213,30 @@ func TestFindConfigFilesInPathMultipleConfigs(t *testing.T) {
 	assert.ElementsMatch(t, expected, actual)
 }
 
+func TestFindConfigFilesInPathSymlinkLoops(t *testing.T) {
+	t.Parallel()
+
+	rootPath, err := filepath.EvalSymlinks("../test/fixtures/config-files/symlink-loop")
+	require.NoError(t, err)
+
+	rootPath, err = filepath.Abs(rootPath)
+	require.NoError(t, err)
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("test")
+	require.NoError(t, err)
+
+	terragruntOptions.ExperimentMode = true
+
+	actual, err := config.FindConfigFilesInPath(rootPath, terragruntOptions)
+	require.NoError(t, err)
+
+	expected := []string{
+		filepath.Join(rootPath, "app", "terragrunt.hcl"),
+		filepath.Join(rootPath, "vpc", "terragrunt.hcl"),
+	}
+	assert.ElementsMatch(t, expected, actual)
+}
+
 func TestFindConfigFilesInPathMultipleJsonConfigs(t *testing.T) {
 	t.Parallel()
 
@@ -1022,6 +1254,178 @@ func TestFindConfigFilesInPathMultipleJsonConfigs(t *testing.T) {
 	assert.ElementsMatch(t, expected, actual)
 }
 
+func TestFindConfigFilesInPathMaxFolderDepth(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		maxFolderDepth int
+		expected       []string
+	}{
+		{
+			name:           "unlimited",
+			maxFolderDepth: 0,
+			expected: []string{
+				"../test/fixtures/config-files/nested-configs/level-1/terragrunt.hcl",
+				"../test/fixtures/config-files/nested-configs/level-1/level-2/terragrunt.hcl",
+				"../test/fixtures/config-files/nested-configs/level-1/level-2/level-3/terragrunt.hcl",
+				"../test/fixtures/config-files/nested-configs/level-1/level-2/level-3/level-4/terragrunt.hcl",
+				"../test/fixtures/config-files/nested-configs/level-1/level-2/level-3/level-4/level-5/terragrunt.hcl",
+			},
+		},
+		{
+			name:           "limited",
+			maxFolderDepth: 2,
+			expected: []string{
+				"../test/fixtures/config-files/nested-configs/level-1/terragrunt.hcl",
+				"../test/fixtures/config-files/nested-configs/level-1/level-2/terragrunt.hcl",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			terragruntOptions, err := options.NewTerragruntOptionsForTest("test")
+			require.NoError(t, err)
+
+			terragruntOptions.MaxFolderDepth = tc.maxFolderDepth
+
+			actual, err := config.FindConfigFilesInPath("../test/fixtures/config-files/nested-configs", terragruntOptions)
+
+			require.NoError(t, err, "Unexpected error: %v", err)
+			assert.ElementsMatch(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestFindConfigFilesInPathIgnoreFile(t *testing.T) {
+	t.Parallel()
+
+	const fixturePath = "../test/fixtures/config-files/ignore-file"
+
+	testCases := []struct {
+		name       string
+		ignoreFile string
+		expected   []string
+	}{
+		{
+			name:       "default",
+			ignoreFile: ".terragruntignore",
+			expected: []string{
+				fixturePath + "/app/terragrunt.hcl",
+				fixturePath + "/live/prod/terragrunt.hcl",
+				fixturePath + "/live/scratch/terragrunt.hcl",
+				fixturePath + "/stacks/keep/terragrunt.hcl",
+			},
+		},
+		{
+			name:       "custom",
+			ignoreFile: ".customignore",
+			expected: []string{
+				fixturePath + "/archive/old/terragrunt.hcl",
+				fixturePath + "/live/legacy/terragrunt.hcl",
+				fixturePath + "/live/prod/terragrunt.hcl",
+				fixturePath + "/live/scratch/terragrunt.hcl",
+				fixturePath + "/scratch/terragrunt.hcl",
+				fixturePath + "/stacks/keep/terragrunt.hcl",
+				fixturePath + "/stacks/old/terragrunt.hcl",
+			},
+		},
+		{
+			name:       "disabled",
+			ignoreFile: "",
+			expected: []string{
+				fixturePath + "/app/terragrunt.hcl",
+				fixturePath + "/app/scratch/terragrunt.hcl",
+				fixturePath + "/archive/old/terragrunt.hcl",
+				fixturePath + "/live/legacy/terragrunt.hcl",
+				fixturePath + "/live/prod/terragrunt.hcl",
+				fixturePath + "/live/scratch/terragrunt.hcl",
+				fixturePath + "/scratch/terragrunt.hcl",
+				fixturePath + "/stacks/keep/terragrunt.hcl",
+				fixturePath + "/stacks/old/terragrunt.hcl",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			terragruntOptions, err := options.NewTerragruntOptionsForTest("test")
+			require.NoError(t, err)
+
+			terragruntOptions.IgnoreFile = tc.ignoreFile
+
+			actual, err := config.FindConfigFilesInPath(fixturePath, terragruntOptions)
+
+			require.NoError(t, err, "Unexpected error: %v", err)
+			assert.ElementsMatch(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestFindConfigFilesInPathConfigFilenameOverrides(t *testing.T) {
+	t.Parallel()
+
+	const fixturePath = "../test/fixtures/config-files/filename-overrides"
+
+	testCases := []struct {
+		name      string
+		overrides map[string]string
+		expected  []string
+	}{
+		{
+			name: "none",
+			expected: []string{
+				fixturePath + "/legacy/app/terragrunt.hcl",
+				fixturePath + "/legacy/db/terragrunt.hcl",
+				fixturePath + "/new/db/terragrunt.hcl",
+				fixturePath + "/new/pinned/terragrunt.hcl",
+			},
+		},
+		{
+			name:      "subtree",
+			overrides: map[string]string{"new/**": "unit.hcl"},
+			expected: []string{
+				fixturePath + "/legacy/app/terragrunt.hcl",
+				fixturePath + "/legacy/db/terragrunt.hcl",
+				fixturePath + "/new/app/unit.hcl",
+				fixturePath + "/new/db/unit.hcl",
+			},
+		},
+		{
+			name:      "longest-glob",
+			overrides: map[string]string{"new/**": "unit.hcl", "new/pinned": "terragrunt.hcl"},
+			expected: []string{
+				fixturePath + "/legacy/app/terragrunt.hcl",
+				fixturePath + "/legacy/db/terragrunt.hcl",
+				fixturePath + "/new/app/unit.hcl",
+				fixturePath + "/new/db/unit.hcl",
+				fixturePath + "/new/pinned/terragrunt.hcl",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			terragruntOptions, err := options.NewTerragruntOptionsForTest("test")
+			require.NoError(t, err)
+
+			terragruntOptions.ConfigFilenameOverrides = tc.overrides
+
+			actual, err := config.FindConfigFilesInPath(fixturePath, terragruntOptions)
+
+			require.NoError(t, err, "Unexpected error: %v", err)
+			assert.ElementsMatch(t, tc.expected, actual)
+		})
+	}
+}
+
 func TestFindConfigFilesInPathMultipleMixedConfigs(t *testing.T) {
 	t.Parallel()
 
@@ -3,6 +3,7 @@ package config
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,6 +11,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 
@@ -19,7 +21,9 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/hcl/v2"
 	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
 	"github.com/zclconf/go-cty/cty/gocty"
 	ctyjson "github.com/zclconf/go-cty/cty/json"
 	"golang.org/x/sync/errgroup"
@@ -37,7 +41,14 @@ import (
 	"github.com/gruntwork-io/terragrunt/util"
 )
 
-const renderJSONCommand = "render-json"
+const (
+	renderJSONCommand = "render-json"
+
+	defaultWorkspace = "default"
+
+	// defaultS3WorkspaceKeyPrefix is the prefix of the state keys of the non-default workspaces with the s3 backend.
+	defaultS3WorkspaceKeyPrefix = "env:"
+)
 
 type Dependencies []Dependency
 
@@ -59,17 +70,80 @@ type Dependency struct {
 	MockOutputsMergeWithState         *bool              `hcl:"mock_outputs_merge_with_state,attr" cty:"mock_outputs_merge_with_state"`
 	MockOutputsMergeStrategyWithState *MergeStrategyType `hcl:"mock_outputs_merge_strategy_with_state" cty:"mock_outputs_merge_strategy_with_state"`
 
+	// Workspace is the Terraform workspace to read the outputs of the dependency from, the default one if not set.
+	Workspace *string `hcl:"workspace,attr" cty:"workspace"`
+
+	// WaitFor is the condition that the outputs must satisfy before they are used, for eventually consistent outputs.
+	WaitFor *DependencyWaitFor `hcl:"wait_for,block"`
+
+	// OutputTimeout is how long reading the outputs of the dependency may take before failing, no limit if unset.
+	OutputTimeout *string `hcl:"output_timeout,attr" cty:"output_timeout"`
+
+	// RawOutputs are the names of the outputs passed as `output -raw` prints them, as strings, instead of their types.
+	RawOutputs *[]string `hcl:"raw_outputs,attr" cty:"raw_outputs"`
+
 	// Used to store the rendered outputs for use when the config is imported or read with `read_terragrunt_config`
 	RenderedOutputs *cty.Value `cty:"outputs"`
 	Inputs          *cty.Value `cty:"inputs"`
 }
 
+// DependencyWaitFor is the `wait_for` block of a dependency. The outputs of the dependency are re-read until Condition,
+// evaluated with the outputs available as `outputs`, is true or Timeout is exceeded.
+type DependencyWaitFor struct {
+	Condition hcl.Expression `hcl:"condition,attr"`
+	Timeout   *string        `hcl:"timeout,attr"`
+	Interval  *string        `hcl:"interval,attr"`
+}
+
+const (
+	defaultDependencyWaitForTimeout  = 5 * time.Minute
+	defaultDependencyWaitForInterval = 10 * time.Second
+)
+
+// getTimeout returns the timeout of the wait_for block, defaulting to 5 minutes.
+func (waitFor *DependencyWaitFor) getTimeout() (time.Duration, error) {
+	return parseDependencyWaitForDuration("timeout", waitFor.Timeout, defaultDependencyWaitForTimeout)
+}
+
+// getInterval returns the delay between two reads of the outputs, defaulting to 10 seconds.
+func (waitFor *DependencyWaitFor) getInterval() (time.Duration, error) {
+	return parseDependencyWaitForDuration("interval", waitFor.Interval, defaultDependencyWaitForInterval)
+}
+
+func parseDependencyWaitForDuration(name string, val *string, defaultVal time.Duration) (time.Duration, error) {
+	if val == nil {
+		return defaultVal, nil
+	}
+
+	duration, err := time.ParseDuration(*val)
+	if err != nil || duration <= 0 {
+		return 0, errors.New(InvalidDependencyWaitForDurationError{Name: name, Value: *val})
+	}
+
+	return duration, nil
+}
+
+// getOutputTimeout returns how long reading the outputs of the dependency may take, 0 for no limit.
+func (dep Dependency) getOutputTimeout() (time.Duration, error) {
+	if dep.OutputTimeout == nil {
+		return 0, nil
+	}
+
+	timeout, err := time.ParseDuration(*dep.OutputTimeout)
+	if err != nil || timeout <= 0 {
+		return 0, errors.New(InvalidDependencyOutputTimeoutError{Name: dep.Name, Value: *dep.OutputTimeout})
+	}
+
+	return timeout, nil
+}
+
 // DeepMerge will deep merge two Dependency configs, updating the target. Deep merge for Dependency configs is defined
 // as follows:
-//   - For simple attributes (bools and strings), the source will override the target.
+//   - For simple attributes (bools and strings), such as output_timeout, and the wait_for block, the source will
+//     override the target.
 //   - For MockOutputs, the two maps will be deeply merged together. This means that maps are recursively merged, while
 //     lists are concatenated together.
-//   - For MockOutputsAllowedTerraformCommands, the source will be concatenated to the target.
+//   - For MockOutputsAllowedTerraformCommands and RawOutputs, the source will be concatenated to the target.
 //
 // Note that RenderedOutputs is ignored in the deep merge operation.
 func (dep *Dependency) DeepMerge(sourceDepConfig Dependency) error {
@@ -85,6 +159,18 @@ func (dep *Dependency) DeepMerge(sourceDepConfig Dependency) error {
 		dep.SkipOutputs = sourceDepConfig.SkipOutputs
 	}
 
+	if sourceDepConfig.Workspace != nil {
+		dep.Workspace = sourceDepConfig.Workspace
+	}
+
+	if sourceDepConfig.WaitFor != nil {
+		dep.WaitFor = sourceDepConfig.WaitFor
+	}
+
+	if sourceDepConfig.OutputTimeout != nil {
+		dep.OutputTimeout = sourceDepConfig.OutputTimeout
+	}
+
 	if sourceDepConfig.MockOutputs != nil {
 		if dep.MockOutputs == nil {
 			dep.MockOutputs = sourceDepConfig.MockOutputs
@@ -107,6 +193,15 @@ func (dep *Dependency) DeepMerge(sourceDepConfig Dependency) error {
 		}
 	}
 
+	if sourceDepConfig.RawOutputs != nil {
+		if dep.RawOutputs == nil {
+			dep.RawOutputs = sourceDepConfig.RawOutputs
+		} else {
+			mergedOutputs := append(*dep.RawOutputs, *sourceDepConfig.RawOutputs...)
+			dep.RawOutputs = &mergedOutputs
+		}
+	}
+
 	return nil
 }
 
@@ -127,6 +222,35 @@ func (dep Dependency) getMockOutputsMergeStrategy() MergeStrategyType {
 	return *dep.MockOutputsMergeStrategyWithState
 }
 
+// convertRawOutputs converts the raw_outputs of the dependency to strings, as `output -raw` prints them: the strings are
+// kept as is, the numbers and bools are formatted, and the other types fail, as `output -raw` does. The outputs that
+// are not set are ignored.
+func (dep Dependency) convertRawOutputs(outputs map[string]cty.Value) error {
+	if dep.RawOutputs == nil {
+		return nil
+	}
+
+	for _, name := range *dep.RawOutputs {
+		val, ok := outputs[name]
+		if !ok {
+			continue
+		}
+
+		if !val.Type().IsPrimitiveType() {
+			return errors.New(DependencyRawOutputError{Name: dep.Name, Output: name, Type: val.Type().FriendlyName()})
+		}
+
+		rawVal, err := convert.Convert(val, cty.String)
+		if err != nil {
+			return errors.New(err)
+		}
+
+		outputs[name] = rawVal
+	}
+
+	return nil
+}
+
 // Given a dependency config, we should only attempt to get the outputs if SkipOutputs is nil or false
 func (dep Dependency) shouldGetOutputs(ctx *ParsingContext) bool {
 	return !ctx.TerragruntOptions.SkipOutput && dep.isEnabled() && (dep.SkipOutputs == nil || !*dep.SkipOutputs)
@@ -141,13 +265,44 @@ func (dep Dependency) isEnabled() bool {
 	return *dep.Enabled
 }
 
+// getWorkspace returns the Terraform workspace to read the outputs of the dependency from, or an empty string for the
+// default workspace.
+func (dep Dependency) getWorkspace() string {
+	if dep.Workspace == nil {
+		return ""
+	}
+
+	return *dep.Workspace
+}
+
 // isDisabled returns true if the dependency is disabled
 func (dep Dependency) isDisabled() bool {
 	return !dep.isEnabled()
 }
 
+// isExternal returns true if the dependency lives outside of the root working directory of the current run.
+func (dep Dependency) isExternal(ctx *ParsingContext) bool {
+	if ctx.TerragruntOptions.RootWorkingDir == "" {
+		return false
+	}
+
+	targetConfig := getCleanedTargetConfigPath(dep.ConfigPath.AsString(), ctx.TerragruntOptions.TerragruntConfigPath, ctx.TerragruntOptions)
+
+	return !util.HasPathPrefix(filepath.Dir(targetConfig), ctx.TerragruntOptions.RootWorkingDir)
+}
+
+// isMockOutputsForbidden returns true if the dependency outputs must be read from the existing state, which is the case
+// for external dependencies when the --terragrunt-external-dependencies-outputs-only flag is set.
+func (dep Dependency) isMockOutputsForbidden(ctx *ParsingContext) bool {
+	return ctx.TerragruntOptions.ExternalDependencyOutputsOnly && dep.isExternal(ctx)
+}
+
 // Given a dependency config, we should only attempt to merge mocks outputs with the outputs if MockOutputsMergeWithState is not nil or true
 func (dep Dependency) shouldMergeMockOutputsWithState(ctx *ParsingContext) bool {
+	if dep.isMockOutputsForbidden(ctx) {
+		return false
+	}
+
 	allowedCommand :=
 		dep.MockOutputsAllowedTerraformCommands == nil ||
 			len(*dep.MockOutputsAllowedTerraformCommands) == 0 ||
@@ -161,6 +316,10 @@ func (dep *Dependency) setRenderedOutputs(ctx *ParsingContext) error {
 		return nil
 	}
 
+	if err := dep.mergeCLIMockOutputs(ctx); err != nil {
+		return err
+	}
+
 	if dep.shouldGetOutputs(ctx) || dep.shouldReturnMockOutputs(ctx) {
 		outputVal, err := getTerragruntOutputIfAppliedElseConfiguredDefault(ctx, *dep)
 		if err != nil {
@@ -173,12 +332,46 @@ func (dep *Dependency) setRenderedOutputs(ctx *ParsingContext) error {
 	return nil
 }
 
-// jsonOutputCache is a map that maps config paths to the outputs so that they can be reused across calls for common
+// mergeCLIMockOutputs merges the mock outputs set with the --terragrunt-mock-output flag for this dependency into its
+// mock_outputs. Mock outputs set from the CLI take precedence over the ones set in the config.
+func (dep *Dependency) mergeCLIMockOutputs(ctx *ParsingContext) error {
+	cliMockOutputs := map[string]cty.Value{}
+
+	for key, val := range ctx.TerragruntOptions.MockOutputs {
+		if outputName, ok := strings.CutPrefix(key, dep.Name+"."); ok && outputName != "" {
+			cliMockOutputs[outputName] = cty.StringVal(val)
+		}
+	}
+
+	if len(cliMockOutputs) == 0 {
+		return nil
+	}
+
+	cliMockOutputsVal := cty.ObjectVal(cliMockOutputs)
+
+	if dep.MockOutputs == nil {
+		dep.MockOutputs = &cliMockOutputsVal
+		return nil
+	}
+
+	mergedMockOutputs, err := deepMergeCtyMaps(*dep.MockOutputs, cliMockOutputsVal)
+	if err != nil {
+		return err
+	}
+
+	dep.MockOutputs = mergedMockOutputs
+
+	return nil
+}
+
+// jsonOutputCache is a map that maps config paths, and workspaces, to the outputs so that they can be reused across calls for common
 // modules. We use sync.Map to ensure atomic updates during concurrent access.
 var jsonOutputCache = sync.Map{}
 
-// outputLocks is a map that maps config paths to mutex locks to ensure we only have a single instance of terragrunt
+// outputLocks is a map that maps config paths to locks to ensure we only have a single instance of terragrunt
 // output running for a given dependent config. We use sync.Map to ensure atomic updates during concurrent access.
+// Each lock is a channel with a buffer of one, so that it can be given up as soon as the context of the output
+// retrieval is cancelled, e.g. once the output_timeout of the dependency is exceeded.
 var outputLocks = sync.Map{}
 
 // Decode the dependency blocks from the file, and then retrieve all the outputs from the remote state. Then encode the
@@ -231,7 +424,7 @@ func decodeDependencies(ctx *ParsingContext, decodedDependency TerragruntDepende
 	depCache := cache.ContextCache[*dependencyOutputCache](ctx, DependencyOutputCacheContextKey)
 
 	for _, dep := range decodedDependency.Dependencies {
-		depPath := getCleanedTargetConfigPath(dep.ConfigPath.AsString(), ctx.TerragruntOptions.TerragruntConfigPath)
+		depPath := getCleanedTargetConfigPath(dep.ConfigPath.AsString(), ctx.TerragruntOptions.TerragruntConfigPath, ctx.TerragruntOptions)
 		if dep.isEnabled() && util.FileExists(depPath) {
 			cacheKey := ctx.TerragruntOptions.WorkingDir + depPath
 
@@ -309,7 +502,7 @@ func checkForDependencyBlockCycles(ctx *ParsingContext, configPath string, decod
 			continue
 		}
 
-		dependencyPath := getCleanedTargetConfigPath(dependency.ConfigPath.AsString(), configPath)
+		dependencyPath := getCleanedTargetConfigPath(dependency.ConfigPath.AsString(), configPath, ctx.TerragruntOptions)
 		dependencyOpts, err := cloneTerragruntOptionsForDependency(ctx, dependencyPath)
 
 		if err != nil {
@@ -351,7 +544,7 @@ func checkForDependencyBlockCyclesUsingDFS(
 	}
 
 	for _, dependency := range dependencyPaths {
-		dependencyPath := getCleanedTargetConfigPath(dependency, dependencyPath)
+		dependencyPath := getCleanedTargetConfigPath(dependency, dependencyPath, ctx.TerragruntOptions)
 
 		dependencyOpts, err := cloneTerragruntOptionsForDependency(ctx, dependencyPath)
 		if err != nil {
@@ -472,7 +665,9 @@ func getTerragruntOutputIfAppliedElseConfiguredDefault(ctx *ParsingContext, depe
 	}
 
 	if dependencyConfig.shouldGetOutputs(ctx) {
-		outputVal, isEmpty, err := getTerragruntOutput(ctx, dependencyConfig)
+		outputVal, isEmpty, err := WaitForDependencyOutputs(ctx, dependencyConfig, func() (*cty.Value, bool, error) {
+			return getTerragruntOutputWithTimeout(ctx, dependencyConfig)
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -499,7 +694,7 @@ func getTerragruntOutputIfAppliedElseConfiguredDefault(ctx *ParsingContext, depe
 	// When we get no output, it can be an indication that either the module has no outputs or the module is not
 	// applied. In either case, check if there are default output values to return. If yes, return that. Else,
 	// return error.
-	targetConfig := getCleanedTargetConfigPath(dependencyConfig.ConfigPath.AsString(), ctx.TerragruntOptions.TerragruntConfigPath)
+	targetConfig := getCleanedTargetConfigPath(dependencyConfig.ConfigPath.AsString(), ctx.TerragruntOptions.TerragruntConfigPath, ctx.TerragruntOptions)
 
 	if dependencyConfig.shouldReturnMockOutputs(ctx) {
 		ctx.TerragruntOptions.Logger.Warnf("Config %s is a dependency of %s that has no outputs, but mock outputs provided and returning those in dependency output.",
@@ -514,10 +709,10 @@ func getTerragruntOutputIfAppliedElseConfiguredDefault(ctx *ParsingContext, depe
 	// returning mocks is not allowed. So return a useful error message indicating that we expected outputs, but they
 	// did not exist.
 	err := TerragruntOutputTargetNoOutputs{
-		targetName:    dependencyConfig.Name,
-		targetPath:    dependencyConfig.ConfigPath.AsString(),
-		targetConfig:  targetConfig,
-		currentConfig: ctx.TerragruntOptions.TerragruntConfigPath,
+		TargetName:    dependencyConfig.Name,
+		TargetPath:    dependencyConfig.ConfigPath.AsString(),
+		TargetConfig:  targetConfig,
+		CurrentConfig: ctx.TerragruntOptions.TerragruntConfigPath,
 	}
 
 	return nil, err
@@ -530,6 +725,10 @@ func (dep Dependency) shouldReturnMockOutputs(ctx *ParsingContext) bool {
 		return true
 	}
 
+	if dep.isMockOutputsForbidden(ctx) {
+		return false
+	}
+
 	defaultOutputsSet := dep.MockOutputs != nil
 
 	allowedCommand :=
@@ -545,12 +744,12 @@ func (dep Dependency) shouldReturnMockOutputs(ctx *ParsingContext) bool {
 // module hasn't been applied yet.
 func getTerragruntOutput(ctx *ParsingContext, dependencyConfig Dependency) (*cty.Value, bool, error) {
 	// target config check: make sure the target config exists
-	targetConfigPath := getCleanedTargetConfigPath(dependencyConfig.ConfigPath.AsString(), ctx.TerragruntOptions.TerragruntConfigPath)
+	targetConfigPath := getCleanedTargetConfigPath(dependencyConfig.ConfigPath.AsString(), ctx.TerragruntOptions.TerragruntConfigPath, ctx.TerragruntOptions)
 	if !util.FileExists(targetConfigPath) {
 		return nil, true, errors.New(DependencyConfigNotFound{Path: targetConfigPath})
 	}
 
-	jsonBytes, err := getOutputJSONWithCaching(ctx, targetConfigPath)
+	jsonBytes, err := getOutputJSONWithCaching(ctx, targetConfigPath, dependencyConfig.getWorkspace())
 	if err != nil {
 		if !isRenderJSONCommand(ctx) && !isAwsS3NoSuchKey(err) {
 			return nil, true, err
@@ -571,6 +770,10 @@ func getTerragruntOutput(ctx *ParsingContext, dependencyConfig Dependency) (*cty
 		return nil, isEmpty, err
 	}
 
+	if err := dependencyConfig.convertRawOutputs(outputMap); err != nil {
+		return nil, isEmpty, err
+	}
+
 	// We need to convert the value map to a single cty.Value at the end for use in the terragrunt config.
 	convertedOutput, err := gocty.ToCtyValue(outputMap, generateTypeFromValuesMap(outputMap))
 	if err != nil {
@@ -580,6 +783,146 @@ func getTerragruntOutput(ctx *ParsingContext, dependencyConfig Dependency) (*cty
 	return &convertedOutput, isEmpty, errors.New(err)
 }
 
+// getTerragruntOutputWithTimeout reads the outputs of the dependency with getTerragruntOutput, failing once the
+// output_timeout of the dependency is exceeded. On timeout, the context of the output retrieval is cancelled, which
+// interrupts the running `output` command, and the error is returned right away, without waiting for it to exit.
+func getTerragruntOutputWithTimeout(ctx *ParsingContext, dependencyConfig Dependency) (*cty.Value, bool, error) {
+	timeout, err := dependencyConfig.getOutputTimeout()
+	if err != nil {
+		return nil, true, err
+	}
+
+	if timeout == 0 {
+		return getTerragruntOutput(ctx, dependencyConfig)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	outputCtx := *ctx
+	outputCtx.Context = timeoutCtx
+
+	type outputResult struct {
+		val     *cty.Value
+		isEmpty bool
+		err     error
+	}
+
+	resultCh := make(chan outputResult, 1)
+
+	go func() {
+		val, isEmpty, err := getTerragruntOutput(&outputCtx, dependencyConfig)
+		resultCh <- outputResult{val: val, isEmpty: isEmpty, err: err}
+	}()
+
+	select {
+	case result := <-resultCh:
+		return result.val, result.isEmpty, result.err
+	case <-timeoutCtx.Done():
+		if ctx.Err() != nil {
+			return nil, true, errors.New(ctx.Err())
+		}
+
+		targetConfigPath := getCleanedTargetConfigPath(dependencyConfig.ConfigPath.AsString(), ctx.TerragruntOptions.TerragruntConfigPath, ctx.TerragruntOptions)
+
+		return nil, true, errors.New(DependencyOutputTimeoutError{Name: dependencyConfig.Name, ConfigPath: targetConfigPath, Timeout: timeout})
+	}
+}
+
+// DependencyOutputsReader reads the outputs of a dependency, also returning whether the outputs are empty.
+type DependencyOutputsReader func() (*cty.Value, bool, error)
+
+// WaitForDependencyOutputs reads the outputs of the dependency with readOutputs. If the dependency has a wait_for block,
+// the outputs are re-read every interval, bypassing the output cache, until they satisfy its condition, and an error is
+// returned once its timeout is exceeded. Empty outputs are returned right away, without checking the condition.
+func WaitForDependencyOutputs(ctx *ParsingContext, dep Dependency, readOutputs DependencyOutputsReader) (*cty.Value, bool, error) {
+	if dep.WaitFor == nil {
+		return readOutputs()
+	}
+
+	timeout, err := dep.WaitFor.getTimeout()
+	if err != nil {
+		return nil, true, err
+	}
+
+	interval, err := dep.WaitFor.getInterval()
+	if err != nil {
+		return nil, true, err
+	}
+
+	evalCtx, err := createTerragruntEvalContext(ctx, ctx.TerragruntOptions.TerragruntConfigPath)
+	if err != nil {
+		return nil, true, err
+	}
+
+	targetConfigPath := getCleanedTargetConfigPath(dep.ConfigPath.AsString(), ctx.TerragruntOptions.TerragruntConfigPath, ctx.TerragruntOptions)
+	deadline := time.Now().Add(timeout)
+
+	for {
+		outputVal, isEmpty, err := readOutputs()
+		if err != nil {
+			return nil, isEmpty, err
+		}
+
+		// The dependency is not applied yet, so there is nothing to wait for and the caller falls back to the mock outputs.
+		if isEmpty {
+			return outputVal, isEmpty, nil
+		}
+
+		reason := dependencyWaitForConditionReason(evalCtx, dep.WaitFor.Condition, outputVal)
+		if reason == "" {
+			return outputVal, isEmpty, nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, isEmpty, errors.New(DependencyWaitForTimeoutError{Name: dep.Name, Timeout: timeout, Reason: reason})
+		}
+
+		delay := min(interval, remaining)
+
+		ctx.TerragruntOptions.Logger.Infof("Outputs of dependency %s do not satisfy the wait_for condition (%s), reading them again in %s", dep.Name, reason, delay)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, isEmpty, errors.New(ctx.Err())
+		}
+
+		// Drop the cached outputs so that the next read gets the current outputs of the dependency.
+		jsonOutputCache.Delete(outputCacheKey(targetConfigPath, dep.getWorkspace()))
+	}
+}
+
+// dependencyWaitForConditionReason evaluates the wait_for condition against the outputs and returns why it is not
+// satisfied, or an empty string if it is. Evaluation errors are reported as not satisfied, since the outputs referenced
+// by the condition may not exist yet.
+func dependencyWaitForConditionReason(evalCtx *hcl.EvalContext, condition hcl.Expression, outputVal *cty.Value) string {
+	outputs := cty.EmptyObjectVal
+	if outputVal != nil && !outputVal.IsNull() {
+		outputs = *outputVal
+	}
+
+	evalCtx = evalCtx.NewChild()
+	evalCtx.Variables = map[string]cty.Value{"outputs": outputs}
+
+	val, diags := condition.Value(evalCtx)
+	if diags.HasErrors() {
+		return diags.Error()
+	}
+
+	val, err := convert.Convert(val, cty.Bool)
+	if err != nil {
+		return fmt.Sprintf("the condition must be a bool: %v", err)
+	}
+
+	if val.IsNull() || !val.IsKnown() || val.False() {
+		return "the condition is false"
+	}
+
+	return ""
+}
+
 func isAwsS3NoSuchKey(err error) bool {
 	var awsErr awserr.Error
 	if errors.As(err, &awsErr) {
@@ -594,13 +937,38 @@ func isRenderJSONCommand(ctx *ParsingContext) bool {
 	return util.ListContainsElement(ctx.TerragruntOptions.TerraformCliArgs, renderJSONCommand)
 }
 
-// getOutputJSONWithCaching will run terragrunt output on the target config if it is not already cached.
-func getOutputJSONWithCaching(ctx *ParsingContext, targetConfig string) ([]byte, error) {
+// outputCacheKey returns the key of the outputs of the given workspace of the target config in jsonOutputCache, the
+// outputs of each workspace are cached separately.
+func outputCacheKey(targetConfig, workspace string) string {
+	if workspace == "" {
+		return targetConfig
+	}
+
+	return targetConfig + "@" + workspace
+}
+
+// getOutputJSONWithCaching will run terragrunt output on the target config if it is not already cached. The outputs are
+// read from the given Terraform workspace, or the default one if empty.
+func getOutputJSONWithCaching(ctx *ParsingContext, targetConfig, workspace string) ([]byte, error) {
+	cacheKey := outputCacheKey(targetConfig, workspace)
+
 	// Acquire synchronization lock to ensure only one instance of output is called per config.
-	rawActualLock, _ := outputLocks.LoadOrStore(targetConfig, &sync.Mutex{})
-	actualLock := rawActualLock.(*sync.Mutex)
-	defer actualLock.Unlock()
-	actualLock.Lock()
+	rawActualLock, _ := outputLocks.LoadOrStore(cacheKey, make(chan struct{}, 1))
+	actualLock := rawActualLock.(chan struct{})
+
+	select {
+	case actualLock <- struct{}{}:
+	case <-ctx.Done():
+		return nil, errors.New(ctx.Err())
+	}
+
+	// Release the lock once the context is cancelled, without waiting for the `output` command to exit, so that a
+	// timed out retrieval doesn't block the next reads of the same dependency.
+	unlock := sync.OnceFunc(func() { <-actualLock })
+	defer unlock()
+
+	stopUnlockOnCancel := context.AfterFunc(ctx, unlock)
+	defer stopUnlockOnCancel()
 
 	// This debug log is useful for validating if the locking mechanism is working. If the locking mechanism is working,
 	// we should only see one pair of logs at a time that begin with this statement, and then the relevant "terraform
@@ -608,7 +976,7 @@ func getOutputJSONWithCaching(ctx *ParsingContext, targetConfig string) ([]byte,
 	ctx.TerragruntOptions.Logger.Debugf("Getting output of dependency %s for config %s", targetConfig, ctx.TerragruntOptions.TerragruntConfigPath)
 
 	// Look up if we have already run terragrunt output for this target config
-	rawJSONBytes, hasRun := jsonOutputCache.Load(targetConfig)
+	rawJSONBytes, hasRun := jsonOutputCache.Load(cacheKey)
 	if hasRun {
 		// Cache hit, so return cached output
 		ctx.TerragruntOptions.Logger.Debugf("%s was run before. Using cached output.", targetConfig)
@@ -616,7 +984,7 @@ func getOutputJSONWithCaching(ctx *ParsingContext, targetConfig string) ([]byte,
 	}
 
 	// Cache miss, so look up the output and store in cache
-	newJSONBytes, err := getTerragruntOutputJSON(ctx, targetConfig)
+	newJSONBytes, err := getTerragruntOutputJSON(ctx, targetConfig, workspace)
 	if err != nil {
 		return nil, err
 	}
@@ -628,7 +996,7 @@ func getOutputJSONWithCaching(ctx *ParsingContext, targetConfig string) ([]byte,
 		newJSONBytes = newJSONBytes[index:]
 	}
 
-	jsonOutputCache.Store(targetConfig, newJSONBytes)
+	jsonOutputCache.Store(cacheKey, newJSONBytes)
 
 	return newJSONBytes, nil
 }
@@ -739,8 +1107,8 @@ func cloneTerragruntOptionsForDependencyOutput(ctx *ParsingContext, targetConfig
 // - The `remote_state` block does not depend on any `dependency` outputs.
 // If these conditions are met, terragrunt can optimize the retrieval to avoid recursively retrieving dependency outputs
 // by directly pulling down the state file. Otherwise, terragrunt will fallback to running `terragrunt output` on the
-// target module.
-func getTerragruntOutputJSON(ctx *ParsingContext, targetConfig string) ([]byte, error) {
+// target module. The outputs are read from the given Terraform workspace, or the default one if empty.
+func getTerragruntOutputJSON(ctx *ParsingContext, targetConfig, workspace string) ([]byte, error) {
 	// Make a copy of the terragruntOptions so that we can reuse the same execution environment, but in the ctx of
 	// the target config.
 	targetTGOptions, err := cloneTerragruntOptionsForDependencyOutput(ctx, targetConfig)
@@ -748,6 +1116,12 @@ func getTerragruntOutputJSON(ctx *ParsingContext, targetConfig string) ([]byte,
 		return nil, err
 	}
 
+	// Terraform selects the workspace set with TF_WORKSPACE, the options of all the commands run to read the outputs
+	// are cloned from these ones.
+	if workspace != "" {
+		targetTGOptions.Env[terraform.EnvNameTFWorkspace] = workspace
+	}
+
 	ctx = ctx.WithTerragruntOptions(targetTGOptions)
 
 	// First attempt to parse the `remote_state` blocks without parsing/getting dependency outputs. If this is possible,
@@ -784,9 +1158,10 @@ func getTerragruntOutputJSON(ctx *ParsingContext, targetConfig string) ([]byte,
 	return getTerragruntOutputJSONFromRemoteState(ctx, targetConfig, remoteStateTGConfig.RemoteState, remoteStateTGConfig.GetIAMRoleOptions())
 }
 
-// canGetRemoteState returns true if the remote state block is not nil and dependency optimization is not disabled
+// canGetRemoteState returns true if the remote state block is not nil and dependency optimization is not disabled. A
+// partial backend can't be used either, since its configuration is only known to whoever runs `init`.
 func canGetRemoteState(remoteState *remote.RemoteState) bool {
-	return remoteState != nil && !remoteState.DisableDependencyOptimization
+	return remoteState != nil && !remoteState.DisableDependencyOptimization && !remoteState.IsPartial()
 }
 
 // terragruntAlreadyInit returns true if it detects that the module specified by the given terragrunt configuration is
@@ -966,7 +1341,19 @@ func getTerragruntOutputJSONFromRemoteState(
 
 // getTerragruntOutputJSONFromRemoteStateS3 pulls the output directly from an S3 bucket without calling Terraform
 func getTerragruntOutputJSONFromRemoteStateS3(terragruntOptions *options.TerragruntOptions, remoteState *remote.RemoteState) ([]byte, error) {
-	terragruntOptions.Logger.Debugf("Fetching outputs directly from s3://%s/%s", remoteState.Config["bucket"], remoteState.Config["key"])
+	key := fmt.Sprintf("%s", remoteState.Config["key"])
+
+	// The state of a non-default workspace is stored under the workspace key prefix of the backend.
+	if workspace := terragruntOptions.Env[terraform.EnvNameTFWorkspace]; workspace != "" && workspace != defaultWorkspace {
+		prefix := defaultS3WorkspaceKeyPrefix
+		if val, ok := remoteState.Config["workspace_key_prefix"]; ok {
+			prefix = fmt.Sprintf("%s", val)
+		}
+
+		key = fmt.Sprintf("%s/%s/%s", prefix, workspace, key)
+	}
+
+	terragruntOptions.Logger.Debugf("Fetching outputs directly from s3://%s/%s", remoteState.Config["bucket"], key)
 
 	s3ConfigExtended, err := remote.ParseExtendedS3Config(remoteState.Config)
 	if err != nil {
@@ -982,7 +1369,7 @@ func getTerragruntOutputJSONFromRemoteStateS3(terragruntOptions *options.Terragr
 
 	result, err := s3Client.GetObject(&s3.GetObjectInput{
 		Bucket: aws.String(fmt.Sprintf("%s", remoteState.Config["bucket"])),
-		Key:    aws.String(fmt.Sprintf("%s", remoteState.Config["key"])),
+		Key:    aws.String(key),
 	})
 
 	if err != nil {
@@ -3,10 +3,14 @@ package config_test
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/cli"
+	"github.com/gruntwork-io/terragrunt/util"
 
 	"github.com/gruntwork-io/go-commons/env"
 	"github.com/gruntwork-io/terragrunt/config/hclparse"
@@ -150,3 +154,568 @@ dependency "vpc" {
 	require.NoError(t, file.Decode(&decoded, &hcl.EvalContext{}))
 	assert.Len(t, decoded.Dependencies, 2)
 }
+
+func TestDependencyCycleErrorMembers(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		name     string
+		expected []string
+	}{
+		{"aa", []string{"foo", "foo"}},
+		{"aba", []string{"foo", "bar", "foo"}},
+		{"abca", []string{"foo", "bar", "foo"}},
+		{"abcda", []string{"foo", "bar", "baz", "car", "foo"}},
+	}
+
+	for _, tt := range tc {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			configPath := absPath(t, filepath.Join("../test/fixtures/get-output/cycle", tt.name, "foo", config.DefaultTerragruntConfigPath))
+			opts := terragruntOptionsForTest(t, configPath)
+
+			_, err := config.ReadTerragruntConfig(context.Background(), opts, config.DefaultParserOptions(opts))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "Found a dependency cycle between modules")
+
+			var cycleErr config.DependencyCycleError
+			require.ErrorAs(t, err, &cycleErr)
+
+			actual := make([]string, 0, len(cycleErr))
+			for _, path := range cycleErr {
+				actual = append(actual, filepath.Base(filepath.Dir(path)))
+			}
+
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestDependencyNotAppliedExitCode(t *testing.T) {
+	t.Parallel()
+
+	notAppliedErr := errors.New(config.TerragruntOutputTargetNoOutputs{
+		TargetName:    "vpc",
+		TargetPath:    "../vpc",
+		TargetConfig:  "../vpc/terragrunt.hcl",
+		CurrentConfig: "app/terragrunt.hcl",
+	})
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"dependency not applied", notAppliedErr, config.DependencyNotAppliedExitCode},
+		{"dependency not applied in a run-all", new(errors.MultiError).Append(notAppliedErr), config.DependencyNotAppliedExitCode},
+		{"other failure", cli.NewExitError(errors.New("failed"), 1), 1},
+		{"detailed exit code", util.ProcessExecutionError{Err: cli.NewExitError(errors.New("changes"), 2)}, 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			exitCode, err := util.GetExitCode(tc.err)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, exitCode)
+		})
+	}
+
+	var noOutputsErr config.TerragruntOutputTargetNoOutputs
+
+	require.ErrorAs(t, notAppliedErr, &noOutputsErr)
+	assert.Equal(t, "../vpc", noOutputsErr.TargetPath)
+}
+
+func TestDependencyOutputWorkspace(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	vpcDir := filepath.Join(tmpDir, "vpc")
+	require.NoError(t, os.MkdirAll(vpcDir, os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(vpcDir, config.DefaultTerragruntConfigPath), nil, 0644))
+
+	appConfigPath := filepath.Join(tmpDir, "app", config.DefaultTerragruntConfigPath)
+
+	cfg := `
+dependency "vpc" {
+  config_path = "../vpc"
+}
+
+dependency "vpc_staging" {
+  config_path = "../vpc"
+  workspace   = "staging"
+}
+
+inputs = {
+  vpc_id         = dependency.vpc.outputs.id
+  staging_vpc_id = dependency.vpc_staging.outputs.id
+}
+`
+	// The state of each workspace of the vpc unit.
+	states := map[string]string{
+		"":        `{"id": {"sensitive": false, "type": "string", "value": "vpc-default"}}`,
+		"staging": `{"id": {"sensitive": false, "type": "string", "value": "vpc-staging"}}`,
+	}
+
+	opts, err := options.NewTerragruntOptionsForTest(appConfigPath)
+	require.NoError(t, err)
+
+	// Simulate the `terragrunt output` command of the dependency, reading the state of the workspace Terraform selects.
+	opts.RunTerragrunt = func(_ context.Context, opts *options.TerragruntOptions) error {
+		state, ok := states[opts.Env["TF_WORKSPACE"]]
+		if !ok {
+			return errors.Errorf("workspace %q does not exist", opts.Env["TF_WORKSPACE"])
+		}
+
+		_, err := opts.Writer.Write([]byte(state))
+
+		return err
+	}
+
+	ctx := config.NewParsingContext(context.Background(), opts)
+
+	terragruntConfig, err := config.ParseConfigString(ctx, appConfigPath, cfg, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "vpc-default", terragruntConfig.Inputs["vpc_id"])
+	assert.Equal(t, "vpc-staging", terragruntConfig.Inputs["staging_vpc_id"])
+}
+
+func TestDependencySkipOutputsFlag(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		skipOutput      bool
+		expectedOutputs int
+	}{
+		{"outputs read", false, 1},
+		{"outputs skipped", true, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpDir := t.TempDir()
+
+			depDir := filepath.Join(tmpDir, "dep")
+			require.NoError(t, os.MkdirAll(depDir, os.ModePerm))
+			require.NoError(t, os.WriteFile(filepath.Join(depDir, config.DefaultTerragruntConfigPath), nil, 0644))
+
+			appConfigPath := filepath.Join(tmpDir, "app", config.DefaultTerragruntConfigPath)
+
+			cfg := `
+dependency "dep" {
+  config_path = "../dep"
+
+  mock_outputs = {
+    name = "mock"
+  }
+}
+
+inputs = {
+  name = dependency.dep.outputs.name
+}
+`
+			opts, err := options.NewTerragruntOptionsForTest(appConfigPath)
+			require.NoError(t, err)
+
+			opts.SkipOutput = tc.skipOutput
+
+			// Count the `terragrunt output` commands of the dependency, which is not applied yet.
+			outputs := 0
+			opts.RunTerragrunt = func(_ context.Context, opts *options.TerragruntOptions) error {
+				outputs++
+
+				_, err := opts.Writer.Write([]byte(`{}`))
+
+				return err
+			}
+
+			ctx := config.NewParsingContext(context.Background(), opts)
+
+			terragruntConfig, err := config.ParseConfigString(ctx, appConfigPath, cfg, nil)
+			require.NoError(t, err)
+			assert.Equal(t, "mock", terragruntConfig.Inputs["name"])
+			assert.Equal(t, tc.expectedOutputs, outputs)
+		})
+	}
+}
+
+func TestDependencyWaitFor(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		timeout       string
+		readyAfter    int
+		expectedReads int
+		expectTimeout bool
+	}{
+		{"condition satisfied after a delay", "10s", 3, 4, false},
+		{"condition satisfied immediately", "10s", 0, 1, false},
+		{"condition never satisfied", "100ms", -1, 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := `
+dependency "vpc" {
+  config_path = "../vpc"
+
+  wait_for {
+    condition = outputs.status == "ready"
+    timeout   = "` + tc.timeout + `"
+    interval  = "10ms"
+  }
+}
+`
+			file, err := hclparse.NewParser().ParseFromString(cfg, config.DefaultTerragruntConfigPath)
+			require.NoError(t, err)
+
+			decoded := config.TerragruntDependency{}
+			require.NoError(t, file.Decode(&decoded, &hcl.EvalContext{}))
+			require.Len(t, decoded.Dependencies, 1)
+
+			opts, err := options.NewTerragruntOptionsForTest(filepath.Join(t.TempDir(), config.DefaultTerragruntConfigPath))
+			require.NoError(t, err)
+
+			// Simulate eventually consistent outputs, the status only becomes ready after a number of reads.
+			reads := 0
+			readOutputs := func() (*cty.Value, bool, error) {
+				status := "pending"
+				if tc.readyAfter >= 0 && reads >= tc.readyAfter {
+					status = "ready"
+				}
+
+				reads++
+
+				outputs := cty.ObjectVal(map[string]cty.Value{"status": cty.StringVal(status)})
+
+				return &outputs, false, nil
+			}
+
+			ctx := config.NewParsingContext(context.Background(), opts)
+
+			outputs, _, err := config.WaitForDependencyOutputs(ctx, decoded.Dependencies[0], readOutputs)
+			if tc.expectTimeout {
+				var timeoutErr config.DependencyWaitForTimeoutError
+
+				require.ErrorAs(t, err, &timeoutErr)
+				assert.Equal(t, "vpc", timeoutErr.Name)
+				assert.Greater(t, reads, 1)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedReads, reads)
+			assert.Equal(t, cty.StringVal("ready"), outputs.GetAttr("status"))
+		})
+	}
+}
+
+func TestDependencyWaitForNotApplied(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	vpcDir := filepath.Join(tmpDir, "vpc")
+	require.NoError(t, os.MkdirAll(vpcDir, os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(vpcDir, config.DefaultTerragruntConfigPath), nil, 0644))
+
+	appConfigPath := filepath.Join(tmpDir, "app", config.DefaultTerragruntConfigPath)
+
+	cfg := `
+dependency "vpc" {
+  config_path = "../vpc"
+
+  mock_outputs = {
+    id     = "mock-vpc"
+    status = "pending"
+  }
+
+  wait_for {
+    condition = outputs.status == "ready"
+    timeout   = "1m"
+    interval  = "10ms"
+  }
+}
+
+inputs = {
+  vpc_id = dependency.vpc.outputs.id
+}
+`
+	opts, err := options.NewTerragruntOptionsForTest(appConfigPath)
+	require.NoError(t, err)
+
+	// Simulate the `terragrunt output` command of a dependency that is not applied yet.
+	reads := 0
+	opts.RunTerragrunt = func(_ context.Context, opts *options.TerragruntOptions) error {
+		reads++
+
+		_, err := opts.Writer.Write([]byte(`{}`))
+
+		return err
+	}
+
+	ctx := config.NewParsingContext(context.Background(), opts)
+
+	terragruntConfig, err := config.ParseConfigString(ctx, appConfigPath, cfg, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "mock-vpc", terragruntConfig.Inputs["vpc_id"])
+	assert.Equal(t, 1, reads)
+}
+
+func TestDependencyOutputTimeout(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		outputTimeout string
+		hang          bool
+		expectTimeout bool
+	}{
+		{"output command hangs", "100ms", true, true},
+		{"output command returns in time", "10s", false, false},
+		{"no timeout", "", false, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpDir := t.TempDir()
+
+			vpcDir := filepath.Join(tmpDir, "vpc")
+			require.NoError(t, os.MkdirAll(vpcDir, os.ModePerm))
+			require.NoError(t, os.WriteFile(filepath.Join(vpcDir, config.DefaultTerragruntConfigPath), nil, 0644))
+
+			appConfigPath := filepath.Join(tmpDir, "app", config.DefaultTerragruntConfigPath)
+
+			outputTimeout := ""
+			if tc.outputTimeout != "" {
+				outputTimeout = `output_timeout = "` + tc.outputTimeout + `"`
+			}
+
+			cfg := `
+dependency "vpc" {
+  config_path = "../vpc"
+  ` + outputTimeout + `
+}
+
+inputs = {
+  vpc_id = dependency.vpc.outputs.id
+}
+`
+			opts, err := options.NewTerragruntOptionsForTest(appConfigPath)
+			require.NoError(t, err)
+
+			// Simulate the `terragrunt output` command of the dependency, hanging until it is interrupted.
+			opts.RunTerragrunt = func(ctx context.Context, opts *options.TerragruntOptions) error {
+				if tc.hang {
+					<-ctx.Done()
+					return ctx.Err()
+				}
+
+				_, err := opts.Writer.Write([]byte(`{"id": {"sensitive": false, "type": "string", "value": "vpc-1"}}`))
+
+				return err
+			}
+
+			ctx := config.NewParsingContext(context.Background(), opts)
+
+			terragruntConfig, err := config.ParseConfigString(ctx, appConfigPath, cfg, nil)
+			if tc.expectTimeout {
+				var timeoutErr config.DependencyOutputTimeoutError
+
+				require.ErrorAs(t, err, &timeoutErr)
+				assert.Equal(t, "vpc", timeoutErr.Name)
+				assert.Equal(t, filepath.Join(vpcDir, config.DefaultTerragruntConfigPath), timeoutErr.ConfigPath)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "vpc-1", terragruntConfig.Inputs["vpc_id"])
+		})
+	}
+}
+
+func TestDependencyOutputTimeoutReleasesLock(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	vpcDir := filepath.Join(tmpDir, "vpc")
+	require.NoError(t, os.MkdirAll(vpcDir, os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(vpcDir, config.DefaultTerragruntConfigPath), nil, 0644))
+
+	appConfigPath := filepath.Join(tmpDir, "app", config.DefaultTerragruntConfigPath)
+
+	parseConfig := func(outputTimeout string, runTerragrunt func(ctx context.Context, opts *options.TerragruntOptions) error) (*config.TerragruntConfig, error) {
+		cfg := `
+dependency "vpc" {
+  config_path    = "../vpc"
+  output_timeout = "` + outputTimeout + `"
+}
+
+inputs = {
+  vpc_id = dependency.vpc.outputs.id
+}
+`
+		opts, err := options.NewTerragruntOptionsForTest(appConfigPath)
+		require.NoError(t, err)
+
+		opts.RunTerragrunt = runTerragrunt
+
+		return config.ParseConfigString(config.NewParsingContext(context.Background(), opts), appConfigPath, cfg, nil)
+	}
+
+	// Simulate a `terragrunt output` command of the dependency that doesn't exit when it is interrupted.
+	exit := make(chan struct{})
+	defer close(exit)
+
+	_, err := parseConfig("100ms", func(_ context.Context, _ *options.TerragruntOptions) error {
+		<-exit
+		return nil
+	})
+
+	var timeoutErr config.DependencyOutputTimeoutError
+
+	require.ErrorAs(t, err, &timeoutErr)
+
+	// The next read of the same dependency doesn't wait for the orphaned command to exit.
+	terragruntConfig, err := parseConfig("10s", func(_ context.Context, opts *options.TerragruntOptions) error {
+		_, err := opts.Writer.Write([]byte(`{"id": {"sensitive": false, "type": "string", "value": "vpc-1"}}`))
+
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "vpc-1", terragruntConfig.Inputs["vpc_id"])
+}
+
+func TestDependencyConfigFilenameOverride(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	// the dependency is in a subtree using another config filename
+	vpcConfigPath := filepath.Join(tmpDir, "new", "vpc", "unit.hcl")
+	require.NoError(t, os.MkdirAll(filepath.Dir(vpcConfigPath), os.ModePerm))
+	require.NoError(t, os.WriteFile(vpcConfigPath, nil, 0644))
+
+	appConfigPath := filepath.Join(tmpDir, "legacy", "app", config.DefaultTerragruntConfigPath)
+
+	cfg := `
+dependency "vpc" {
+  config_path = "../../new/vpc"
+}
+
+inputs = {
+  vpc_id = dependency.vpc.outputs.id
+}
+`
+	opts, err := options.NewTerragruntOptionsForTest(appConfigPath)
+	require.NoError(t, err)
+
+	opts.RootWorkingDir = tmpDir
+	opts.ConfigFilenameOverrides = map[string]string{"new/**": "unit.hcl"}
+
+	var outputConfigPath string
+
+	opts.RunTerragrunt = func(_ context.Context, opts *options.TerragruntOptions) error {
+		outputConfigPath = opts.TerragruntConfigPath
+
+		_, err := opts.Writer.Write([]byte(`{"id": {"sensitive": false, "type": "string", "value": "vpc-1"}}`))
+
+		return err
+	}
+
+	ctx := config.NewParsingContext(context.Background(), opts)
+
+	terragruntConfig, err := config.ParseConfigString(ctx, appConfigPath, cfg, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "vpc-1", terragruntConfig.Inputs["vpc_id"])
+	assert.Equal(t, vpcConfigPath, outputConfigPath)
+}
+
+func TestDependencyRawOutputs(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		rawOutputs    string
+		expectedID    any
+		expectedPort  any
+		expectedError bool
+	}{
+		{"raw outputs", `["id", "port", "missing"]`, `{"name": "vpc-1"}`, "8080", false},
+		{"no raw outputs", `[]`, `{"name": "vpc-1"}`, float64(8080), false},
+		{"raw list output", `["subnets"]`, nil, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpDir := t.TempDir()
+
+			vpcDir := filepath.Join(tmpDir, "vpc")
+			require.NoError(t, os.MkdirAll(vpcDir, os.ModePerm))
+			require.NoError(t, os.WriteFile(filepath.Join(vpcDir, config.DefaultTerragruntConfigPath), nil, 0644))
+
+			appConfigPath := filepath.Join(tmpDir, "app", config.DefaultTerragruntConfigPath)
+
+			cfg := `
+dependency "vpc" {
+  config_path = "../vpc"
+  raw_outputs = ` + tc.rawOutputs + `
+}
+
+inputs = {
+  vpc_id   = dependency.vpc.outputs.id
+  vpc_port = dependency.vpc.outputs.port
+}
+`
+			opts, err := options.NewTerragruntOptionsForTest(appConfigPath)
+			require.NoError(t, err)
+
+			// Simulate the `terragrunt output` command of the dependency, with a string output that looks like JSON.
+			opts.RunTerragrunt = func(_ context.Context, opts *options.TerragruntOptions) error {
+				_, err := opts.Writer.Write([]byte(`{
+  "id": {"sensitive": false, "type": "string", "value": "{\"name\": \"vpc-1\"}"},
+  "port": {"sensitive": false, "type": "number", "value": 8080},
+  "subnets": {"sensitive": false, "type": ["list", "string"], "value": ["subnet-1"]}
+}`))
+
+				return err
+			}
+
+			ctx := config.NewParsingContext(context.Background(), opts)
+
+			terragruntConfig, err := config.ParseConfigString(ctx, appConfigPath, cfg, nil)
+			if tc.expectedError {
+				var rawOutputErr config.DependencyRawOutputError
+
+				require.ErrorAs(t, err, &rawOutputErr)
+				assert.Equal(t, "subnets", rawOutputErr.Output)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedID, terragruntConfig.Inputs["vpc_id"])
+			assert.Equal(t, tc.expectedPort, terragruntConfig.Inputs["vpc_port"])
+		})
+	}
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 // Custom error types
@@ -48,6 +49,17 @@ func (err InvalidMergeStrategyTypeError) Error() string {
 	)
 }
 
+type InvalidRetryableErrorsMergeError string
+
+func (err InvalidRetryableErrorsMergeError) Error() string {
+	return fmt.Sprintf(
+		"retryable_errors_merge %s is unknown. Valid values are: %s, %s",
+		string(err),
+		RetryableErrorsMergeAppend,
+		RetryableErrorsMergeReplace,
+	)
+}
+
 type DependencyDirNotFoundError struct {
 	Dir []string
 }
@@ -68,6 +80,35 @@ func (err DuplicatedGenerateBlocksError) Error() string {
 	)
 }
 
+type InvalidGenerateBlockError struct {
+	Name string
+	Err  error
+}
+
+func (err InvalidGenerateBlockError) Error() string {
+	return fmt.Sprintf("Invalid generate block %q: %v", err.Name, err.Err)
+}
+
+func (err InvalidGenerateBlockError) Unwrap() error {
+	return err.Err
+}
+
+type GenerateBlockContentsMissingError struct {
+	Name string
+}
+
+func (err GenerateBlockContentsMissingError) Error() string {
+	return fmt.Sprintf("The generate block %q must set either the contents or the required_version/required_providers attributes", err.Name)
+}
+
+type GenerateBlockContentsConflictError struct {
+	Name string
+}
+
+func (err GenerateBlockContentsConflictError) Error() string {
+	return fmt.Sprintf("The generate block %q cannot set both the contents and the required_version/required_providers attributes", err.Name)
+}
+
 type TFVarFileNotFoundError struct {
 	File  string
 	Cause string
@@ -106,6 +147,16 @@ func (err ParentFileNotFoundError) Error() string {
 	return fmt.Sprintf("ParentFileNotFoundError: Could not find a %s in any of the parent folders of %s. Cause: %s.", err.File, err.Path, err.Cause)
 }
 
+type IncludeOutsideRepoError struct {
+	ConfigPath  string
+	IncludePath string
+	RepoRoot    string
+}
+
+func (err IncludeOutsideRepoError) Error() string {
+	return fmt.Sprintf("%s includes %s, which is outside of its git repository %s. Includes are restricted to the repository by the --terragrunt-restrict-includes-to-repo flag.", err.ConfigPath, err.IncludePath, err.RepoRoot)
+}
+
 type InvalidGetEnvParamsError struct {
 	ActualNumParams int
 	Example         string
@@ -137,6 +188,12 @@ func (err EmptyStringNotAllowedError) Error() string {
 	return "Empty string value is not allowed for " + string(err)
 }
 
+type InvalidStableSuffixLengthError string
+
+func (length InvalidStableSuffixLengthError) Error() string {
+	return fmt.Sprintf("Invalid length %s for the stable_suffix function, expected a whole number between 1 and %d", string(length), StableSuffixMaxLength)
+}
+
 type TerragruntConfigNotFoundError struct {
 	Path string
 }
@@ -225,15 +282,25 @@ func (err TerragruntOutputListEncodingError) Error() string {
 	return fmt.Sprintf("Could not encode output from list of terragrunt configs %v. Underlying error: %s", err.Paths, err.Err)
 }
 
+// DependencyNotAppliedExitCode is the exit code of Terragrunt when the outputs of a dependency are read but the
+// dependency has no outputs, most likely because it has not been applied yet.
+const DependencyNotAppliedExitCode = 3
+
+// TerragruntOutputTargetNoOutputs is returned when a dependency has no outputs, either because it has not been
+// applied yet, or because the module has no outputs.
 type TerragruntOutputTargetNoOutputs struct {
-	targetName    string
-	targetPath    string
-	targetConfig  string
-	currentConfig string
+	// TargetName is the name of the dependency block.
+	TargetName string
+	// TargetPath is the config_path of the dependency block.
+	TargetPath string
+	// TargetConfig is the path of the config of the dependency.
+	TargetConfig string
+	// CurrentConfig is the path of the config with the dependency block.
+	CurrentConfig string
 }
 
 func (err TerragruntOutputTargetNoOutputs) ExitCode() int {
-	return 1
+	return DependencyNotAppliedExitCode
 }
 
 func (err TerragruntOutputTargetNoOutputs) Unwrap() error {
@@ -244,11 +311,11 @@ func (err TerragruntOutputTargetNoOutputs) Error() string {
 	msg := `
 If this dependency is accessed before the outputs are ready (which can happen during the planning phase of an unapplied stack), consider using mock_outputs:
 
-dependency "` + err.targetName + `" {
-    config_path = "` + err.targetPath + `"
+dependency "` + err.TargetName + `" {
+    config_path = "` + err.TargetPath + `"
 
     mock_outputs = {
-        ` + err.targetName + `_output = "mock-` + err.targetName + `-output"
+        ` + err.TargetName + `_output = "mock-` + err.TargetName + `-output"
     }
 }
 
@@ -261,12 +328,77 @@ https://terragrunt.gruntwork.io/docs/reference/config-blocks-and-attributes/#dep
 
 	return fmt.Sprintf(
 		"%s is a dependency of %s but detected no outputs. Either the target module has not been applied yet, or the module has no outputs.\n%s",
-		err.targetConfig,
-		err.currentConfig,
+		err.TargetConfig,
+		err.CurrentConfig,
 		msg,
 	)
 }
 
+// DependencyWaitForTimeoutError is returned when the outputs of a dependency do not satisfy its wait_for condition in time.
+type DependencyWaitForTimeoutError struct {
+	Name    string
+	Reason  string
+	Timeout time.Duration
+}
+
+func (err DependencyWaitForTimeoutError) Error() string {
+	return fmt.Sprintf("timed out after %s waiting for the outputs of dependency %s to satisfy the wait_for condition: %s", err.Timeout, err.Name, err.Reason)
+}
+
+// DependencyOutputTimeoutError is returned when reading the outputs of a dependency takes longer than its output_timeout.
+type DependencyOutputTimeoutError struct {
+	Name       string
+	ConfigPath string
+	Timeout    time.Duration
+}
+
+func (err DependencyOutputTimeoutError) Error() string {
+	return fmt.Sprintf("timed out after %s reading the outputs of dependency %s from %s, see the output_timeout attribute of the dependency block", err.Timeout, err.Name, err.ConfigPath)
+}
+
+// DependencyRawOutputError is returned when an output listed in raw_outputs is not a primitive value.
+type DependencyRawOutputError struct {
+	Name   string
+	Output string
+	Type   string
+}
+
+func (err DependencyRawOutputError) Error() string {
+	return fmt.Sprintf("output %s of dependency %s is listed in raw_outputs but is a %s, only strings, numbers and bools can be read raw", err.Output, err.Name, err.Type)
+}
+
+// InvalidDependencyOutputTimeoutError is returned when the output_timeout of a dependency is not a positive duration.
+type InvalidDependencyOutputTimeoutError struct {
+	Name  string
+	Value string
+}
+
+func (err InvalidDependencyOutputTimeoutError) Error() string {
+	return fmt.Sprintf("invalid output_timeout %q in dependency %s: expected a positive duration such as \"30s\" or \"5m\"", err.Value, err.Name)
+}
+
+// InvalidDependencyWaitForDurationError is returned when a duration of a wait_for block is not a positive duration.
+type InvalidDependencyWaitForDurationError struct {
+	Name  string
+	Value string
+}
+
+func (err InvalidDependencyWaitForDurationError) Error() string {
+	return fmt.Sprintf("invalid %s %q in wait_for block: expected a positive duration such as \"30s\" or \"5m\"", err.Name, err.Value)
+}
+
+// FunctionNotAllowedError is returned when the configuration calls a function that is not allowed by the functions
+// policy set with --terragrunt-allowed-functions or --terragrunt-denied-functions.
+type FunctionNotAllowedError struct {
+	Name string
+}
+
+func (err FunctionNotAllowedError) Error() string {
+	return fmt.Sprintf("the function %s is not allowed to be called in the Terragrunt configuration", err.Name)
+}
+
+// DependencyCycleError is returned when the `dependency` blocks form a cycle. It holds the config paths that make up the
+// cycle in traversal order, with the first path repeated at the end, and can be retrieved with `errors.As`.
 type DependencyCycleError []string
 
 func (err DependencyCycleError) Error() string {
@@ -2,11 +2,13 @@ package hclparse
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
 const (
@@ -51,6 +53,34 @@ func (file *File) Update(content []byte) error {
 	return nil
 }
 
+// FunctionCalls walks the AST of the file and returns the sorted names of all the functions called in its expressions,
+// without evaluating them. Only the native HCL syntax is analyzed, for JSON files an empty list is returned.
+func (file *File) FunctionCalls() []string {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return []string{}
+	}
+
+	names := make(map[string]struct{})
+
+	hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics { //nolint:errcheck
+		if call, ok := node.(*hclsyntax.FunctionCallExpr); ok {
+			names[call.Name] = struct{}{}
+		}
+
+		return nil
+	})
+
+	functions := make([]string, 0, len(names))
+	for name := range names {
+		functions = append(functions, name)
+	}
+
+	sort.Strings(functions)
+
+	return functions
+}
+
 // Decode uses the HCL2 parser to decode the parsed HCL into the struct specified by out.
 //
 // Note that we take a two pass approach to support parsing include blocks without a label. Ideally we can parse include
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// ignoreRule is a pattern of an ignore file, such as `.terragruntignore`, in the gitignore format.
+type ignoreRule struct {
+	// The directory of the ignore file the pattern comes from, the paths are matched relative to it.
+	baseDir string
+	pattern string
+	// True for the patterns prefixed with `!`, which re-include the paths excluded by the previous patterns.
+	negate bool
+	// True for the patterns containing a `/`, which match the path relative to baseDir, instead of the base name at any level.
+	anchored bool
+}
+
+// readIgnoreFile parses the ignore file with the given name in dir, if any. Blank lines and lines starting with `#` are
+// skipped. As only directories are matched against the patterns, a trailing `/` makes no difference and is dropped.
+func readIgnoreFile(dir, name string) ([]ignoreRule, error) {
+	file, err := os.Open(filepath.Join(dir, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, errors.New(err)
+	}
+	defer file.Close()
+
+	var rules []ignoreRule
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := ignoreRule{baseDir: dir}
+
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+
+		line = strings.TrimRight(line, "/")
+
+		rule.anchored = strings.Contains(line, "/")
+		rule.pattern = strings.TrimPrefix(line, "/")
+
+		if rule.pattern != "" {
+			rules = append(rules, rule)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.New(err)
+	}
+
+	return rules, nil
+}
+
+// isIgnored returns true if the given directory is excluded by the rules. As in gitignore, the last matching rule wins, so
+// that a negated pattern can re-include a path excluded by an earlier pattern of the same or a parent ignore file.
+func isIgnored(rules []ignoreRule, path string) bool {
+	ignored := false
+
+	for _, rule := range rules {
+		relPath, err := filepath.Rel(rule.baseDir, path)
+		if err != nil {
+			continue
+		}
+
+		// the paths outside of the directory of the ignore file are not matched against its rules
+		relPath = filepath.ToSlash(relPath)
+		if relPath == "." || relPath == ".." || strings.HasPrefix(relPath, "../") {
+			continue
+		}
+
+		if !rule.anchored {
+			relPath = filepath.Base(relPath)
+		}
+
+		if util.MatchGlobPath(rule.pattern, relPath) {
+			ignored = !rule.negate
+		}
+	}
+
+	return ignored
+}
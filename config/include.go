@@ -15,6 +15,7 @@ import (
 
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/shell"
 	"github.com/gruntwork-io/terragrunt/util"
 )
 
@@ -34,6 +35,10 @@ func parseIncludedConfig(ctx *ParsingContext, includedConfig *IncludeConfig) (*T
 		includePath = util.JoinPath(filepath.Dir(ctx.TerragruntOptions.TerragruntConfigPath), includePath)
 	}
 
+	if err := checkIncludeInRepo(ctx, includePath); err != nil {
+		return nil, err
+	}
+
 	// These condition are here to specifically handle the `run-all` command. During any `run-all` call, terragrunt
 	// needs to first build up the dependency graph to know what order to process the modules in. We want to limit users
 	// from creating a dependency between the dependency path for graph generation, and a module output. This is because
@@ -95,6 +100,35 @@ func parseIncludedConfig(ctx *ParsingContext, includedConfig *IncludeConfig) (*T
 	return ParseConfigFile(ctx, includePath, includedConfig)
 }
 
+// checkIncludeInRepo returns an error if the given included path is outside the git repository of the current config,
+// when the includes are restricted to the repository with the --terragrunt-restrict-includes-to-repo flag.
+func checkIncludeInRepo(ctx *ParsingContext, includePath string) error {
+	if !ctx.TerragruntOptions.RestrictIncludesToRepo {
+		return nil
+	}
+
+	repoRoot, err := shell.GitTopLevelDir(ctx, ctx.TerragruntOptions, filepath.Dir(ctx.TerragruntOptions.TerragruntConfigPath))
+	if err != nil {
+		return err
+	}
+
+	// git resolves the symlinks of the repository path, so we do the same for the included path.
+	resolvedIncludePath, err := filepath.EvalSymlinks(includePath)
+	if err != nil {
+		resolvedIncludePath = includePath
+	}
+
+	if !util.HasPathPrefix(resolvedIncludePath, repoRoot) {
+		return errors.New(IncludeOutsideRepoError{
+			ConfigPath:  ctx.TerragruntOptions.TerragruntConfigPath,
+			IncludePath: includePath,
+			RepoRoot:    repoRoot,
+		})
+	}
+
+	return nil
+}
+
 // handleInclude merges the included config into the current config depending on the merge strategy specified by the
 // user.
 func handleInclude(ctx *ParsingContext, config *TerragruntConfig, isPartial bool) (*TerragruntConfig, error) {
@@ -291,6 +325,14 @@ func (cfg *TerragruntConfig) Merge(sourceConfig *TerragruntConfig, terragruntOpt
 				cfg.Terraform.CopyTerraformLockFile = sourceConfig.Terraform.CopyTerraformLockFile
 			}
 
+			if sourceConfig.Terraform.Workspace != nil {
+				cfg.Terraform.Workspace = sourceConfig.Terraform.Workspace
+			}
+
+			if sourceConfig.Terraform.CreateWorkspace != nil {
+				cfg.Terraform.CreateWorkspace = sourceConfig.Terraform.CreateWorkspace
+			}
+
 			mergeExtraArgs(terragruntOptions, sourceConfig.Terraform.ExtraArgs, &cfg.Terraform.ExtraArgs)
 
 			mergeHooks(terragruntOptions, sourceConfig.Terraform.BeforeHooks, &cfg.Terraform.BeforeHooks)
@@ -315,7 +357,19 @@ func (cfg *TerragruntConfig) Merge(sourceConfig *TerragruntConfig, terragruntOpt
 	}
 
 	if sourceConfig.RetryableErrors != nil {
-		cfg.RetryableErrors = sourceConfig.RetryableErrors
+		if sourceConfig.RetryableErrorsMerge == RetryableErrorsMergeAppend {
+			cfg.RetryableErrors = append(cfg.RetryableErrors, sourceConfig.RetryableErrors...)
+		} else {
+			cfg.RetryableErrors = sourceConfig.RetryableErrors
+		}
+	}
+
+	if sourceConfig.RetryableErrorsMerge != "" {
+		cfg.RetryableErrorsMerge = sourceConfig.RetryableErrorsMerge
+	}
+
+	if sourceConfig.GlobalExtraArgs != nil {
+		cfg.GlobalExtraArgs = sourceConfig.GlobalExtraArgs
 	}
 
 	// Merge the generate configs. This is a shallow merge. Meaning, if the child has the same name generate block, then the
@@ -475,7 +529,24 @@ func (cfg *TerragruntConfig) DeepMerge(sourceConfig *TerragruntConfig, terragrun
 	cfg.FeatureFlags = mergedFlags
 
 	if sourceConfig.RetryableErrors != nil {
-		cfg.RetryableErrors = append(cfg.RetryableErrors, sourceConfig.RetryableErrors...)
+		if sourceConfig.RetryableErrorsMerge == RetryableErrorsMergeReplace {
+			cfg.RetryableErrors = sourceConfig.RetryableErrors
+		} else {
+			cfg.RetryableErrors = append(cfg.RetryableErrors, sourceConfig.RetryableErrors...)
+		}
+	}
+
+	if sourceConfig.RetryableErrorsMerge != "" {
+		cfg.RetryableErrorsMerge = sourceConfig.RetryableErrorsMerge
+	}
+
+	// Deep merge the global extra arguments by appending the child arguments to the parent ones for each command.
+	for cmd, args := range sourceConfig.GlobalExtraArgs {
+		if cfg.GlobalExtraArgs == nil {
+			cfg.GlobalExtraArgs = map[string][]string{}
+		}
+
+		cfg.GlobalExtraArgs[cmd] = append(cfg.GlobalExtraArgs[cmd], args...)
 	}
 
 	// Handle complex structs by recursively merging the structs together
@@ -491,6 +562,14 @@ func (cfg *TerragruntConfig) DeepMerge(sourceConfig *TerragruntConfig, terragrun
 				cfg.Terraform.CopyTerraformLockFile = sourceConfig.Terraform.CopyTerraformLockFile
 			}
 
+			if sourceConfig.Terraform.Workspace != nil {
+				cfg.Terraform.Workspace = sourceConfig.Terraform.Workspace
+			}
+
+			if sourceConfig.Terraform.CreateWorkspace != nil {
+				cfg.Terraform.CreateWorkspace = sourceConfig.Terraform.CreateWorkspace
+			}
+
 			if sourceConfig.Terraform.IncludeInCopy != nil {
 				srcList := *sourceConfig.Terraform.IncludeInCopy
 
@@ -1016,3 +1095,54 @@ type IncludeIsNotABlockErr struct {
 func (err IncludeIsNotABlockErr) Error() string {
 	return fmt.Sprintf("Parsed include is not a block: %v", err.parsed)
 }
+
+// ParseIncludes returns the include blocks of the given config, in the order they are declared, with their paths
+// resolved to absolute paths. The included configs are not parsed.
+func ParseIncludes(ctx *ParsingContext, configPath string) (IncludeConfigs, error) {
+	file, err := hclparse.NewParser(ctx.ParserOptions...).ParseFromFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	baseBlocks, err := DecodeBaseBlocks(ctx.WithTrackInclude(nil), file, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if baseBlocks.TrackInclude == nil {
+		return nil, nil
+	}
+
+	includes := make(IncludeConfigs, 0, len(baseBlocks.TrackInclude.CurrentList))
+
+	for _, include := range baseBlocks.TrackInclude.CurrentList {
+		if !filepath.IsAbs(include.Path) {
+			include.Path = util.JoinPath(filepath.Dir(configPath), include.Path)
+		}
+
+		include.Path = util.CleanPath(include.Path)
+		includes = append(includes, include)
+	}
+
+	return includes, nil
+}
+
+// MergeOrder returns the includes in the order they are merged into the config, see handleInclude: the includes are
+// merged bottom up, so that the last include overrides the previous ones. The includes that are not merged, with the
+// `no_merge` strategy, are left out.
+func (includes IncludeConfigs) MergeOrder() (IncludeConfigs, error) {
+	var merged IncludeConfigs
+
+	for i := len(includes) - 1; i >= 0; i-- {
+		mergeStrategy, err := includes[i].GetMergeStrategy()
+		if err != nil {
+			return nil, err
+		}
+
+		if mergeStrategy != NoMerge {
+			merged = append(merged, includes[i])
+		}
+	}
+
+	return merged, nil
+}
@@ -152,6 +152,21 @@ func TestMergeConfigIntoIncludedConfig(t *testing.T) {
 			&config.TerragruntConfig{Terraform: &config.TerraformConfig{IncludeInCopy: &[]string{"abc"}}},
 			&config.TerragruntConfig{Terraform: &config.TerraformConfig{CopyTerraformLockFile: &[]bool{false}[0], IncludeInCopy: &[]string{"abc"}}},
 		},
+		{
+			&config.TerragruntConfig{},
+			&config.TerragruntConfig{RetryableErrors: []string{"parent"}},
+			&config.TerragruntConfig{RetryableErrors: []string{"parent"}},
+		},
+		{
+			&config.TerragruntConfig{RetryableErrors: []string{"child"}},
+			&config.TerragruntConfig{RetryableErrors: []string{"parent"}},
+			&config.TerragruntConfig{RetryableErrors: []string{"child"}},
+		},
+		{
+			&config.TerragruntConfig{RetryableErrors: []string{"child"}, RetryableErrorsMerge: config.RetryableErrorsMergeAppend},
+			&config.TerragruntConfig{RetryableErrors: []string{"parent"}},
+			&config.TerragruntConfig{RetryableErrors: []string{"parent", "child"}, RetryableErrorsMerge: config.RetryableErrorsMergeAppend},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -311,6 +326,12 @@ func TestDeepMergeConfigIntoIncludedConfig(t *testing.T) {
 			&config.TerragruntConfig{RetryableErrors: []string{"original", "error"}},
 			&config.TerragruntConfig{RetryableErrors: []string{"original", "error", "error", "override"}},
 		},
+		{
+			"retryable errors replace",
+			&config.TerragruntConfig{RetryableErrors: []string{"override"}, RetryableErrorsMerge: config.RetryableErrorsMergeReplace},
+			&config.TerragruntConfig{RetryableErrors: []string{"original", "error"}},
+			&config.TerragruntConfig{RetryableErrors: []string{"override"}, RetryableErrorsMerge: config.RetryableErrorsMergeReplace},
+		},
 		// Deep merge inputs
 		{
 			"inputs",
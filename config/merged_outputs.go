@@ -0,0 +1,89 @@
+package config
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// MergedOutputs returns the outputs of the unit of the given options and of all the units it depends on through
+// `dependency` blocks, recursively, as an object keyed by the path of the unit relative to the working directory, e.g.:
+//
+//	{
+//	  "."       = { app_url = "https://app.example.com" }
+//	  "../vpc"  = { vpc_id = "vpc-123456" }
+//	}
+//
+// The outputs are read the same way as the outputs of `dependency` blocks, so a unit that has not been applied yet has
+// no outputs.
+func MergedOutputs(ctx context.Context, opts *options.TerragruntOptions) (cty.Value, error) {
+	configPath, err := filepath.Abs(opts.TerragruntConfigPath)
+	if err != nil {
+		return cty.NilVal, errors.New(err)
+	}
+
+	workingDir, err := filepath.Abs(opts.WorkingDir)
+	if err != nil {
+		return cty.NilVal, errors.New(err)
+	}
+
+	outputs := map[string]cty.Value{}
+
+	if err := collectMergedOutputs(NewParsingContext(ctx, opts), util.CleanPath(configPath), workingDir, outputs); err != nil {
+		return cty.NilVal, err
+	}
+
+	return cty.ObjectVal(outputs), nil
+}
+
+func collectMergedOutputs(ctx *ParsingContext, configPath, workingDir string, outputs map[string]cty.Value) error {
+	unitPath, err := util.GetPathRelativeTo(filepath.Dir(configPath), workingDir)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := outputs[unitPath]; ok {
+		return nil
+	}
+
+	// Mark the unit as visited before walking its dependencies, so that a unit shared by several dependencies is only
+	// read once.
+	outputs[unitPath] = cty.EmptyObjectVal
+
+	jsonBytes, err := getOutputJSONWithCaching(ctx, configPath, "")
+	if err != nil {
+		return err
+	}
+
+	unitOutputs, err := TerraformOutputJSONToCtyValueMap(configPath, jsonBytes)
+	if err != nil {
+		return err
+	}
+
+	outputs[unitPath] = cty.ObjectVal(unitOutputs)
+
+	dependencyPaths, err := getDependencyBlockConfigPathsByFilepath(ctx, configPath)
+	if err != nil {
+		return err
+	}
+
+	for _, dependencyPath := range dependencyPaths {
+		dependencyPath = getCleanedTargetConfigPath(dependencyPath, configPath, ctx.TerragruntOptions)
+
+		dependencyOpts, err := cloneTerragruntOptionsForDependency(ctx, dependencyPath)
+		if err != nil {
+			return err
+		}
+
+		if err := collectMergedOutputs(ctx.WithTerragruntOptions(dependencyOpts), dependencyPath, workingDir, outputs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
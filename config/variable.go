@@ -30,7 +30,7 @@ func ParseVariables(opts *options.TerragruntOptions, directoryPath string) ([]*P
 	walkWithSymlinks := experiment.Evaluate(opts.ExperimentMode)
 
 	// list all tf files
-	tfFiles, err := util.ListTfFiles(directoryPath, walkWithSymlinks)
+	tfFiles, err := util.ListTfFiles(opts.Logger, directoryPath, walkWithSymlinks)
 	if err != nil {
 		return nil, errors.New(err)
 	}
@@ -3,6 +3,7 @@ package configstack
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/gruntwork-io/terragrunt/util"
@@ -81,3 +82,37 @@ type DependencyNotFoundWhileCrossLinkingError struct {
 func (err DependencyNotFoundWhileCrossLinkingError) Error() string {
 	return fmt.Sprintf("Module %v specifies a dependency on module %v, but could not find that module while cross-linking dependencies. This is most likely a bug in Terragrunt. Please report it.", err.Module, err.Dependency)
 }
+
+// ProviderVersionConflict represents a provider pinned to different versions across the units of a stack.
+type ProviderVersionConflict struct {
+	// Provider is the provider source address, e.g. `hashicorp/aws`.
+	Provider string
+	// Units maps each version of the provider to the units pinning that version.
+	Units map[string][]string
+}
+
+func (conflict ProviderVersionConflict) String() string {
+	versions := make([]string, 0, len(conflict.Units))
+	for version := range conflict.Units {
+		versions = append(versions, version)
+	}
+
+	sort.Strings(versions)
+
+	for i, version := range versions {
+		versions[i] = fmt.Sprintf("%s (%s)", version, strings.Join(conflict.Units[version], ", "))
+	}
+
+	return conflict.Provider + ": " + strings.Join(versions, ", ")
+}
+
+type ProviderVersionConflictsError []ProviderVersionConflict
+
+func (err ProviderVersionConflictsError) Error() string {
+	conflicts := make([]string, 0, len(err))
+	for _, conflict := range err {
+		conflicts = append(conflicts, "- "+conflict.String())
+	}
+
+	return "Found providers pinned to different versions across units:\n" + strings.Join(conflicts, "\n")
+}
@@ -0,0 +1,154 @@
+package configstack
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+// EventsOutStdout is the value of --terragrunt-events-out to write the events to stdout.
+const EventsOutStdout = "-"
+
+// RunEventType is the type of a run event.
+type RunEventType string
+
+const (
+	// RunEventUnitStarted is emitted when a unit starts running, once all its dependencies are done.
+	RunEventUnitStarted RunEventType = "unit_started"
+	// RunEventUnitFinished is emitted when a unit finishes running successfully.
+	RunEventUnitFinished RunEventType = "unit_finished"
+	// RunEventUnitFailed is emitted when a unit finishes with an error. It is not preceded by a `unit_started`
+	// event if the unit has not been run because one of its dependencies failed.
+	RunEventUnitFailed RunEventType = "unit_failed"
+)
+
+// RunEvent is an event of the lifecycle of a unit run as part of a stack run, written as a JSON line:
+//
+//	{"type":"unit_finished","time":"2024-01-01T00:00:00Z","command":"apply","unit":"vpc","duration_seconds":12.5}
+type RunEvent struct {
+	// Type is the type of the event.
+	Type RunEventType `json:"type"`
+	// Time is the time the event occurred.
+	Time time.Time `json:"time"`
+	// Command is the terraform command run in the units, e.g. `apply`.
+	Command string `json:"command"`
+	// Unit is the path of the unit, relative to the working directory.
+	Unit string `json:"unit"`
+	// DurationSeconds is how long the unit took to run, set for the `unit_finished` and `unit_failed` events.
+	DurationSeconds *float64 `json:"duration_seconds,omitempty"`
+	// Error is the error the unit failed with, set for the `unit_failed` events.
+	Error string `json:"error,omitempty"`
+}
+
+// RunEvents writes the events of a stack run as newline-delimited JSON, one line per event, as they occur.
+// A nil RunEvents does nothing.
+type RunEvents struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	closer  io.Closer
+	command string
+	err     error
+}
+
+// NewRunEvents returns a new RunEvents instance writing the events of the run of the given terraform command to `out`.
+func NewRunEvents(out io.Writer, command string) *RunEvents {
+	return &RunEvents{
+		encoder: json.NewEncoder(out),
+		command: command,
+	}
+}
+
+// newRunEventsFromOptions returns a RunEvents instance writing to the destination given with --terragrunt-events-out,
+// either a file or stdout, or nil if the flag is not set. A relative path is relative to the working directory.
+func newRunEventsFromOptions(opts *options.TerragruntOptions) (*RunEvents, error) {
+	switch opts.EventsOut {
+	case "":
+		return nil, nil
+	case EventsOutStdout:
+		return NewRunEvents(opts.Writer, opts.TerraformCommand), nil
+	}
+
+	path := opts.EventsOut
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(opts.WorkingDir, path)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, errors.New(err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	opts.Logger.Debugf("Writing the events of the run to %s", path)
+
+	events := NewRunEvents(file, opts.TerraformCommand)
+	events.closer = file
+
+	return events, nil
+}
+
+// UnitStarted emits a `unit_started` event for the unit with the given name.
+func (events *RunEvents) UnitStarted(name string) {
+	events.emit(&RunEvent{Type: RunEventUnitStarted, Unit: name})
+}
+
+// UnitFinished emits a `unit_finished` event for the unit with the given name, or a `unit_failed` event if err is
+// not nil.
+func (events *RunEvents) UnitFinished(name string, duration time.Duration, err error) {
+	seconds := duration.Seconds()
+	event := &RunEvent{Type: RunEventUnitFinished, Unit: name, DurationSeconds: &seconds}
+
+	if err != nil {
+		event.Type = RunEventUnitFailed
+		event.Error = err.Error()
+	}
+
+	events.emit(event)
+}
+
+// Close closes the destination of the events, if it is a file, and returns the first error that occurred while
+// writing the events.
+func (events *RunEvents) Close() error {
+	if events == nil {
+		return nil
+	}
+
+	events.mu.Lock()
+	defer events.mu.Unlock()
+
+	if events.closer != nil {
+		if err := events.closer.Close(); err != nil && events.err == nil {
+			events.err = errors.New(err)
+		}
+
+		events.closer = nil
+	}
+
+	return events.err
+}
+
+func (events *RunEvents) emit(event *RunEvent) {
+	if events == nil {
+		return
+	}
+
+	events.mu.Lock()
+	defer events.mu.Unlock()
+
+	event.Time = time.Now().UTC()
+	event.Command = events.command
+
+	// Keep going if an event can't be written so the run is not interrupted, the error is returned by Close.
+	if err := events.encoder.Encode(event); err != nil && events.err == nil {
+		events.err = errors.New(err)
+	}
+}
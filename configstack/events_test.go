@@ -0,0 +1,134 @@
+package configstack_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunModulesWritesEvents(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		errB           error
+		expectedEvents []configstack.RunEvent
+	}{
+		{
+			name: "success",
+			expectedEvents: []configstack.RunEvent{
+				{Type: configstack.RunEventUnitStarted, Unit: "a"},
+				{Type: configstack.RunEventUnitFinished, Unit: "a"},
+				{Type: configstack.RunEventUnitStarted, Unit: "b"},
+				{Type: configstack.RunEventUnitFinished, Unit: "b"},
+				{Type: configstack.RunEventUnitStarted, Unit: "c"},
+				{Type: configstack.RunEventUnitFinished, Unit: "c"},
+			},
+		},
+		{
+			name: "failure",
+			errB: errors.New("Expected error for module b"),
+			expectedEvents: []configstack.RunEvent{
+				{Type: configstack.RunEventUnitStarted, Unit: "a"},
+				{Type: configstack.RunEventUnitFinished, Unit: "a"},
+				{Type: configstack.RunEventUnitStarted, Unit: "b"},
+				{Type: configstack.RunEventUnitFailed, Unit: "b"},
+				// c has not been run since its dependency failed
+				{Type: configstack.RunEventUnitFailed, Unit: "c"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			aRan := false
+			moduleA := &configstack.TerraformModule{
+				Stack:             &configstack.Stack{},
+				Path:              "a",
+				Dependencies:      configstack.TerraformModules{},
+				Config:            config.TerragruntConfig{},
+				TerragruntOptions: optionsWithMockTerragruntCommand(t, "a", nil, &aRan),
+			}
+
+			bRan := false
+			moduleB := &configstack.TerraformModule{
+				Stack:             &configstack.Stack{},
+				Path:              "b",
+				Dependencies:      configstack.TerraformModules{moduleA},
+				Config:            config.TerragruntConfig{},
+				TerragruntOptions: optionsWithMockTerragruntCommand(t, "b", tc.errB, &bRan),
+			}
+
+			cRan := false
+			moduleC := &configstack.TerraformModule{
+				Stack:             &configstack.Stack{},
+				Path:              "c",
+				Dependencies:      configstack.TerraformModules{moduleB},
+				Config:            config.TerragruntConfig{},
+				TerragruntOptions: optionsWithMockTerragruntCommand(t, "c", nil, &cRan),
+			}
+
+			eventsFile := filepath.Join(t.TempDir(), "events", "events.jsonl")
+
+			opts, err := options.NewTerragruntOptionsForTest("")
+			require.NoError(t, err)
+
+			opts.TerraformCommand = "apply"
+			opts.EventsOut = eventsFile
+
+			modules := configstack.TerraformModules{moduleA, moduleB, moduleC}
+			err = modules.RunModules(context.Background(), opts, options.DefaultParallelism)
+
+			if tc.errB != nil {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			file, err := os.Open(eventsFile)
+			require.NoError(t, err)
+
+			defer file.Close()
+
+			var events []configstack.RunEvent
+
+			scanner := bufio.NewScanner(file)
+			for scanner.Scan() {
+				var event configstack.RunEvent
+				require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+
+				assert.Equal(t, "apply", event.Command)
+				assert.False(t, event.Time.IsZero())
+
+				switch event.Type {
+				case configstack.RunEventUnitStarted:
+					assert.Nil(t, event.DurationSeconds)
+					assert.Empty(t, event.Error)
+				case configstack.RunEventUnitFinished:
+					assert.NotNil(t, event.DurationSeconds)
+					assert.Empty(t, event.Error)
+				case configstack.RunEventUnitFailed:
+					assert.NotNil(t, event.DurationSeconds)
+					assert.NotEmpty(t, event.Error)
+				}
+
+				events = append(events, configstack.RunEvent{Type: event.Type, Unit: event.Unit})
+			}
+
+			require.NoError(t, scanner.Err())
+			assert.Equal(t, tc.expectedEvents, events)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package configstack
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// InclusionReason describes why a module is included in the run queue.
+type InclusionReason string
+
+const (
+	// InclusionReasonDiscovered is the reason of the modules found in the working directory when no include flags are set.
+	InclusionReasonDiscovered InclusionReason = "discovered"
+	// InclusionReasonIncludeDir is the reason of the modules matching the --terragrunt-include-dir flag.
+	InclusionReasonIncludeDir InclusionReason = "include-dir"
+	// InclusionReasonIncludesConfig is the reason of the modules including one of the files given with the
+	// --terragrunt-modules-that-include flag.
+	InclusionReasonIncludesConfig InclusionReason = "includes-config"
+	// InclusionReasonReadsFile is the reason of the modules reading one of the files given with the
+	// --terragrunt-queue-include-units-reading flag.
+	InclusionReasonReadsFile InclusionReason = "reads-file"
+	// InclusionReasonDependency is the reason of the modules included because other included modules depend on them.
+	InclusionReasonDependency InclusionReason = "dependency"
+	// InclusionReasonExternalDependency is the reason of the modules outside the working directory that other modules
+	// depend on and that the user chose to run.
+	InclusionReasonExternalDependency InclusionReason = "external-dependency"
+)
+
+// ExecutionPlan is the structured representation of a stack run, listing the modules that are going to be run in
+// the order they run, together with their dependencies and why they are included.
+type ExecutionPlan struct {
+	Command string              `json:"command"`
+	Units   []ExecutionPlanUnit `json:"units"`
+}
+
+// ExecutionPlanUnit is a module in the execution plan. The modules of the same group run concurrently, once all the
+// modules of the previous groups are done.
+type ExecutionPlanUnit struct {
+	Path         string          `json:"path"`
+	Group        int             `json:"group"`
+	Dependencies []string        `json:"dependencies"`
+	Reason       InclusionReason `json:"reason"`
+}
+
+// ExecutionPlan returns the execution plan of the stack for the given command.
+// The paths of the modules are relative to the working directory.
+func (stack *Stack) ExecutionPlan(terraformCommand string) (*ExecutionPlan, error) {
+	runGraph, err := stack.GetModuleRunGraph(terraformCommand)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &ExecutionPlan{
+		Command: terraformCommand,
+		Units:   []ExecutionPlanUnit{},
+	}
+
+	for i, group := range runGraph {
+		for _, module := range group {
+			unit := ExecutionPlanUnit{
+				Path:         stack.relativePath(module.Path),
+				Group:        i + 1,
+				Dependencies: []string{},
+				Reason:       module.InclusionReason,
+			}
+
+			if unit.Reason == "" {
+				unit.Reason = InclusionReasonDiscovered
+			}
+
+			for _, dependency := range module.Dependencies {
+				if !dependency.FlagExcluded {
+					unit.Dependencies = append(unit.Dependencies, stack.relativePath(dependency.Path))
+				}
+			}
+
+			sort.Strings(unit.Dependencies)
+
+			plan.Units = append(plan.Units, unit)
+		}
+	}
+
+	return plan, nil
+}
+
+// JSONExecutionPlan returns the execution plan of the stack for the given command, rendered as JSON.
+func (stack *Stack) JSONExecutionPlan(terraformCommand string) (string, error) {
+	plan, err := stack.ExecutionPlan(terraformCommand)
+	if err != nil {
+		return "", err
+	}
+
+	j, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	return string(j), nil
+}
+
+func (stack *Stack) relativePath(path string) string {
+	if rel, err := filepath.Rel(stack.terragruntOptions.WorkingDir, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+
+	return path
+}
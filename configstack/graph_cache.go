@@ -0,0 +1,318 @@
+package configstack
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gruntwork-io/go-commons/collections"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/puzpuzpuz/xsync/v3"
+)
+
+// graphCache keeps the dependency graphs resolved by run-all, when `--terragrunt-graph-cache` is set, so that the next
+// run-all in the same process with the same options, feature flags and environment against an unchanged tree skips
+// parsing and crosslinking the units again.
+var graphCache = newStackGraphCache()
+
+// stackGraphCache is a cache of resolved dependency graphs, keyed by the hash of the discovered configuration files and
+// the options, feature flags and environment variables that change how the graph is resolved.
+type stackGraphCache struct {
+	mu      sync.Mutex
+	entries map[string]*stackGraphCacheEntry
+	// resolves is the number of graphs resolved because they were not cached, or their cached entry was stale.
+	resolves int
+}
+
+// stackGraphCacheEntry is a resolved dependency graph along with the stamps of all the configuration files it was
+// resolved from, including the included and the external dependencies configurations. Only the graph is cached, i.e. the
+// units, their dependencies and their parsed configuration, the options of the units are derived again from the options
+// of each run.
+type stackGraphCacheEntry struct {
+	modules TerraformModules
+	// configPaths are the paths of the configuration files of the units, by unit path.
+	configPaths map[string]string
+	// readFiles are the files read by the units while the graph was resolved, by file path.
+	readFiles *xsync.MapOf[string, []string]
+	files     map[string]fileStamp
+}
+
+// fileStamp is the modification time and size of a file, used to tell whether the file changed since the graph was cached.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func newStackGraphCache() *stackGraphCache {
+	return &stackGraphCache{
+		entries: make(map[string]*stackGraphCacheEntry),
+	}
+}
+
+// resolve returns the cached dependency graph for the given configuration files, if none of the files it was resolved
+// from changed since, otherwise resolves the graph with the given func and caches it.
+func (cache *stackGraphCache) resolve(stack *Stack, canonicalTerragruntConfigPaths []string, resolveFn func() (TerraformModules, error)) (TerraformModules, error) {
+	key, err := graphCacheKey(stack.terragruntOptions, canonicalTerragruntConfigPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if entry, ok := cache.entries[key]; ok && entry.isFresh() {
+		stack.terragruntOptions.Logger.Debugf("Reusing the cached dependency graph of %d unit(s) in %s", len(entry.modules), stack.terragruntOptions.WorkingDir)
+
+		// The units are not parsed again, so the files they read are recorded as when the graph was resolved.
+		stack.terragruntOptions.CloneReadFiles(entry.readFiles)
+
+		return entry.modules.withStackOptions(stack, entry.configPaths)
+	}
+
+	cache.resolves++
+
+	modules, err := resolveFn()
+	if err != nil {
+		return nil, err
+	}
+
+	// A graph resolved from a file that can't be stamped can't be validated later, so it is not cached.
+	files, err := statFiles(graphConfigPaths(canonicalTerragruntConfigPaths, modules))
+	if err != nil {
+		return modules, nil //nolint:nilerr
+	}
+
+	// The units are modified while they run, so a copy of the graph is cached rather than the units handed out.
+	graph, configPaths := modules.graphOnly()
+
+	readFiles := xsync.NewMapOf[string, []string]()
+	if stack.terragruntOptions.ReadFiles != nil {
+		stack.terragruntOptions.ReadFiles.Range(func(path string, units []string) bool {
+			readFiles.Store(path, append([]string(nil), units...))
+			return true
+		})
+	}
+
+	cache.entries[key] = &stackGraphCacheEntry{modules: graph, configPaths: configPaths, readFiles: readFiles, files: files}
+
+	return modules, nil
+}
+
+// graphOnly returns a copy of the given units, and their dependencies links, without the stack and the options they were
+// resolved with, along with the paths of the configuration files of the units.
+func (modules TerraformModules) graphOnly() (TerraformModules, map[string]string) {
+	configPaths := make(map[string]string, len(modules))
+
+	graph := modules.copyGraph()
+
+	for _, module := range graph {
+		configPaths[module.Path] = module.TerragruntOptions.TerragruntConfigPath
+
+		module.Stack = nil
+		module.TerragruntOptions = nil
+	}
+
+	return graph, configPaths
+}
+
+// withStackOptions returns a copy of the given units, and their dependencies links, belonging to the given stack, with
+// their options derived from the options of the stack as when the units are resolved.
+func (modules TerraformModules) withStackOptions(stack *Stack, configPaths map[string]string) (TerraformModules, error) {
+	result := modules.copyGraph()
+
+	for _, module := range result {
+		configPath := configPaths[module.Path]
+
+		opts, err := stack.cloneOptionsForModule(configPath)
+		if err != nil {
+			return nil, err
+		}
+
+		// the units excluded with --terragrunt-exclude-dir are not parsed, see resolveTerraformModule
+		if !collections.ListContainsElement(opts.ExcludeDirs, module.Path) {
+			if err := stack.setModuleSourceAndDownloadDir(opts, module.Path, configPath, &module.Config); err != nil {
+				return nil, err
+			}
+		}
+
+		module.Stack = stack
+		module.TerragruntOptions = opts
+	}
+
+	return result, nil
+}
+
+// copyGraph returns a copy of the given units, with the dependencies linking the copies.
+func (modules TerraformModules) copyGraph() TerraformModules {
+	clones := make(map[string]*TerraformModule, len(modules))
+
+	for _, module := range modules {
+		clone := *module
+		clones[module.Path] = &clone
+	}
+
+	result := make(TerraformModules, 0, len(modules))
+
+	for _, module := range modules {
+		clone := clones[module.Path]
+		clone.Dependencies = make(TerraformModules, 0, len(module.Dependencies))
+
+		for _, dependency := range module.Dependencies {
+			if dependencyClone, ok := clones[dependency.Path]; ok {
+				clone.Dependencies = append(clone.Dependencies, dependencyClone)
+			}
+		}
+
+		result = append(result, clone)
+	}
+
+	return result
+}
+
+// isFresh returns true if none of the configuration files the graph was resolved from changed since it was cached.
+func (entry *stackGraphCacheEntry) isFresh() bool {
+	for path, cached := range entry.files {
+		stamp, err := statFile(path)
+		if err != nil || !stamp.modTime.Equal(cached.modTime) || stamp.size != cached.size {
+			return false
+		}
+	}
+
+	return true
+}
+
+// graphCacheKey hashes the discovered configuration files, along with their stamps so that a changed file never hits a
+// stale entry, and the options that change how the dependency graph is resolved. The feature flags and the environment
+// variables are part of the key too, since the partial parse evaluates the `feature` blocks and `get_env()` calls the
+// dependencies, the Terraform source and the exclusion of the units may depend on.
+func graphCacheKey(opts *options.TerragruntOptions, canonicalTerragruntConfigPaths []string) (string, error) {
+	hash := sha256.New()
+
+	fmt.Fprintf(hash, "%s\n%s\n%v\n%v\n%v\n%v\n%v\n%v\n%v\n%v\n%v\n",
+		opts.WorkingDir,
+		opts.TerraformCommand,
+		opts.ExcludeDirs,
+		opts.IncludeDirs,
+		opts.ExcludeByDefault,
+		opts.StrictInclude,
+		opts.ModulesThatInclude,
+		opts.UnitsReading,
+		opts.IgnoreExternalDependencies,
+		opts.IncludeExternalDependencies,
+		opts.NonInteractive,
+	)
+
+	featureFlags := make(map[string]string)
+	if opts.FeatureFlags != nil {
+		opts.FeatureFlags.Range(func(name, value string) bool {
+			featureFlags[name] = value
+			return true
+		})
+	}
+
+	writeSortedPairs(hash, "feature", featureFlags)
+	writeSortedPairs(hash, "env", opts.Env)
+
+	paths := make([]string, len(canonicalTerragruntConfigPaths))
+	copy(paths, canonicalTerragruntConfigPaths)
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		stamp, err := statFile(path)
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprintf(hash, "%s %d %d\n", path, stamp.modTime.UnixNano(), stamp.size)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// writeSortedPairs writes the given key value pairs to the writer, sorted by key so that the output is stable.
+func writeSortedPairs(w io.Writer, kind string, pairs map[string]string) {
+	keys := make([]string, 0, len(pairs))
+	for key := range pairs {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s %q=%q\n", kind, key, pairs[key])
+	}
+}
+
+// graphConfigPaths returns the configuration files the given dependency graph was resolved from: the discovered ones,
+// the ones of the external dependencies and the included ones.
+func graphConfigPaths(canonicalTerragruntConfigPaths []string, modules TerraformModules) []string {
+	paths := make(map[string]struct{})
+
+	for _, path := range canonicalTerragruntConfigPaths {
+		paths[path] = struct{}{}
+	}
+
+	for _, module := range modules {
+		paths[module.TerragruntOptions.TerragruntConfigPath] = struct{}{}
+
+		for _, include := range module.Config.ProcessedIncludes {
+			paths[include.Path] = struct{}{}
+		}
+	}
+
+	result := make([]string, 0, len(paths))
+	for path := range paths {
+		result = append(result, path)
+	}
+
+	return result
+}
+
+func statFiles(paths []string) (map[string]fileStamp, error) {
+	stamps := make(map[string]fileStamp, len(paths))
+
+	for _, path := range paths {
+		stamp, err := statFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		stamps[path] = stamp
+	}
+
+	return stamps, nil
+}
+
+func statFile(path string) (fileStamp, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}, err
+	}
+
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}, nil
+}
+
+// resolveTerraformModules resolves the dependency graph of the given configuration files, reusing the one cached by a
+// previous run in the same process when `--terragrunt-graph-cache` is set.
+func (stack *Stack) resolveTerraformModules(ctx context.Context, terragruntConfigPaths []string) (TerraformModules, error) {
+	// The graph of a stack created for a child configuration depends on that configuration, which is not part of the key.
+	if !stack.terragruntOptions.GraphCache || stack.childTerragruntConfig != nil {
+		return stack.ResolveTerraformModules(ctx, terragruntConfigPaths)
+	}
+
+	canonicalTerragruntConfigPaths, err := util.CanonicalPaths(terragruntConfigPaths, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	return graphCache.resolve(stack, canonicalTerragruntConfigPaths, func() (TerraformModules, error) {
+		return stack.ResolveTerraformModules(ctx, terragruntConfigPaths)
+	})
+}
@@ -0,0 +1,250 @@
+package configstack
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphCacheReusesUnchangedGraph(t *testing.T) {
+	t.Parallel()
+
+	workingDir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	configs := map[string]string{
+		"vpc/terragrunt.hcl": `terraform {
+  source = "test"
+}
+`,
+		"app/terragrunt.hcl": `terraform {
+  source = "test"
+}
+
+dependencies {
+  paths = ["../vpc"]
+}
+`,
+	}
+
+	for path, contents := range configs {
+		path = filepath.Join(workingDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, os.WriteFile(path, []byte(contents), os.ModePerm))
+	}
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = workingDir
+	opts.GraphCache = true
+
+	resolves := func() int {
+		graphCache.mu.Lock()
+		defer graphCache.mu.Unlock()
+
+		return graphCache.resolves
+	}
+
+	findStack := func() *Stack {
+		stack, err := FindStackInSubfolders(context.Background(), opts)
+		require.NoError(t, err)
+		require.Len(t, stack.Modules, 2)
+
+		return stack
+	}
+
+	before := resolves()
+
+	first := findStack()
+	assert.Equal(t, before+1, resolves())
+
+	second := findStack()
+	assert.Equal(t, before+1, resolves(), "the unchanged graph must be reused")
+	assert.Equal(t, first.ListStackDependentModules(), second.ListStackDependentModules())
+
+	for _, module := range second.Modules {
+		assert.Same(t, second, module.Stack)
+	}
+
+	vpcConfigPath := filepath.Join(workingDir, "vpc", "terragrunt.hcl")
+	modTime := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(vpcConfigPath, modTime, modTime))
+
+	findStack()
+	assert.Equal(t, before+2, resolves(), "the graph must be resolved again once a config changed")
+
+	findStack()
+	assert.Equal(t, before+2, resolves())
+}
+
+func TestGraphCacheDerivesUnitOptionsFromEachRun(t *testing.T) {
+	t.Parallel()
+
+	workingDir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	configs := map[string]string{
+		"vpc/terragrunt.hcl": `terraform {
+  source = "test"
+}
+`,
+		"app/terragrunt.hcl": `terraform {
+  source = "test"
+}
+
+dependencies {
+  paths = ["../vpc"]
+}
+`,
+	}
+
+	for path, contents := range configs {
+		path = filepath.Join(workingDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, os.WriteFile(path, []byte(contents), os.ModePerm))
+	}
+
+	resolves := func() int {
+		graphCache.mu.Lock()
+		defer graphCache.mu.Unlock()
+
+		return graphCache.resolves
+	}
+
+	findStack := func(planFile string) *Stack {
+		opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+		require.NoError(t, err)
+
+		opts.WorkingDir = workingDir
+		opts.GraphCache = true
+		opts.TerraformCliArgs = []string{"plan", "-out=" + planFile}
+
+		stack, err := FindStackInSubfolders(context.Background(), opts)
+		require.NoError(t, err)
+		require.Len(t, stack.Modules, 2)
+
+		return stack
+	}
+
+	findStack("a")
+
+	before := resolves()
+
+	stack := findStack("b")
+	assert.Equal(t, before, resolves(), "the unchanged graph must be reused")
+
+	for _, module := range stack.Modules {
+		assert.Equal(t, []string{"plan", "-out=b"}, module.TerragruntOptions.TerraformCliArgs)
+		assert.Equal(t, filepath.Join(module.Path, "terragrunt.hcl"), module.TerragruntOptions.TerragruntConfigPath)
+		assert.Equal(t, filepath.Join(module.Path, util.TerragruntCacheDir), module.TerragruntOptions.DownloadDir)
+		assert.Same(t, stack, module.Stack)
+	}
+}
+
+func TestGraphCacheKeyedByFeatureFlagsAndEnv(t *testing.T) {
+	t.Parallel()
+
+	workingDir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	configs := map[string]string{
+		"vpc/terragrunt.hcl": `terraform {
+  source = "test"
+}
+`,
+		"app/terragrunt.hcl": `terraform {
+  source = "test"
+}
+
+feature "dep" {
+  default = false
+}
+
+dependencies {
+  paths = feature.dep.value || get_env("APP_DEPENDS_ON_VPC", "") == "true" ? ["../vpc"] : []
+}
+`,
+	}
+
+	for path, contents := range configs {
+		path = filepath.Join(workingDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, os.WriteFile(path, []byte(contents), os.ModePerm))
+	}
+
+	appDependencies := func(featureFlags, env map[string]string) int {
+		opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+		require.NoError(t, err)
+
+		opts.WorkingDir = workingDir
+		opts.GraphCache = true
+		opts.Env = env
+
+		for name, value := range featureFlags {
+			opts.FeatureFlags.Store(name, value)
+		}
+
+		stack, err := FindStackInSubfolders(context.Background(), opts)
+		require.NoError(t, err)
+
+		for _, module := range stack.Modules {
+			if module.Path == filepath.Join(workingDir, "app") {
+				return len(module.Dependencies)
+			}
+		}
+
+		require.FailNow(t, "the app unit is missing from the stack")
+
+		return 0
+	}
+
+	assert.Equal(t, 0, appDependencies(nil, nil))
+	assert.Equal(t, 1, appDependencies(map[string]string{"dep": "true"}, nil))
+	assert.Equal(t, 0, appDependencies(map[string]string{"dep": "false"}, nil))
+	assert.Equal(t, 1, appDependencies(nil, map[string]string{"APP_DEPENDS_ON_VPC": "true"}))
+	assert.Equal(t, 0, appDependencies(nil, nil))
+}
+
+func TestGraphCacheKeepsReadFiles(t *testing.T) {
+	t.Parallel()
+
+	workingDir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	dataPath := filepath.Join(workingDir, "data.txt")
+	require.NoError(t, os.WriteFile(dataPath, []byte("data"), os.ModePerm))
+
+	appDir := filepath.Join(workingDir, "app")
+	require.NoError(t, os.MkdirAll(appDir, os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(appDir, "terragrunt.hcl"), []byte(`terraform {
+  source = "test"
+}
+
+locals {
+  data = mark_as_read("../data.txt")
+}
+`), os.ModePerm))
+
+	for range 2 {
+		opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+		require.NoError(t, err)
+
+		opts.WorkingDir = workingDir
+		opts.GraphCache = true
+
+		_, err = FindStackInSubfolders(context.Background(), opts)
+		require.NoError(t, err)
+
+		units, ok := opts.ReadFiles.Load(dataPath)
+		require.True(t, ok, "the read file must be recorded")
+		assert.Equal(t, []string{appDir}, units)
+	}
+}
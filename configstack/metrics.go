@@ -0,0 +1,185 @@
+package configstack
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+const (
+	metricsResultSucceeded = "succeeded"
+	metricsResultFailed    = "failed"
+)
+
+// RunMetrics are the metrics of a stack run, written in the Prometheus text exposition format so that they can be
+// collected by the node_exporter textfile collector.
+type RunMetrics struct {
+	// Command is the terraform command run in the units, e.g. `apply`.
+	Command string
+	// Duration is the duration of the whole run.
+	Duration time.Duration
+	// FinishedAt is the time the run finished.
+	FinishedAt time.Time
+	// Units are the metrics of the units of the run.
+	Units []UnitMetrics
+}
+
+// UnitMetrics are the metrics of a unit run as part of a stack run.
+type UnitMetrics struct {
+	// Path is the path of the unit, relative to the working directory.
+	Path string
+	// Duration is how long the unit took to run, zero if it has not been run, e.g. because a dependency failed.
+	Duration time.Duration
+	// Failed is set if the unit, or one of its dependencies, finished with an error.
+	Failed bool
+}
+
+// Write writes the metrics in the Prometheus text format:
+//
+//	terragrunt_unit_duration_seconds{command="apply",unit="vpc"} 12.5
+//	terragrunt_unit_success{command="apply",unit="vpc"} 1
+//	terragrunt_units{command="apply",result="succeeded"} 1
+//	terragrunt_units{command="apply",result="failed"} 0
+//	terragrunt_run_duration_seconds{command="apply"} 12.6
+//	terragrunt_run_timestamp_seconds{command="apply"} 1700000000
+func (metrics *RunMetrics) Write(w io.Writer) error {
+	units := make([]UnitMetrics, len(metrics.Units))
+	copy(units, metrics.Units)
+
+	sort.Slice(units, func(i, j int) bool {
+		return units[i].Path < units[j].Path
+	})
+
+	command := `command="` + escapeMetricsLabel(metrics.Command) + `"`
+	results := map[string]int{metricsResultSucceeded: 0, metricsResultFailed: 0}
+
+	var sb strings.Builder
+
+	writeMetricsHeader(&sb, "terragrunt_unit_duration_seconds", "Duration of the run of the unit in seconds.")
+
+	for _, unit := range units {
+		fmt.Fprintf(&sb, "terragrunt_unit_duration_seconds{%s,unit=\"%s\"} %s\n", command, escapeMetricsLabel(unit.Path), formatMetricsValue(unit.Duration.Seconds()))
+	}
+
+	writeMetricsHeader(&sb, "terragrunt_unit_success", "Whether the run of the unit succeeded (1) or failed (0).")
+
+	for _, unit := range units {
+		success := 1
+
+		if unit.Failed {
+			success = 0
+			results[metricsResultFailed]++
+		} else {
+			results[metricsResultSucceeded]++
+		}
+
+		fmt.Fprintf(&sb, "terragrunt_unit_success{%s,unit=\"%s\"} %d\n", command, escapeMetricsLabel(unit.Path), success)
+	}
+
+	writeMetricsHeader(&sb, "terragrunt_units", "Number of units run, by result.")
+
+	for _, result := range []string{metricsResultSucceeded, metricsResultFailed} {
+		fmt.Fprintf(&sb, "terragrunt_units{%s,result=\"%s\"} %d\n", command, result, results[result])
+	}
+
+	writeMetricsHeader(&sb, "terragrunt_run_duration_seconds", "Duration of the run in seconds.")
+	fmt.Fprintf(&sb, "terragrunt_run_duration_seconds{%s} %s\n", command, formatMetricsValue(metrics.Duration.Seconds()))
+
+	writeMetricsHeader(&sb, "terragrunt_run_timestamp_seconds", "Unix time the run finished.")
+	fmt.Fprintf(&sb, "terragrunt_run_timestamp_seconds{%s} %d\n", command, metrics.FinishedAt.Unix())
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// WriteFile writes the metrics to the given file. The file is replaced atomically, so that a collector never reads
+// partially written metrics.
+func (metrics *RunMetrics) WriteFile(path string) error {
+	dir := filepath.Dir(path)
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return errors.New(err)
+	}
+
+	// The textfile collector only reads `*.prom` files, so the temporary file is ignored until it is renamed.
+	file, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return errors.New(err)
+	}
+	defer os.Remove(file.Name()) //nolint:errcheck
+
+	if err := metrics.Write(file); err != nil {
+		file.Close() //nolint:errcheck
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return errors.New(err)
+	}
+
+	if err := os.Chmod(file.Name(), 0644); err != nil { //nolint:mnd
+		return errors.New(err)
+	}
+
+	if err := os.Rename(file.Name(), path); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// metrics returns the metrics of the run of the modules.
+func (modules RunningModules) metrics(opts *options.TerragruntOptions, duration time.Duration) *RunMetrics {
+	metrics := &RunMetrics{
+		Command:    opts.TerraformCommand,
+		Duration:   duration,
+		FinishedAt: time.Now(),
+	}
+
+	for _, module := range modules {
+		metrics.Units = append(metrics.Units, UnitMetrics{
+			Path:     module.displayName(opts),
+			Duration: module.Duration,
+			Failed:   module.Err != nil,
+		})
+	}
+
+	return metrics
+}
+
+// writeMetricsFile writes the metrics of the run of the modules to the file given with --terragrunt-metrics-file.
+// A relative path is relative to the working directory.
+func (modules RunningModules) writeMetricsFile(opts *options.TerragruntOptions, duration time.Duration) error {
+	path := opts.MetricsFile
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(opts.WorkingDir, path)
+	}
+
+	opts.Logger.Debugf("Writing the metrics of the run to %s", path)
+
+	return modules.metrics(opts, duration).WriteFile(path)
+}
+
+func writeMetricsHeader(sb *strings.Builder, name, help string) {
+	fmt.Fprintf(sb, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+}
+
+func formatMetricsValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// escapeMetricsLabel escapes a label value as required by the Prometheus text format.
+func escapeMetricsLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,120 @@
+package configstack_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunMetricsWrite(t *testing.T) {
+	t.Parallel()
+
+	metrics := &configstack.RunMetrics{
+		Command:    "apply",
+		Duration:   4500 * time.Millisecond,
+		FinishedAt: time.Unix(1700000000, 0),
+		Units: []configstack.UnitMetrics{
+			{Path: "vpc", Duration: 2 * time.Second},
+			{Path: `app/"blue"`, Duration: 1250 * time.Millisecond, Failed: true},
+		},
+	}
+
+	var out bytes.Buffer
+
+	require.NoError(t, metrics.Write(&out))
+
+	expected := `# HELP terragrunt_unit_duration_seconds Duration of the run of the unit in seconds.
+# TYPE terragrunt_unit_duration_seconds gauge
+terragrunt_unit_duration_seconds{command="apply",unit="app/\"blue\""} 1.25
+terragrunt_unit_duration_seconds{command="apply",unit="vpc"} 2
+# HELP terragrunt_unit_success Whether the run of the unit succeeded (1) or failed (0).
+# TYPE terragrunt_unit_success gauge
+terragrunt_unit_success{command="apply",unit="app/\"blue\""} 0
+terragrunt_unit_success{command="apply",unit="vpc"} 1
+# HELP terragrunt_units Number of units run, by result.
+# TYPE terragrunt_units gauge
+terragrunt_units{command="apply",result="succeeded"} 1
+terragrunt_units{command="apply",result="failed"} 1
+# HELP terragrunt_run_duration_seconds Duration of the run in seconds.
+# TYPE terragrunt_run_duration_seconds gauge
+terragrunt_run_duration_seconds{command="apply"} 4.5
+# HELP terragrunt_run_timestamp_seconds Unix time the run finished.
+# TYPE terragrunt_run_timestamp_seconds gauge
+terragrunt_run_timestamp_seconds{command="apply"} 1700000000
+`
+	assert.Equal(t, expected, out.String())
+}
+
+func TestRunModulesWritesMetricsFile(t *testing.T) {
+	t.Parallel()
+
+	aRan := false
+	moduleA := &configstack.TerraformModule{
+		Stack:             &configstack.Stack{},
+		Path:              "a",
+		Dependencies:      configstack.TerraformModules{},
+		Config:            config.TerragruntConfig{},
+		TerragruntOptions: optionsWithMockTerragruntCommand(t, "a", nil, &aRan),
+	}
+
+	bRan := false
+	moduleB := &configstack.TerraformModule{
+		Stack:             &configstack.Stack{},
+		Path:              "b",
+		Dependencies:      configstack.TerraformModules{moduleA},
+		Config:            config.TerragruntConfig{},
+		TerragruntOptions: optionsWithMockTerragruntCommand(t, "b", errors.New("Expected error for module b"), &bRan),
+	}
+
+	cRan := false
+	moduleC := &configstack.TerraformModule{
+		Stack:             &configstack.Stack{},
+		Path:              "c",
+		Dependencies:      configstack.TerraformModules{moduleB},
+		Config:            config.TerragruntConfig{},
+		TerragruntOptions: optionsWithMockTerragruntCommand(t, "c", nil, &cRan),
+	}
+
+	metricsFile := filepath.Join(t.TempDir(), "metrics", "terragrunt.prom")
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	opts.TerraformCommand = "apply"
+	opts.MetricsFile = metricsFile
+
+	modules := configstack.TerraformModules{moduleA, moduleB, moduleC}
+	err = modules.RunModules(context.Background(), opts, options.DefaultParallelism)
+	require.Error(t, err)
+
+	metrics, err := os.ReadFile(metricsFile)
+	require.NoError(t, err)
+
+	assert.Regexp(t, `(?m)^terragrunt_unit_duration_seconds\{command="apply",unit="a"\} [0-9.e-]+$`, string(metrics))
+	assert.Regexp(t, `(?m)^terragrunt_unit_duration_seconds\{command="apply",unit="b"\} [0-9.e-]+$`, string(metrics))
+	// c has not been run since its dependency failed
+	assert.Contains(t, string(metrics), `terragrunt_unit_duration_seconds{command="apply",unit="c"} 0`+"\n")
+
+	assert.Contains(t, string(metrics), `terragrunt_unit_success{command="apply",unit="a"} 1`+"\n")
+	assert.Contains(t, string(metrics), `terragrunt_unit_success{command="apply",unit="b"} 0`+"\n")
+	assert.Contains(t, string(metrics), `terragrunt_unit_success{command="apply",unit="c"} 0`+"\n")
+
+	assert.Contains(t, string(metrics), `terragrunt_units{command="apply",result="succeeded"} 1`+"\n")
+	assert.Contains(t, string(metrics), `terragrunt_units{command="apply",result="failed"} 2`+"\n")
+	assert.Regexp(t, `(?m)^terragrunt_run_duration_seconds\{command="apply"\} [0-9.e-]+$`, string(metrics))
+
+	// the temporary file is renamed to the metrics file
+	entries, err := os.ReadDir(filepath.Dir(metricsFile))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
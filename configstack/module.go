@@ -34,6 +34,8 @@ type TerraformModule struct {
 	TerragruntOptions    *options.TerragruntOptions
 	AssumeAlreadyApplied bool
 	FlagExcluded         bool
+	// InclusionReason is why the module is included in the run queue, empty if it was simply discovered.
+	InclusionReason InclusionReason
 }
 
 // String renders this module as a human-readable string
@@ -53,16 +55,20 @@ func (module *TerraformModule) MarshalJSON() ([]byte, error) {
 	return json.Marshal(module.Path)
 }
 
-// FlushOutput flushes buffer data to the output writer.
-func (module *TerraformModule) FlushOutput() error {
-	if writer, ok := module.TerragruntOptions.Writer.(*ModuleWriter); ok {
-		module.outputMu.Lock()
-		defer module.outputMu.Unlock()
+// FlushOutput flushes the buffered data of the module writer to the output writer.
+func (module *TerraformModule) FlushOutput(writer *ModuleWriter) error {
+	module.outputMu.Lock()
+	defer module.outputMu.Unlock()
 
-		return writer.Flush()
-	}
+	return writer.Flush()
+}
 
-	return nil
+// FlushGroupedOutput writes the grouped output of the module, making sure it is not mixed with the output of other modules.
+func (module *TerraformModule) FlushGroupedOutput(output *GroupedOutput) error {
+	module.outputMu.Lock()
+	defer module.outputMu.Unlock()
+
+	return output.Flush()
 }
 
 // Check for cycles using a depth-first-search as described here:
@@ -122,6 +128,15 @@ func (module *TerraformModule) outputJSONFile(opts *options.TerragruntOptions) s
 	return module.getPlanFilePath(opts, opts.JSONOutputFolder, terraform.TerraformPlanJSONFile)
 }
 
+// logFile - return the location of the file to store the module output in, if unit logs folder is set
+func (module *TerraformModule) logFile(opts *options.TerragruntOptions) string {
+	if opts.UnitLogsFolder == "" {
+		return ""
+	}
+
+	return module.getPlanFilePath(opts, opts.UnitLogsFolder, "") + ".log"
+}
+
 func (module *TerraformModule) getPlanFilePath(opts *options.TerragruntOptions, outputFolder, fileName string) string {
 	if outputFolder == "" {
 		return ""
@@ -419,6 +434,7 @@ func (modules TerraformModules) flagIncludedDirs(opts *options.TerragruntOptions
 	for _, module := range modules {
 		if module.findModuleInPath(opts.IncludeDirs) {
 			module.FlagExcluded = false
+			module.InclusionReason = InclusionReasonIncludeDir
 		} else {
 			module.FlagExcluded = true
 		}
@@ -429,6 +445,10 @@ func (modules TerraformModules) flagIncludedDirs(opts *options.TerragruntOptions
 		for _, module := range modules {
 			if !module.FlagExcluded {
 				for _, dependency := range module.Dependencies {
+					if dependency.FlagExcluded {
+						dependency.InclusionReason = InclusionReasonDependency
+					}
+
 					dependency.FlagExcluded = false
 				}
 			}
@@ -472,6 +492,7 @@ func (modules TerraformModules) flagUnitsThatAreIncluded(opts *options.Terragrun
 
 			if util.ListContainsElement(modulesThatIncludeCanonicalPaths, canonicalPath) {
 				module.FlagExcluded = false
+				module.InclusionReason = InclusionReasonIncludesConfig
 			}
 		}
 
@@ -490,6 +511,7 @@ func (modules TerraformModules) flagUnitsThatAreIncluded(opts *options.Terragrun
 
 				if util.ListContainsElement(modulesThatIncludeCanonicalPaths, canonicalPath) {
 					dependency.FlagExcluded = false
+					dependency.InclusionReason = InclusionReasonIncludesConfig
 				}
 			}
 		}
@@ -543,8 +565,14 @@ func (modules TerraformModules) flagUnitsThatRead(opts *options.TerragruntOption
 		}
 
 		for _, module := range modules {
-			if opts.DidReadFile(path, module.Path) {
+			didRead := opts.DidReadFile(path, module.Path)
+			if util.IsGlobPattern(readPath) {
+				didRead = opts.DidReadFileMatching(path, module.Path)
+			}
+
+			if didRead {
 				module.FlagExcluded = false
+				module.InclusionReason = InclusionReasonReadsFile
 			}
 		}
 	}
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/configstack"
@@ -1313,3 +1315,64 @@ func TestRunModulesReverseOrderMultipleModulesWithDependenciesLargeGraphPartialF
 	assert.True(t, eRan)
 	assert.True(t, fRan)
 }
+
+func TestRunModulesOutputOrder(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		order    options.OutputOrder
+		expected []string
+	}{
+		{options.OutputOrderPath, []string{"prod/web", "stage/app", "stage/db"}},
+		{options.OutputOrderName, []string{"stage/app", "stage/db", "prod/web"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.order), func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+
+			// The modules finish in the reverse order of their paths.
+			paths := []string{"stage/db", "stage/app", "prod/web"}
+			modules := configstack.TerraformModules{}
+
+			for i, path := range paths {
+				opts, err := options.NewTerragruntOptionsForTest(path)
+				require.NoError(t, err)
+
+				opts.Writer = &out
+				opts.OutputOrder = tc.order
+				opts.RunTerragrunt = func(_ context.Context, opts *options.TerragruntOptions) error {
+					time.Sleep(time.Duration(i) * 50 * time.Millisecond)
+
+					_, err := fmt.Fprintf(opts.Writer, "output of %s\n", path)
+
+					return err
+				}
+
+				modules = append(modules, &configstack.TerraformModule{
+					Stack:             &configstack.Stack{},
+					Path:              path,
+					Dependencies:      configstack.TerraformModules{},
+					Config:            config.TerragruntConfig{},
+					TerragruntOptions: opts,
+				})
+			}
+
+			opts, err := options.NewTerragruntOptionsForTest("")
+			require.NoError(t, err)
+
+			opts.OutputOrder = tc.order
+
+			require.NoError(t, modules.RunModules(context.Background(), opts, options.DefaultParallelism))
+
+			var expected strings.Builder
+			for _, path := range tc.expected {
+				fmt.Fprintf(&expected, "output of %s\n", path)
+			}
+
+			assert.Equal(t, expected.String(), out.String())
+		})
+	}
+}
@@ -2,8 +2,9 @@ package configstack
 
 import (
 	"bytes"
-	"fmt"
 	"io"
+	"os"
+	"sync"
 
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 )
@@ -12,21 +13,160 @@ import (
 // We should avoid outputting data directly to the output out,
 // since when modules run in parallel, the output data may be mixed with each other, thereby spoiling each other's results.
 type ModuleWriter struct {
-	buffer *bytes.Buffer
+	buffer *spillBuffer
 	out    io.Writer
 }
 
 // NewModuleWriter returns a new ModuleWriter instance.
 func NewModuleWriter(out io.Writer) *ModuleWriter {
 	return &ModuleWriter{
-		buffer: &bytes.Buffer{},
+		buffer: &spillBuffer{},
 		out:    out,
 	}
 }
 
+// WithSpill makes the writer move the buffered data to a temporary file in `dir` once it exceeds `threshold` bytes,
+// to bound the memory used by the output of the module. A threshold of 0 disables spilling, an empty dir means
+// the default directory for temporary files.
+func (writer *ModuleWriter) WithSpill(threshold int64, dir string) *ModuleWriter {
+	writer.buffer.threshold = threshold
+	writer.buffer.dir = dir
+
+	return writer
+}
+
+// Spilled returns true if the buffered data has been moved to a temporary file.
+func (writer *ModuleWriter) Spilled() bool {
+	return writer.buffer.spilled()
+}
+
 // Write appends the contents of p to the buffer.
 func (writer *ModuleWriter) Write(p []byte) (int, error) {
-	n, err := writer.buffer.Write(p)
+	return writer.buffer.Write(p)
+}
+
+// Flush flushes buffer data to the `out` writer.
+func (writer *ModuleWriter) Flush() error {
+	if _, err := io.Copy(writer.out, writer.buffer.section(0, writer.buffer.size)); err != nil {
+		writer.buffer.reset() //nolint:errcheck
+		return errors.New(err)
+	}
+
+	return writer.buffer.reset()
+}
+
+// GroupedOutput buffers the stdout and stderr data of a module, preserving the order in which it was written,
+// so that the whole module output can be written contiguously once the module finishes.
+type GroupedOutput struct {
+	mu     sync.Mutex
+	buffer spillBuffer
+	chunks []outputChunk
+}
+
+type outputChunk struct {
+	out    io.Writer
+	offset int64
+	size   int64
+}
+
+// NewGroupedOutput returns a new GroupedOutput instance.
+func NewGroupedOutput() *GroupedOutput {
+	return &GroupedOutput{}
+}
+
+// WithSpill makes the output move the buffered data to a temporary file in `dir` once it exceeds `threshold` bytes,
+// to bound the memory used by the output of the module. A threshold of 0 disables spilling, an empty dir means
+// the default directory for temporary files.
+func (output *GroupedOutput) WithSpill(threshold int64, dir string) *GroupedOutput {
+	output.buffer.threshold = threshold
+	output.buffer.dir = dir
+
+	return output
+}
+
+// Spilled returns true if the buffered data has been moved to a temporary file.
+func (output *GroupedOutput) Spilled() bool {
+	output.mu.Lock()
+	defer output.mu.Unlock()
+
+	return output.buffer.spilled()
+}
+
+// Writer returns a Writer that buffers data that should be eventually written to the `out` writer.
+func (output *GroupedOutput) Writer(out io.Writer) io.Writer {
+	return &groupedOutputWriter{output: output, out: out}
+}
+
+// Flush writes the buffered data to the original writers in the order it was written.
+func (output *GroupedOutput) Flush() error {
+	output.mu.Lock()
+	defer output.mu.Unlock()
+
+	chunks := output.chunks
+	output.chunks = nil
+
+	for _, chunk := range chunks {
+		if _, err := io.Copy(chunk.out, output.buffer.section(chunk.offset, chunk.size)); err != nil {
+			output.buffer.reset() //nolint:errcheck
+			return errors.New(err)
+		}
+	}
+
+	return output.buffer.reset()
+}
+
+type groupedOutputWriter struct {
+	output *GroupedOutput
+	out    io.Writer
+}
+
+// Write appends a copy of p to the grouped output.
+func (writer *groupedOutputWriter) Write(p []byte) (int, error) {
+	writer.output.mu.Lock()
+	defer writer.output.mu.Unlock()
+
+	offset := writer.output.buffer.size
+
+	n, err := writer.output.buffer.Write(p)
+	if err != nil {
+		return n, err
+	}
+
+	writer.output.chunks = append(writer.output.chunks, outputChunk{out: writer.out, offset: offset, size: int64(n)})
+
+	return n, nil
+}
+
+// spillBuffer buffers data in memory until it exceeds the threshold, then moves all the data to a temporary file and
+// appends the subsequent data to it. A threshold of 0 disables spilling.
+type spillBuffer struct {
+	threshold int64
+	dir       string
+	memory    bytes.Buffer
+	file      *os.File
+	size      int64
+}
+
+func (buffer *spillBuffer) Write(p []byte) (int, error) {
+	if buffer.file == nil && buffer.threshold > 0 && buffer.size+int64(len(p)) > buffer.threshold {
+		if err := buffer.spill(); err != nil {
+			return 0, err
+		}
+	}
+
+	var (
+		n   int
+		err error
+	)
+
+	if buffer.file != nil {
+		n, err = buffer.file.Write(p)
+	} else {
+		n, err = buffer.memory.Write(p)
+	}
+
+	buffer.size += int64(n)
+
 	if err != nil {
 		return n, errors.New(err)
 	}
@@ -34,13 +174,56 @@ func (writer *ModuleWriter) Write(p []byte) (int, error) {
 	return n, nil
 }
 
-// Flush flushes buffer data to the `out` writer.
-func (writer *ModuleWriter) Flush() error {
-	if _, err := fmt.Fprint(writer.out, writer.buffer); err != nil {
+func (buffer *spillBuffer) spill() error {
+	file, err := os.CreateTemp(buffer.dir, "terragrunt-output-*")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	buffer.file = file
+
+	if _, err := buffer.memory.WriteTo(file); err != nil {
+		return errors.New(err)
+	}
+
+	// Release the memory, the data is on disk now.
+	buffer.memory = bytes.Buffer{}
+
+	return nil
+}
+
+func (buffer *spillBuffer) spilled() bool {
+	return buffer.file != nil
+}
+
+// section returns a reader of `size` bytes of the buffered data starting at `offset`.
+func (buffer *spillBuffer) section(offset, size int64) io.Reader {
+	if buffer.file != nil {
+		return io.NewSectionReader(buffer.file, offset, size)
+	}
+
+	return bytes.NewReader(buffer.memory.Bytes()[offset : offset+size])
+}
+
+// reset discards the buffered data and removes the temporary file, if any.
+func (buffer *spillBuffer) reset() error {
+	buffer.memory.Reset()
+	buffer.size = 0
+
+	if buffer.file == nil {
+		return nil
+	}
+
+	file := buffer.file
+	buffer.file = nil
+
+	if err := file.Close(); err != nil {
 		return errors.New(err)
 	}
 
-	writer.buffer.Reset()
+	if err := os.Remove(file.Name()); err != nil {
+		return errors.New(err)
+	}
 
 	return nil
 }
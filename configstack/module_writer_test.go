@@ -0,0 +1,131 @@
+package configstack_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSpillThreshold = 64 * 1024
+	testSpillLines     = 20000
+)
+
+func TestModuleWriterSpill(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		threshold     int64
+		expectedSpill bool
+	}{
+		{
+			name:          "below threshold",
+			threshold:     100 * testSpillThreshold,
+			expectedSpill: false,
+		},
+		{
+			name:          "above threshold",
+			threshold:     testSpillThreshold,
+			expectedSpill: true,
+		},
+		{
+			name:          "disabled",
+			threshold:     0,
+			expectedSpill: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			spillDir := t.TempDir()
+
+			var out, expected bytes.Buffer
+
+			writer := configstack.NewModuleWriter(&out).WithSpill(tc.threshold, spillDir)
+
+			for i := range testSpillLines {
+				line := fmt.Sprintf("# module.resource[%d] will be created\n", i)
+				expected.WriteString(line)
+
+				_, err := writer.Write([]byte(line))
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expectedSpill, writer.Spilled())
+			assert.Empty(t, out.String(), "the output must not be written before the flush")
+
+			entries, err := os.ReadDir(spillDir)
+			require.NoError(t, err)
+
+			if tc.expectedSpill {
+				assert.Len(t, entries, 1)
+			} else {
+				assert.Empty(t, entries)
+			}
+
+			require.NoError(t, writer.Flush())
+			assert.Equal(t, expected.String(), out.String())
+
+			// the temporary file is removed once flushed
+			entries, err = os.ReadDir(spillDir)
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+		})
+	}
+}
+
+func TestGroupedOutputSpill(t *testing.T) {
+	t.Parallel()
+
+	spillDir := t.TempDir()
+
+	var (
+		stdout, stderr, combined                    bytes.Buffer
+		expectedStdout, expectedStderr, expectedAll bytes.Buffer
+	)
+
+	output := configstack.NewGroupedOutput().WithSpill(testSpillThreshold, spillDir)
+	stdoutWriter := output.Writer(&stdout)
+	stderrWriter := output.Writer(&stderr)
+	combinedStdoutWriter := output.Writer(&combined)
+
+	for i := range testSpillLines {
+		line := fmt.Sprintf("# module.resource[%d] will be created\n", i)
+		expectedStdout.WriteString(line)
+		expectedAll.WriteString(line)
+
+		_, err := stdoutWriter.Write([]byte(line))
+		require.NoError(t, err)
+
+		_, err = combinedStdoutWriter.Write([]byte(line))
+		require.NoError(t, err)
+
+		if i%100 == 0 {
+			logLine := fmt.Sprintf("DEBUG resource %d\n", i)
+			expectedStderr.WriteString(logLine)
+
+			_, err = stderrWriter.Write([]byte(logLine))
+			require.NoError(t, err)
+		}
+	}
+
+	assert.True(t, output.Spilled())
+
+	require.NoError(t, output.Flush())
+	assert.Equal(t, expectedStdout.String(), stdout.String())
+	assert.Equal(t, expectedStderr.String(), stderr.String())
+	assert.Equal(t, expectedAll.String(), combined.String())
+
+	// the temporary file is removed once flushed
+	entries, err := os.ReadDir(spillDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,187 @@
+package configstack
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/term"
+
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+const (
+	// clearLine moves the cursor to the beginning of the line and erases the line.
+	clearLine = "\r\033[K"
+
+	// maxProgressRunningModules is the max number of running modules listed in the progress line.
+	maxProgressRunningModules = 3
+)
+
+// Progress renders a single line with the progress of a stack run, updating it in place.
+// All the output of the modules must be written through the writers returned by `Writer`,
+// so that the progress line is cleared before the output is written and rendered again afterwards.
+// A nil Progress does nothing.
+type Progress struct {
+	mu        sync.Mutex
+	out       io.Writer
+	total     int
+	completed int
+	failed    int
+	running   []string
+	rendered  bool
+	done      bool
+}
+
+// NewProgress returns a new Progress instance rendering the progress of `total` modules to the `out` writer.
+func NewProgress(out io.Writer, total int) *Progress {
+	return &Progress{
+		out:   out,
+		total: total,
+	}
+}
+
+// newTerminalProgress returns a Progress instance if the stderr of Terragrunt is a terminal and logging is enabled,
+// otherwise returns nil.
+func newTerminalProgress(opts *options.TerragruntOptions, total int) *Progress {
+	if opts.DisableLog || opts.JSONLogFormat {
+		return nil
+	}
+
+	file, ok := opts.ErrWriter.(*os.File)
+	if !ok || !term.IsTerminal(int(file.Fd())) {
+		return nil
+	}
+
+	return NewProgress(file, total)
+}
+
+// ModuleStarted marks the module with the given name as running.
+func (progress *Progress) ModuleStarted(name string) {
+	if progress == nil {
+		return
+	}
+
+	progress.mu.Lock()
+	defer progress.mu.Unlock()
+
+	progress.running = append(progress.running, name)
+	progress.render()
+}
+
+// ModuleFinished marks the module with the given name as completed, or failed if err is not nil.
+func (progress *Progress) ModuleFinished(name string, err error) {
+	if progress == nil {
+		return
+	}
+
+	progress.mu.Lock()
+	defer progress.mu.Unlock()
+
+	progress.running = slices.DeleteFunc(progress.running, func(running string) bool {
+		return running == name
+	})
+
+	progress.completed++
+
+	if err != nil {
+		progress.failed++
+	}
+
+	progress.render()
+}
+
+// Done clears the progress line, it is not rendered anymore.
+func (progress *Progress) Done() {
+	if progress == nil {
+		return
+	}
+
+	progress.mu.Lock()
+	defer progress.mu.Unlock()
+
+	progress.clear()
+	progress.done = true
+}
+
+// String returns the progress line, e.g. "2 of 5 units complete, 1 failed, running: app, vpc".
+func (progress *Progress) String() string {
+	progress.mu.Lock()
+	defer progress.mu.Unlock()
+
+	return progress.line()
+}
+
+func (progress *Progress) line() string {
+	line := fmt.Sprintf("%d of %d units complete", progress.completed, progress.total)
+
+	if progress.failed > 0 {
+		line += fmt.Sprintf(", %d failed", progress.failed)
+	}
+
+	if len(progress.running) > 0 {
+		running := progress.running
+		if len(running) > maxProgressRunningModules {
+			running = append(slices.Clone(running[:maxProgressRunningModules]), fmt.Sprintf("+%d more", len(running)-maxProgressRunningModules))
+		}
+
+		line += ", running: " + strings.Join(running, ", ")
+	}
+
+	return line
+}
+
+// Writer returns a Writer that writes to `out` without corrupting the progress line.
+func (progress *Progress) Writer(out io.Writer) io.Writer {
+	if progress == nil {
+		return out
+	}
+
+	return &progressWriter{progress: progress, out: out}
+}
+
+func (progress *Progress) render() {
+	if progress.done {
+		return
+	}
+
+	fmt.Fprint(progress.out, clearLine+progress.line()) //nolint:errcheck
+
+	progress.rendered = true
+}
+
+func (progress *Progress) clear() {
+	if !progress.rendered {
+		return
+	}
+
+	fmt.Fprint(progress.out, clearLine) //nolint:errcheck
+
+	progress.rendered = false
+}
+
+type progressWriter struct {
+	progress *Progress
+	out      io.Writer
+}
+
+// Write clears the progress line, writes p to the underlying writer and renders the progress line again,
+// unless p is an incomplete line which would be continued by the next write.
+func (writer *progressWriter) Write(p []byte) (int, error) {
+	writer.progress.mu.Lock()
+	defer writer.progress.mu.Unlock()
+
+	writer.progress.clear()
+
+	n, err := writer.out.Write(p)
+
+	if bytes.HasSuffix(p, []byte("\n")) {
+		writer.progress.render()
+	}
+
+	return n, err
+}
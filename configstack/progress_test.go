@@ -0,0 +1,92 @@
+package configstack_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressReflectsModuleCompletions(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	progress := configstack.NewProgress(&out, 3)
+
+	progress.ModuleStarted("vpc")
+	assert.Equal(t, "0 of 3 units complete, running: vpc", progress.String())
+
+	progress.ModuleStarted("mysql")
+	progress.ModuleStarted("redis")
+	assert.Equal(t, "0 of 3 units complete, running: vpc, mysql, redis", progress.String())
+
+	progress.ModuleFinished("vpc", nil)
+	assert.Equal(t, "1 of 3 units complete, running: mysql, redis", progress.String())
+
+	progress.ModuleFinished("mysql", errors.New("mysql failed"))
+	assert.Equal(t, "2 of 3 units complete, 1 failed, running: redis", progress.String())
+
+	progress.ModuleFinished("redis", nil)
+	assert.Equal(t, "3 of 3 units complete, 1 failed", progress.String())
+
+	// every update is rendered in place
+	assert.Equal(t, "\r\033[K3 of 3 units complete, 1 failed", out.String()[bytes.LastIndex(out.Bytes(), []byte("\r")):])
+}
+
+func TestProgressRunningModulesAreTruncated(t *testing.T) {
+	t.Parallel()
+
+	progress := configstack.NewProgress(&bytes.Buffer{}, 5)
+
+	for i := range 5 {
+		progress.ModuleStarted(fmt.Sprintf("unit-%d", i))
+	}
+
+	assert.Equal(t, "0 of 5 units complete, running: unit-0, unit-1, unit-2, +2 more", progress.String())
+}
+
+func TestProgressWriterDoesNotCorruptOutput(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	progress := configstack.NewProgress(&out, 1)
+	writer := progress.Writer(&out)
+
+	progress.ModuleStarted("vpc")
+	out.Reset()
+
+	_, err := writer.Write([]byte("log line\n"))
+	assert.NoError(t, err)
+
+	// the progress line is cleared before the output and rendered again after it
+	assert.Equal(t, "\r\033[Klog line\n\r\033[K0 of 1 units complete, running: vpc", out.String())
+
+	progress.Done()
+	out.Reset()
+
+	_, err = writer.Write([]byte("another log line\n"))
+	assert.NoError(t, err)
+
+	// the progress line is not rendered anymore once the run is done
+	assert.Equal(t, "another log line\n", out.String())
+}
+
+func TestNilProgressWriter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		out      bytes.Buffer
+		progress *configstack.Progress
+	)
+
+	progress.ModuleStarted("vpc")
+	progress.ModuleFinished("vpc", nil)
+	progress.Done()
+
+	assert.Equal(t, &out, progress.Writer(&out))
+}
@@ -3,13 +3,18 @@ package configstack
 import (
 	"bytes"
 	"context"
+	"io"
+	"math/rand"
 	"os"
 	"path/filepath"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/shell"
 	"github.com/gruntwork-io/terragrunt/telemetry"
 	"github.com/gruntwork-io/terragrunt/terraform"
 )
@@ -44,6 +49,15 @@ type RunningModule struct {
 	Dependencies   map[string]*RunningModule
 	NotifyWhenDone []*RunningModule
 	FlagExcluded   bool
+	// Changed is set once the module has been run, i.e. it was not skipped because its plan had no changes.
+	Changed bool
+	// DependencyChanged is set if any of the module dependencies has changed, which forces the module to run.
+	DependencyChanged bool
+	// Duration is how long the module took to run.
+	Duration time.Duration
+	// pendingOutput writes the buffered output of each command run in the module, e.g. the plan run before apply with
+	// --terragrunt-skip-no-changes, if the output is written once all the modules are done with --terragrunt-output-order.
+	pendingOutput []func() error
 }
 
 // Create a new RunningModule struct for the given module. This will initialize all fields to reasonable defaults,
@@ -60,8 +74,43 @@ func newRunningModule(module *TerraformModule) *RunningModule {
 	}
 }
 
-// Run a module once all of its dependencies have finished executing.
-func (module *RunningModule) runModuleWhenReady(ctx context.Context, opts *options.TerragruntOptions, semaphore chan struct{}) {
+// queueTurn makes the modules of a queue start in the order of the queue when they are run concurrently: a module
+// waits for the previous module of the queue to start before it starts.
+type queueTurn struct {
+	previous <-chan struct{}
+	started  chan struct{}
+}
+
+// newQueueTurns returns the turns of the modules of the given queue, by index in the queue.
+func newQueueTurns(queue []*RunningModule) []*queueTurn {
+	turns := make([]*queueTurn, len(queue))
+
+	previous := make(chan struct{})
+	close(previous)
+
+	for i := range queue {
+		turns[i] = &queueTurn{previous: previous, started: make(chan struct{})}
+		previous = turns[i].started
+	}
+
+	return turns
+}
+
+func (turn *queueTurn) wait() {
+	if turn != nil {
+		<-turn.previous
+	}
+}
+
+func (turn *queueTurn) done() {
+	if turn != nil {
+		close(turn.started)
+	}
+}
+
+// Run a module once all of its dependencies have finished executing and, if the module has a turn in a queue, once the
+// previous module of the queue has started.
+func (module *RunningModule) runModuleWhenReady(ctx context.Context, opts *options.TerragruntOptions, semaphore chan struct{}, progress *Progress, events *RunEvents, turn *queueTurn) {
 	err := telemetry.Telemetry(ctx, opts, "wait_for_module_ready", map[string]interface{}{
 		"path":             module.Module.Path,
 		"terraformCommand": module.Module.TerragruntOptions.TerraformCommand,
@@ -69,21 +118,61 @@ func (module *RunningModule) runModuleWhenReady(ctx context.Context, opts *optio
 		return module.waitForDependencies()
 	})
 
+	turn.wait()
+
 	semaphore <- struct{}{} // Add one to the buffered channel. Will block if parallelism limit is met
 	defer func() {
 		<-semaphore // Remove one from the buffered channel
 	}()
 
+	name := module.displayName(opts)
+
+	if err == nil {
+		progress.ModuleStarted(name)
+		events.UnitStarted(name)
+	}
+
+	turn.done()
+
 	if err == nil {
+		start := time.Now()
+
 		err = telemetry.Telemetry(ctx, opts, "run_module", map[string]interface{}{
 			"path":             module.Module.Path,
 			"terraformCommand": module.Module.TerragruntOptions.TerraformCommand,
 		}, func(childCtx context.Context) error {
 			return module.runNow(ctx, opts)
 		})
+
+		module.Duration = time.Since(start)
 	}
 
 	module.moduleFinished(err)
+	progress.ModuleFinished(name, err)
+	events.UnitFinished(name, module.Duration, err)
+}
+
+// displayName returns the module path relative to the working directory, used to refer to the module in the progress line.
+func (module *RunningModule) displayName(opts *options.TerragruntOptions) string {
+	if name, err := filepath.Rel(opts.WorkingDir, module.Module.Path); err == nil {
+		return name
+	}
+
+	return module.Module.Path
+}
+
+// withProgressWriters makes the module output pass through the progress writers, so it doesn't corrupt the progress line.
+func (module *RunningModule) withProgressWriters(progress *Progress) {
+	if progress == nil {
+		return
+	}
+
+	opts := module.Module.TerragruntOptions
+
+	// Terragrunt logs are written to stderr, make them pass through the progress writer too.
+	opts.Logger = opts.Logger.WithOptions(log.WithOutput(progress.Writer(opts.ErrWriter)))
+	opts.Writer = progress.Writer(opts.Writer)
+	opts.ErrWriter = progress.Writer(opts.ErrWriter)
 }
 
 // Wait for all of this modules dependencies to finish executing. Return an error if any of those dependencies complete
@@ -105,16 +194,74 @@ func (module *RunningModule) waitForDependencies() error {
 		} else {
 			module.Module.TerragruntOptions.Logger.Debugf("Dependency %s of module %s just finished successfully. Module %s must wait on %d more dependencies.", doneDependency.Module.Path, module.Module.Path, module.Module.Path, len(module.Dependencies))
 		}
+
+		if doneDependency.Changed {
+			module.DependencyChanged = true
+		}
 	}
 
 	return nil
 }
 
-func (module *RunningModule) runTerragrunt(ctx context.Context, opts *options.TerragruntOptions) error {
+func (module *RunningModule) runTerragrunt(ctx context.Context, opts *options.TerragruntOptions, logFile string) error {
 	opts.Logger.Debugf("Running %s", module.Module.Path)
-	opts.Writer = NewModuleWriter(opts.Writer)
 
-	defer module.Module.FlushOutput() //nolint:errcheck
+	// Restore the writers once the module is done, so nothing writes to the closed log file or the flushed buffers.
+	writer, errWriter, logger := opts.Writer, opts.ErrWriter, opts.Logger
+	defer func() {
+		opts.Writer, opts.ErrWriter, opts.Logger = writer, errWriter, logger
+	}()
+
+	closeLogFile := func() error { return nil }
+
+	if logFile != "" {
+		if err := os.MkdirAll(filepath.Dir(logFile), os.ModePerm); err != nil {
+			return errors.New(err)
+		}
+
+		file, err := os.Create(logFile)
+		if err != nil {
+			return errors.New(err)
+		}
+
+		closeLogFile = file.Close
+
+		opts.Logger.Debugf("Saving output of %s to %s", module.Module.Path, logFile)
+
+		opts.Writer = io.MultiWriter(opts.Writer, file)
+		opts.ErrWriter = io.MultiWriter(opts.ErrWriter, file)
+	}
+
+	var flushOutput func() error
+
+	if opts.OutputMode == options.OutputModeGrouped {
+		output := NewGroupedOutput().WithSpill(opts.OutputSpillThreshold, "")
+
+		opts.Writer = output.Writer(opts.Writer)
+		opts.ErrWriter = output.Writer(opts.ErrWriter)
+		// Terragrunt logs are written to stderr, group them with the module output.
+		opts.Logger = opts.Logger.WithOptions(log.WithOutput(output.Writer(errWriter)))
+
+		flushOutput = func() error { return module.Module.FlushGroupedOutput(output) }
+	} else {
+		writer := NewModuleWriter(opts.Writer).WithSpill(opts.OutputSpillThreshold, "")
+		opts.Writer = writer
+
+		flushOutput = func() error { return module.Module.FlushOutput(writer) }
+	}
+
+	// The buffered output also goes to the log file, so the file is closed once the output is flushed.
+	flushAndClose := func() error {
+		return errors.Join(flushOutput(), closeLogFile())
+	}
+
+	switch opts.OutputOrder {
+	case options.OutputOrderPath, options.OutputOrderName:
+		// The output is written by runModules once all the modules are done, in the requested order.
+		module.pendingOutput = append(module.pendingOutput, flushAndClose)
+	default:
+		defer flushAndClose() //nolint:errcheck
+	}
 
 	return opts.RunTerragrunt(ctx, opts)
 }
@@ -127,10 +274,24 @@ func (module *RunningModule) runNow(ctx context.Context, rootOptions *options.Te
 		module.Module.TerragruntOptions.Logger.Debugf("Assuming module %s has already been applied and skipping it", module.Module.Path)
 		return nil
 	} else {
-		if err := module.runTerragrunt(ctx, module.Module.TerragruntOptions); err != nil {
+		if rootOptions.SkipNoChanges && module.Module.TerragruntOptions.TerraformCommand == terraform.CommandNameApply && !module.DependencyChanged {
+			hasChanges, err := module.planHasChanges(ctx)
+			if err != nil {
+				return err
+			}
+
+			if !hasChanges {
+				module.Module.TerragruntOptions.Logger.Infof("Skipping module %s as its plan has no changes", module.Module.Path)
+				return nil
+			}
+		}
+
+		if err := module.runTerragrunt(ctx, module.Module.TerragruntOptions, module.Module.logFile(rootOptions)); err != nil {
 			return err
 		}
 
+		module.Changed = true
+
 		// convert terragrunt output to json
 		if module.Module.outputJSONFile(module.Module.TerragruntOptions) != "" {
 			jsonOptions, err := module.Module.TerragruntOptions.Clone(module.Module.TerragruntOptions.TerragruntConfigPath)
@@ -166,6 +327,36 @@ func (module *RunningModule) runNow(ctx context.Context, rootOptions *options.Te
 	}
 }
 
+// planHasChanges runs `plan -detailed-exitcode` with the same arguments the module is going to be applied with,
+// and returns true if the plan has changes.
+func (module *RunningModule) planHasChanges(ctx context.Context) (bool, error) {
+	opts := module.Module.TerragruntOptions
+
+	planOpts, err := opts.Clone(opts.TerragruntConfigPath)
+	if err != nil {
+		return false, err
+	}
+
+	planOpts.TerraformCommand = terraform.CommandNamePlan
+	planOpts.TerraformCliArgs = []string{terraform.CommandNamePlan, terraform.FlagNameDetailedExitCode}
+
+	for _, arg := range opts.TerraformCliArgs[1:] {
+		if arg != "-auto-approve" {
+			planOpts.TerraformCliArgs = append(planOpts.TerraformCliArgs, arg)
+		}
+	}
+
+	opts.Logger.Debugf("Running plan for module %s to check if it has changes", module.Module.Path)
+
+	var exitCode shell.DetailedExitCode
+
+	if err := module.runTerragrunt(shell.ContextWithDetailedExitCode(ctx, &exitCode), planOpts, ""); err != nil {
+		return false, err
+	}
+
+	return exitCode.Get() == shell.DetailedExitCodeChanges, nil
+}
+
 // Record that a module has finished executing and notify all of this module's dependencies
 func (module *RunningModule) moduleFinished(moduleErr error) {
 	if moduleErr == nil {
@@ -306,26 +497,195 @@ func (modules RunningModules) RemoveFlagExcluded() map[string]*RunningModule {
 
 // Run the given map of module path to runningModule. To "run" a module, execute the RunTerragrunt command in its
 // TerragruntOptions object. The modules will be executed in an order determined by their inter-dependencies, using
-// as much concurrency as possible.
+// as much concurrency as possible, or one at a time in a deterministic order with --terragrunt-sequential.
 func (modules RunningModules) runModules(ctx context.Context, opts *options.TerragruntOptions, parallelism int) error {
 	var (
 		waitGroup sync.WaitGroup
 		semaphore = make(chan struct{}, parallelism) // Make a semaphore from a buffered channel
+		progress  = newTerminalProgress(opts, len(modules))
+		start     = time.Now()
 	)
 
+	events, err := newRunEventsFromOptions(opts)
+	if err != nil {
+		return err
+	}
+
+	for _, module := range modules {
+		module.withProgressWriters(progress)
+	}
+
+	queue := make([]*RunningModule, 0, len(modules))
+	for _, module := range modules {
+		queue = append(queue, module)
+	}
+
+	if opts.Sequential {
+		queue = modules.SequentialQueue()
+	}
+
+	if opts.QueueShuffle {
+		seed := opts.QueueShuffleSeed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+
+		opts.Logger.Infof("Shuffling the order of the units with seed %d, pass --terragrunt-queue-shuffle-seed=%d to reproduce it", seed, seed)
+
+		queue = modules.ShuffledQueue(seed)
+	}
+
+	if opts.Sequential {
+		// The dependencies of each module come before it in the queue, so they are done by the time the module runs.
+		for _, module := range queue {
+			module.runModuleWhenReady(ctx, opts, semaphore, progress, events, nil)
+		}
+	} else {
+		// The shuffled modules are started in the order of the queue, so the order is reproduced by the same seed.
+		turns := make([]*queueTurn, len(queue))
+		if opts.QueueShuffle {
+			turns = newQueueTurns(queue)
+		}
+
+		for i, module := range queue {
+			waitGroup.Add(1)
+
+			go func(module *RunningModule, turn *queueTurn) {
+				defer waitGroup.Done()
+
+				module.runModuleWhenReady(ctx, opts, semaphore, progress, events, turn)
+			}(module, turns[i])
+		}
+
+		waitGroup.Wait()
+	}
+
+	progress.Done()
+
+	modules.writePendingOutput(opts)
+
+	err = modules.collectErrors()
+
+	if eventsErr := events.Close(); eventsErr != nil {
+		err = errors.Join(err, eventsErr)
+	}
+
+	if opts.MetricsFile != "" {
+		if metricsErr := modules.writeMetricsFile(opts, time.Since(start)); metricsErr != nil {
+			return errors.Join(err, metricsErr)
+		}
+	}
+
+	return err
+}
+
+// ShuffledQueue returns the modules in the order they are started by a run with --terragrunt-queue-shuffle: the modules
+// come level by level of the dependency graph, i.e. a module comes after all of its dependencies, and the order of the
+// modules of a level is randomized with the given seed. The same seed always gives the same order.
+func (modules RunningModules) ShuffledQueue(seed int64) []*RunningModule {
+	queue := modules.sortedByPath()
+
+	rnd := rand.New(rand.NewSource(seed)) //nolint:gosec
+	rnd.Shuffle(len(queue), func(i, j int) {
+		queue[i], queue[j] = queue[j], queue[i]
+	})
+
+	return modules.sortByLevel(queue)
+}
+
+// SequentialQueue returns the modules in the order they are run by a run with --terragrunt-sequential: the modules
+// come level by level of the dependency graph, i.e. a module comes after all of its dependencies, and the modules of a
+// level are sorted by path.
+func (modules RunningModules) SequentialQueue() []*RunningModule {
+	return modules.sortByLevel(modules.sortedByPath())
+}
+
+// sortedByPath returns the modules sorted by path, so that the order does not depend on the order of the map iteration.
+func (modules RunningModules) sortedByPath() []*RunningModule {
+	queue := make([]*RunningModule, 0, len(modules))
 	for _, module := range modules {
-		waitGroup.Add(1)
+		queue = append(queue, module)
+	}
+
+	sort.Slice(queue, func(i, j int) bool {
+		return queue[i].Module.Path < queue[j].Module.Path
+	})
+
+	return queue
+}
+
+// sortByLevel stably sorts the given modules by their level in the dependency graph, the modules without dependencies
+// being at level 0 and the others one level above their deepest dependency.
+func (modules RunningModules) sortByLevel(queue []*RunningModule) []*RunningModule {
+	levels := map[string]int{}
+
+	var levelOf func(module *RunningModule) int
+
+	levelOf = func(module *RunningModule) int {
+		path := module.Module.Path
+
+		if level, ok := levels[path]; ok {
+			return level
+		}
+
+		// Guard against cycles, they are reported when the stack is created.
+		levels[path] = 0
+		level := 0
+
+		for depPath := range module.Dependencies {
+			if dependency, ok := modules[depPath]; ok {
+				level = max(level, levelOf(dependency)+1)
+			}
+		}
+
+		levels[path] = level
 
-		go func(module *RunningModule) {
-			defer waitGroup.Done()
+		return level
+	}
+
+	for _, module := range queue {
+		levelOf(module)
+	}
+
+	sort.SliceStable(queue, func(i, j int) bool {
+		return levels[queue[i].Module.Path] < levels[queue[j].Module.Path]
+	})
 
-			module.runModuleWhenReady(ctx, opts, semaphore)
-		}(module)
+	return queue
+}
+
+// writePendingOutput writes the output of the modules that is held until all the modules are done, in the order given
+// with --terragrunt-output-order: sorted by the path of the modules, or by their name, i.e. the name of their directory.
+func (modules RunningModules) writePendingOutput(opts *options.TerragruntOptions) {
+	queue := make([]*RunningModule, 0, len(modules))
+
+	for _, module := range modules {
+		if module.pendingOutput != nil {
+			queue = append(queue, module)
+		}
 	}
 
-	waitGroup.Wait()
+	sort.Slice(queue, func(i, j int) bool {
+		pathI, pathJ := queue[i].Module.Path, queue[j].Module.Path
 
-	return modules.collectErrors()
+		if opts.OutputOrder == options.OutputOrderName {
+			if nameI, nameJ := filepath.Base(pathI), filepath.Base(pathJ); nameI != nameJ {
+				return nameI < nameJ
+			}
+		}
+
+		return pathI < pathJ
+	})
+
+	for _, module := range queue {
+		for _, writeOutput := range module.pendingOutput {
+			if err := writeOutput(); err != nil {
+				opts.Logger.Errorf("Failed to write the output of %s: %v", module.Module.Path, err)
+			}
+		}
+
+		module.pendingOutput = nil
+	}
 }
 
 // Collect the errors from the given modules and return a single error object to represent them, or nil if no errors
@@ -1,7 +1,11 @@
 package configstack_test
 
 import (
+	"context"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/configstack"
@@ -792,3 +796,216 @@ func TestRemoveFlagExcludedOneExcludeWithDependencies(t *testing.T) {
 
 	assertRunningModuleMapsEqual(t, expected, actual, true)
 }
+
+func TestShuffledQueue(t *testing.T) {
+	t.Parallel()
+
+	newModule := func(path string, dependencies ...*configstack.TerraformModule) *configstack.TerraformModule {
+		return &configstack.TerraformModule{
+			Path:              path,
+			Dependencies:      dependencies,
+			Config:            config.TerragruntConfig{},
+			TerragruntOptions: mockOptions,
+		}
+	}
+
+	moduleA := newModule("a")
+	moduleB := newModule("b", moduleA)
+	moduleC := newModule("c", moduleA)
+	moduleD := newModule("d", moduleB, moduleC)
+	moduleE := newModule("e")
+	moduleF := newModule("f", moduleE)
+	moduleG := newModule("g")
+	moduleH := newModule("h")
+
+	modules := configstack.TerraformModules{moduleA, moduleB, moduleC, moduleD, moduleE, moduleF, moduleG, moduleH}
+
+	queuePaths := func(dependencyOrder configstack.DependencyOrder, seed int64) []string {
+		runningModules, err := modules.ToRunningModules(dependencyOrder)
+		require.NoError(t, err)
+
+		var paths []string
+		for _, module := range runningModules.ShuffledQueue(seed) {
+			paths = append(paths, module.Module.Path)
+		}
+
+		return paths
+	}
+
+	orders := map[string]bool{}
+
+	for seed := int64(1); seed <= 20; seed++ {
+		for _, dependencyOrder := range []configstack.DependencyOrder{configstack.NormalOrder, configstack.ReverseOrder} {
+			paths := queuePaths(dependencyOrder, seed)
+			require.Len(t, paths, len(modules))
+
+			// the same seed gives the same order
+			assert.Equal(t, paths, queuePaths(dependencyOrder, seed))
+
+			position := map[string]int{}
+			for i, path := range paths {
+				position[path] = i
+			}
+
+			// the dependencies are still respected
+			for _, module := range modules {
+				for _, dependency := range module.Dependencies {
+					if dependencyOrder == configstack.NormalOrder {
+						assert.Less(t, position[dependency.Path], position[module.Path], "seed %d: %s must come before %s", seed, dependency.Path, module.Path)
+					} else {
+						assert.Less(t, position[module.Path], position[dependency.Path], "seed %d: %s must come before %s", seed, module.Path, dependency.Path)
+					}
+				}
+			}
+
+			if dependencyOrder == configstack.NormalOrder {
+				orders[strings.Join(paths, ",")] = true
+			}
+		}
+	}
+
+	// different seeds give different orders
+	assert.Greater(t, len(orders), 1)
+}
+
+func TestRunModulesSequential(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu         sync.Mutex
+		order      []string
+		running    int
+		maxRunning int
+	)
+
+	newModule := func(path string, dependencies ...*configstack.TerraformModule) *configstack.TerraformModule {
+		opts, err := options.NewTerragruntOptionsForTest(path)
+		require.NoError(t, err)
+
+		opts.RunTerragrunt = func(_ context.Context, _ *options.TerragruntOptions) error {
+			mu.Lock()
+			running++
+			maxRunning = max(maxRunning, running)
+			order = append(order, path)
+			mu.Unlock()
+
+			// give the other modules a chance to start if they are not run one at a time
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+
+			return nil
+		}
+
+		return &configstack.TerraformModule{
+			Stack:             &configstack.Stack{},
+			Path:              path,
+			Dependencies:      dependencies,
+			Config:            config.TerragruntConfig{},
+			TerragruntOptions: opts,
+		}
+	}
+
+	moduleA := newModule("a")
+	moduleB := newModule("b", moduleA)
+	moduleC := newModule("c", moduleA)
+	moduleD := newModule("d", moduleB, moduleC)
+	moduleE := newModule("e")
+	moduleF := newModule("f", moduleE)
+	moduleG := newModule("g")
+
+	modules := configstack.TerraformModules{moduleG, moduleD, moduleF, moduleC, moduleA, moduleE, moduleB}
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	opts.Sequential = true
+
+	for range 3 {
+		order = nil
+
+		require.NoError(t, modules.RunModules(context.Background(), opts, options.DefaultParallelism))
+
+		assert.Equal(t, 1, maxRunning)
+		// dependency order, then sorted by path
+		assert.Equal(t, []string{"a", "e", "g", "b", "c", "f", "d"}, order)
+	}
+}
+
+func TestRunModulesQueueShuffle(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+
+	newModule := func(path string, dependencies ...*configstack.TerraformModule) *configstack.TerraformModule {
+		opts, err := options.NewTerragruntOptionsForTest(path)
+		require.NoError(t, err)
+
+		opts.RunTerragrunt = func(_ context.Context, _ *options.TerragruntOptions) error {
+			mu.Lock()
+			order = append(order, path)
+			mu.Unlock()
+
+			return nil
+		}
+
+		return &configstack.TerraformModule{
+			Stack:             &configstack.Stack{},
+			Path:              path,
+			Dependencies:      dependencies,
+			Config:            config.TerragruntConfig{},
+			TerragruntOptions: opts,
+		}
+	}
+
+	moduleA := newModule("a")
+	moduleB := newModule("b", moduleA)
+	moduleC := newModule("c", moduleA)
+	moduleD := newModule("d", moduleB, moduleC)
+	moduleE := newModule("e")
+	moduleF := newModule("f", moduleE)
+	moduleG := newModule("g")
+	moduleH := newModule("h")
+
+	modules := configstack.TerraformModules{moduleA, moduleB, moduleC, moduleD, moduleE, moduleF, moduleG, moduleH}
+
+	runOrder := func(seed int64) []string {
+		opts, err := options.NewTerragruntOptionsForTest("")
+		require.NoError(t, err)
+
+		opts.QueueShuffle = true
+		opts.QueueShuffleSeed = seed
+
+		order = nil
+
+		require.NoError(t, modules.RunModules(context.Background(), opts, 1))
+
+		return order
+	}
+
+	orders := map[string]bool{}
+
+	for seed := int64(1); seed <= 10; seed++ {
+		runningModules, err := modules.ToRunningModules(configstack.NormalOrder)
+		require.NoError(t, err)
+
+		var expected []string
+		for _, module := range runningModules.ShuffledQueue(seed) {
+			expected = append(expected, module.Module.Path)
+		}
+
+		// the modules run in the shuffled order, which the same seed reproduces
+		for range 3 {
+			assert.Equal(t, expected, runOrder(seed), "seed %d", seed)
+		}
+
+		orders[strings.Join(expected, ",")] = true
+	}
+
+	assert.Greater(t, len(orders), 1)
+}
@@ -37,6 +37,10 @@ type Stack struct {
 	childTerragruntConfig *config.TerragruntConfig
 	Modules               TerraformModules
 	outputMu              sync.Mutex
+
+	// resolvingModulePaths are the paths of the modules whose dependencies are being resolved, so that the resolution
+	// of dependencies forming a cycle stops instead of recursing forever.
+	resolvingModulePaths map[string]bool
 }
 
 // FindStackInSubfolders finds all the Terraform modules in the subfolders of the working directory of the given TerragruntOptions and
@@ -163,6 +167,16 @@ func (stack *Stack) Graph(terragruntOptions *options.TerragruntOptions) {
 func (stack *Stack) Run(ctx context.Context, terragruntOptions *options.TerragruntOptions) error {
 	stackCmd := terragruntOptions.TerraformCommand
 
+	if terragruntOptions.CheckProviderConsistency {
+		if err := stack.checkProviderConsistency(terragruntOptions); err != nil {
+			return err
+		}
+	}
+
+	if terragruntOptions.WarnLocalState {
+		stack.warnLocalState(terragruntOptions)
+	}
+
 	// prepare folder for output hierarchy if output folder is set
 	if terragruntOptions.OutputFolder != "" {
 		for _, module := range stack.Modules {
@@ -219,6 +233,97 @@ func (stack *Stack) Run(ctx context.Context, terragruntOptions *options.Terragru
 	}
 }
 
+// checkProviderConsistency returns an error listing the providers that are pinned to different versions across the
+// units of the stack, either in the dependency lock files or in the `required_providers` blocks.
+func (stack *Stack) checkProviderConsistency(terragruntOptions *options.TerragruntOptions) error {
+	conflicts, err := stack.ProviderVersionConflicts(terragruntOptions)
+	if err != nil {
+		return err
+	}
+
+	if len(conflicts) == 0 {
+		terragruntOptions.Logger.Debugf("All providers are pinned to the same versions across units")
+		return nil
+	}
+
+	return errors.New(conflicts)
+}
+
+// ProviderVersionConflicts returns the providers that are pinned to different versions across the units of the stack,
+// either in the dependency lock files or in the `required_providers` blocks, sorted by provider.
+func (stack *Stack) ProviderVersionConflicts(terragruntOptions *options.TerragruntOptions) (ProviderVersionConflictsError, error) {
+	// provider -> version -> units
+	providers := map[string]map[string][]string{}
+
+	for _, module := range stack.Modules {
+		if module.FlagExcluded || module.AssumeAlreadyApplied {
+			continue
+		}
+
+		versions, err := terraform.ModuleProviderVersions(module.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		unit, err := filepath.Rel(terragruntOptions.WorkingDir, module.Path)
+		if err != nil {
+			unit = module.Path
+		}
+
+		for provider, version := range versions {
+			if providers[provider] == nil {
+				providers[provider] = map[string][]string{}
+			}
+
+			providers[provider][version] = append(providers[provider][version], unit)
+		}
+	}
+
+	var conflicts ProviderVersionConflictsError
+
+	for provider, units := range providers {
+		if len(units) > 1 {
+			for _, paths := range units {
+				sort.Strings(paths)
+			}
+
+			conflicts = append(conflicts, ProviderVersionConflict{Provider: provider, Units: units})
+		}
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Provider < conflicts[j].Provider
+	})
+
+	return conflicts, nil
+}
+
+// LocalStateModules returns the modules of the stack that have neither a `remote_state` block nor a generate block
+// configuring a backend, and so store their state locally unless their OpenTofu/Terraform code configures a backend.
+// The `remote_state` and `generate` blocks are only parsed when the stack is created with --terragrunt-warn-local-state.
+func (stack *Stack) LocalStateModules() TerraformModules {
+	var modules TerraformModules
+
+	for _, module := range stack.Modules {
+		if module.FlagExcluded || module.AssumeAlreadyApplied {
+			continue
+		}
+
+		if !module.Config.StoresStateRemotely() {
+			modules = append(modules, module)
+		}
+	}
+
+	return modules
+}
+
+// warnLocalState logs a warning for each module of the stack that stores its state locally.
+func (stack *Stack) warnLocalState(terragruntOptions *options.TerragruntOptions) {
+	for _, module := range stack.LocalStateModules() {
+		terragruntOptions.Logger.Warnf("Unit %s has neither a remote_state block nor a generate block configuring a backend, its state is stored locally", stack.relativePath(module.Path))
+	}
+}
+
 // We inspect the error streams to give an explicit message if the plan failed because there were references to
 // remote states. `terraform plan` will fail if it tries to access remote state from dependencies and the plan
 // has never been applied on the dependency.
@@ -302,7 +407,7 @@ func (stack *Stack) createStackForTerragruntConfigPaths(ctx context.Context, ter
 			return errors.New(ErrNoTerraformModulesFound)
 		}
 
-		modules, err := stack.ResolveTerraformModules(ctx, terragruntConfigPaths)
+		modules, err := stack.resolveTerraformModules(ctx, terragruntConfigPaths)
 		if err != nil {
 			return errors.New(err)
 		}
@@ -522,6 +627,13 @@ func (stack *Stack) resolveModules(ctx context.Context, canonicalTerragruntConfi
 				"working_dir": stack.terragruntOptions.WorkingDir,
 				"module_path": module.Path,
 			}, func(childCtx context.Context) error {
+				if stack.resolvingModulePaths == nil {
+					stack.resolvingModulePaths = make(map[string]bool)
+				}
+
+				stack.resolvingModulePaths[module.Path] = true
+				defer delete(stack.resolvingModulePaths, module.Path)
+
 				deps, err := stack.resolveDependenciesForModule(ctx, module, modulesMap, true)
 				if err != nil {
 					return err
@@ -555,17 +667,11 @@ func (stack *Stack) resolveTerraformModule(ctx context.Context, terragruntConfig
 		return nil, nil
 	}
 
-	// Clone the options struct so we don't modify the original one. This is especially important as run-all operations
-	// happen concurrently.
-	opts, err := stack.terragruntOptions.Clone(terragruntConfigPath)
+	opts, err := stack.cloneOptionsForModule(terragruntConfigPath)
 	if err != nil {
 		return nil, err
 	}
 
-	// We need to reset the original path for each module. Otherwise, this path will be set to wherever you ran run-all
-	// from, which is not what any of the modules will want.
-	opts.OriginalTerragruntConfigPath = terragruntConfigPath
-
 	// If `childTerragruntConfig.ProcessedIncludes` contains the path `terragruntConfigPath`, then this is a parent config
 	// which implies that `TerragruntConfigPath` must refer to a child configuration file, and the defined `IncludeConfig` must contain the path to the file itself
 	// for the built-in functions `read-terragrunt-config()`, `path_relative_to_include()` to work correctly.
@@ -596,6 +702,10 @@ func (stack *Stack) resolveTerraformModule(ctx context.Context, terragruntConfig
 			config.ErrorsBlock,
 		)
 
+	if stack.terragruntOptions.WarnLocalState {
+		parseCtx = parseCtx.WithDecodeList(append(parseCtx.PartialParseDecodeList, config.RemoteStateBlock, config.GenerateBlock)...)
+	}
+
 	// Credentials have to be acquired before the config is parsed, as the config may contain interpolation functions
 	// that require credentials to be available.
 	credsGetter := creds.NewGetter()
@@ -624,30 +734,10 @@ func (stack *Stack) resolveTerraformModule(ctx context.Context, terragruntConfig
 	// Hack to persist readFiles. Need to discuss with team to see if there is a better way to handle this.
 	stack.terragruntOptions.CloneReadFiles(opts.ReadFiles)
 
-	terragruntSource, err := config.GetTerragruntSourceForModule(stack.terragruntOptions.Source, modulePath, terragruntConfig)
-	if err != nil {
+	if err := stack.setModuleSourceAndDownloadDir(opts, modulePath, terragruntConfigPath, terragruntConfig); err != nil {
 		return nil, err
 	}
 
-	opts.Source = terragruntSource
-
-	_, defaultDownloadDir, err := options.DefaultWorkingAndDownloadDirs(stack.terragruntOptions.TerragruntConfigPath)
-	if err != nil {
-		return nil, err
-	}
-
-	// If we're using the default download directory, put it into the same folder as the Terragrunt configuration file.
-	// If we're not using the default, then the user has specified a custom download directory, and we leave it as-is.
-	if stack.terragruntOptions.DownloadDir == defaultDownloadDir {
-		_, downloadDir, err := options.DefaultWorkingAndDownloadDirs(terragruntConfigPath)
-		if err != nil {
-			return nil, err
-		}
-
-		opts.Logger.Debugf("Setting download directory for module %s to %s", filepath.Dir(opts.TerragruntConfigPath), downloadDir)
-		opts.DownloadDir = downloadDir
-	}
-
 	// Fix for https://github.com/gruntwork-io/terragrunt/issues/208
 	matches, err := filepath.Glob(filepath.Join(filepath.Dir(terragruntConfigPath), "*.tf"))
 	if err != nil {
@@ -677,6 +767,10 @@ func (stack *Stack) resolveDependenciesForModule(ctx context.Context, module *Te
 
 	externalTerragruntConfigPaths := []string{}
 
+	// whether a dependency was skipped because it is being resolved higher up, in which case the result is incomplete
+	// and not cached
+	skippedResolvingModule := false
+
 	for _, dependency := range module.Config.Dependencies.Paths {
 		dependencyPath, err := util.CanonicalPath(dependency, module.Path)
 		if err != nil {
@@ -687,7 +781,12 @@ func (stack *Stack) resolveDependenciesForModule(ctx context.Context, module *Te
 			continue
 		}
 
-		terragruntConfigPath := config.GetDefaultConfigPath(dependencyPath)
+		if skipExternal && stack.resolvingModulePaths[dependencyPath] {
+			skippedResolvingModule = true
+			continue
+		}
+
+		terragruntConfigPath := config.GetConfigPathForDir(dependencyPath, stack.terragruntOptions)
 
 		if _, alreadyContainsModule := modulesMap[dependencyPath]; !alreadyContainsModule {
 			externalTerragruntConfigPaths = append(externalTerragruntConfigPaths, terragruntConfigPath)
@@ -701,11 +800,60 @@ func (stack *Stack) resolveDependenciesForModule(ctx context.Context, module *Te
 		return nil, err
 	}
 
-	existingModules.Put(ctx, key, &result)
+	if !skippedResolvingModule {
+		existingModules.Put(ctx, key, &result)
+	}
 
 	return result, nil
 }
 
+// cloneOptionsForModule returns a copy of the options of the stack for the module of the given Terragrunt configuration
+// file.
+func (stack *Stack) cloneOptionsForModule(terragruntConfigPath string) (*options.TerragruntOptions, error) {
+	// Clone the options struct so we don't modify the original one. This is especially important as run-all operations
+	// happen concurrently.
+	opts, err := stack.terragruntOptions.Clone(terragruntConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// We need to reset the original path for each module. Otherwise, this path will be set to wherever you ran run-all
+	// from, which is not what any of the modules will want.
+	opts.OriginalTerragruntConfigPath = terragruntConfigPath
+
+	return opts, nil
+}
+
+// setModuleSourceAndDownloadDir sets the Terraform source and the download directory of the given module options, from
+// the options of the stack and the configuration of the module.
+func (stack *Stack) setModuleSourceAndDownloadDir(opts *options.TerragruntOptions, modulePath, terragruntConfigPath string, terragruntConfig *config.TerragruntConfig) error {
+	terragruntSource, err := config.GetTerragruntSourceForModule(stack.terragruntOptions.Source, modulePath, terragruntConfig)
+	if err != nil {
+		return err
+	}
+
+	opts.Source = terragruntSource
+
+	_, defaultDownloadDir, err := options.DefaultWorkingAndDownloadDirs(stack.terragruntOptions.TerragruntConfigPath)
+	if err != nil {
+		return err
+	}
+
+	// If we're using the default download directory, put it into the same folder as the Terragrunt configuration file.
+	// If we're not using the default, then the user has specified a custom download directory, and we leave it as-is.
+	if stack.terragruntOptions.DownloadDir == defaultDownloadDir {
+		_, downloadDir, err := options.DefaultWorkingAndDownloadDirs(terragruntConfigPath)
+		if err != nil {
+			return err
+		}
+
+		opts.Logger.Debugf("Setting download directory for module %s to %s", filepath.Dir(opts.TerragruntConfigPath), downloadDir)
+		opts.DownloadDir = downloadDir
+	}
+
+	return nil
+}
+
 // Look through the dependencies of the modules in the given map and resolve the "external" dependency paths listed in
 // each modules config (i.e. those dependencies not in the given list of Terragrunt config canonical file paths).
 // These external dependencies are outside of the current working directory, which means they may not be part of the
@@ -730,7 +878,7 @@ func (stack *Stack) resolveExternalDependenciesForModules(ctx context.Context, m
 			return externalDependencies, err
 		}
 
-		moduleOpts, err := stack.terragruntOptions.Clone(config.GetDefaultConfigPath(module.Path))
+		moduleOpts, err := stack.terragruntOptions.Clone(config.GetConfigPathForDir(module.Path, stack.terragruntOptions))
 		if err != nil {
 			return nil, err
 		}
@@ -741,7 +889,7 @@ func (stack *Stack) resolveExternalDependenciesForModules(ctx context.Context, m
 			}
 
 			shouldApply := false
-			if !stack.terragruntOptions.IgnoreExternalDependencies {
+			if !stack.terragruntOptions.IgnoreExternalDependencies && !stack.terragruntOptions.ExternalDependencyOutputsOnly {
 				shouldApply, err = module.confirmShouldApplyExternalDependency(ctx, externalDependency, moduleOpts)
 				if err != nil {
 					return externalDependencies, err
@@ -749,6 +897,7 @@ func (stack *Stack) resolveExternalDependenciesForModules(ctx context.Context, m
 			}
 
 			externalDependency.AssumeAlreadyApplied = !shouldApply
+			externalDependency.InclusionReason = InclusionReasonExternalDependency
 			allExternalDependencies[externalDependency.Path] = externalDependency
 		}
 	}
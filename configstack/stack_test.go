@@ -60,6 +60,26 @@ func TestFindStackInSubfolders(t *testing.T) {
 	}
 }
 
+func TestLocalStateModules(t *testing.T) {
+	t.Parallel()
+
+	workingDir := canonical(t, "../test/fixtures/warn-local-state")
+
+	terragruntOptions, err := options.NewTerragruntOptionsWithConfigPath(workingDir)
+	require.NoError(t, err)
+
+	terragruntOptions.WorkingDir = workingDir
+	terragruntOptions.WarnLocalState = true
+
+	stack, err := configstack.FindStackInSubfolders(context.Background(), terragruntOptions)
+	require.NoError(t, err)
+	require.Len(t, stack.Modules, 3)
+
+	modules := stack.LocalStateModules()
+	require.Len(t, modules, 1)
+	require.Equal(t, filepath.Join(workingDir, "local-state"), modules[0].Path)
+}
+
 func TestGetModuleRunGraphApplyOrder(t *testing.T) {
 	t.Parallel()
 
@@ -310,6 +330,7 @@ func TestResolveTerraformModulesReadConfigFromParentConfig(t *testing.T) {
 			"dependencies":                  interface{}(nil),
 			"download_dir":                  "",
 			"generate":                      map[string]interface{}{},
+			"global_extra_arguments":        interface{}(nil),
 			"iam_assume_role_duration":      interface{}(nil),
 			"iam_assume_role_session_name":  "",
 			"iam_role":                      "",
@@ -489,6 +510,73 @@ func TestResolveTerraformModulesJsonModulesWithHclDependencies(t *testing.T) {
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
+func TestResolveTerraformModulesDependencyCycleInWorkingDir(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+
+	for module, dependency := range map[string]string{"a": "../b", "b": "../a"} {
+		moduleDir := filepath.Join(workingDir, module)
+		require.NoError(t, os.MkdirAll(moduleDir, os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "main.tf"), nil, 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(moduleDir, config.DefaultTerragruntConfigPath), []byte(`dependencies { paths = ["`+dependency+`"] }`), 0644))
+	}
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, config.DefaultTerragruntConfigPath))
+	require.NoError(t, err)
+
+	configPaths := []string{
+		filepath.Join(workingDir, "a", config.DefaultTerragruntConfigPath),
+		filepath.Join(workingDir, "b", config.DefaultTerragruntConfigPath),
+	}
+
+	// the resolution of the dependencies stops at the cycle, which is then reported
+	stack := configstack.NewStack(opts)
+	modules, err := stack.ResolveTerraformModules(context.Background(), configPaths)
+	require.NoError(t, err)
+	require.Len(t, modules, 2)
+
+	var cycleErr configstack.DependencyCycleError
+
+	require.ErrorAs(t, modules.CheckForCycles(), &cycleErr)
+}
+
+func TestResolveTerraformModulesConfigFilenameOverride(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+
+	// the app of the legacy subtree depends on the vpc of the subtree using another config filename
+	for configPath, contents := range map[string]string{
+		"legacy/app/" + config.DefaultTerragruntConfigPath: `dependencies { paths = ["../../new/vpc"] }`,
+		"new/vpc/unit.hcl": ``,
+	} {
+		configPath = filepath.Join(workingDir, filepath.FromSlash(configPath))
+		require.NoError(t, os.MkdirAll(filepath.Dir(configPath), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(configPath), "main.tf"), nil, 0644))
+		require.NoError(t, os.WriteFile(configPath, []byte(contents), 0644))
+	}
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, config.DefaultTerragruntConfigPath))
+	require.NoError(t, err)
+
+	opts.ConfigFilenameOverrides = map[string]string{"new/**": "unit.hcl"}
+
+	stack := configstack.NewStack(opts)
+	modules, err := stack.ResolveTerraformModules(context.Background(), []string{filepath.Join(workingDir, "legacy", "app", config.DefaultTerragruntConfigPath)})
+	require.NoError(t, err)
+
+	configPaths := map[string]string{}
+	for _, module := range modules {
+		configPaths[module.Path] = module.TerragruntOptions.TerragruntConfigPath
+	}
+
+	require.Equal(t, map[string]string{
+		filepath.Join(workingDir, "legacy", "app"): filepath.Join(workingDir, "legacy", "app", config.DefaultTerragruntConfigPath),
+		filepath.Join(workingDir, "new", "vpc"):    filepath.Join(workingDir, "new", "vpc", "unit.hcl"),
+	}, configPaths)
+}
+
 func TestResolveTerraformModulesHclModulesWithJsonDependencies(t *testing.T) {
 	t.Parallel()
 
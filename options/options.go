@@ -39,6 +39,15 @@ const (
 	// Default to naming it `terragrunt_rendered.json` in the terragrunt config directory.
 	DefaultJSONOutName = "terragrunt_rendered.json"
 
+	// Default to naming it `terragrunt_rendered_bundle.json` in the working directory.
+	DefaultRenderJSONBundleOutName = "terragrunt_rendered_bundle.json"
+
+	// Default to rendering the config as JSON.
+	DefaultRenderJSONFormat = "json"
+
+	// Default to naming it `terragrunt_rendered.tfvars.json` in the terragrunt config directory.
+	DefaultTFVarsOutName = "terragrunt_rendered.tfvars.json"
+
 	DefaultSignalsFile = "error-signals.json"
 
 	DefaultTFDataDir = ".terraform"
@@ -49,6 +58,10 @@ const (
 
 	defaultExcludesFile = ".terragrunt-excludes"
 
+	defaultIgnoreFile = ".terragruntignore"
+
+	defaultAwsProviderPatchProviderName = "aws"
+
 	defaultLogLevel = log.InfoLevel
 )
 
@@ -77,6 +90,35 @@ const (
 	UnknownImpl   TerraformImplementationType = "unknown"
 )
 
+// OutputMode controls how the output of the units is written when running a stack.
+type OutputMode string
+
+const (
+	// OutputModeStream writes the output of the units as soon as it is produced.
+	OutputModeStream OutputMode = "stream"
+	// OutputModeGrouped buffers the output of each unit and writes it contiguously once the unit is done.
+	OutputModeGrouped OutputMode = "grouped"
+)
+
+// AllOutputModes lists the supported output modes.
+var AllOutputModes = []OutputMode{OutputModeStream, OutputModeGrouped}
+
+// OutputOrder controls in which order the output of the units is written when running a stack.
+type OutputOrder string
+
+const (
+	// OutputOrderFinish writes the output of each unit as soon as the unit is done.
+	OutputOrderFinish OutputOrder = "finish"
+	// OutputOrderPath writes the output of the units once all of them are done, sorted by path.
+	OutputOrderPath OutputOrder = "path"
+	// OutputOrderName writes the output of the units once all of them are done, sorted by name, the name of their
+	// directory.
+	OutputOrderName OutputOrder = "name"
+)
+
+// AllOutputOrders lists the supported output orders.
+var AllOutputOrders = []OutputOrder{OutputOrderFinish, OutputOrderPath, OutputOrderName}
+
 // TerragruntOptions represents options that configure the behavior of the Terragrunt program
 type TerragruntOptions struct {
 	// Location of the Terragrunt config file
@@ -129,6 +171,9 @@ type TerragruntOptions struct {
 	// Unlike `WorkingDir`, this path is the same for all dependencies and points to the root working directory specified in the CLI.
 	RootWorkingDir string
 
+	// If the working dir has no config, use the directory of the nearest config in the parent directories instead.
+	ConfigSearchUp bool
+
 	// Basic log entry
 	Logger log.Logger
 
@@ -150,12 +195,18 @@ type TerragruntOptions struct {
 	// If true, logs will be disabled
 	DisableLog bool
 
+	// If true, only warnings and errors are logged
+	Quiet bool
+
 	// If true, logs will be displayed in formatter key/value, by default logs are formatted in human-readable formatter.
 	DisableLogFormatting bool
 
 	// ValidateStrict mode for the validate-inputs command
 	ValidateStrict bool
 
+	// If set to true, the validate-inputs command warns about inputs that are set to the variable default value
+	WarnRedundantInputs bool
+
 	// Environment variables at runtime
 	Env map[string]string
 
@@ -192,6 +243,10 @@ type TerragruntOptions struct {
 	// If set to true, apply all external dependencies when running *-all commands
 	IncludeExternalDependencies bool
 
+	// If set to true, skip any external dependencies when running *-all commands, but still read their outputs from
+	// the existing state, never falling back to mock outputs.
+	ExternalDependencyOutputsOnly bool
+
 	// If you want stdout to go somewhere other than os.stdout
 	Writer io.Writer
 
@@ -217,12 +272,26 @@ type TerragruntOptions struct {
 	// Path to a file with a list of directories that need  to be excluded when running *-all commands.
 	ExcludesFile string
 
+	// Path to a file with a list of directories that need to be included when running *-all commands.
+	IncludesFile string
+
+	// Name of the files with gitignore-style patterns of directories not to look for modules in when running *-all commands.
+	IgnoreFile string
+
+	// The names of the config files to look for in the directories matching the glob patterns, relative to the working
+	// directory, instead of the default config names when running *-all commands.
+	ConfigFilenameOverrides map[string]string
+
 	// Unix-style glob of directories to exclude when running *-all commands
 	ExcludeDirs []string
 
 	// Unix-style glob of directories to include when running *-all commands
 	IncludeDirs []string
 
+	// The max number of folders below the working directory to look for modules in when running *-all commands,
+	// 0 means unlimited.
+	MaxFolderDepth int
+
 	// If set to true, exclude all directories by default when running *-all commands
 	// Is set automatically if IncludeDirs is set
 	ExcludeByDefault bool
@@ -248,9 +317,15 @@ type TerragruntOptions struct {
 	// If True then HCL from StdIn must should be formatted.
 	HclFromStdin bool
 
+	// If True then hclfmt also canonicalizes the `.tf.json` and `.hcl.json` files.
+	HclIncludeJSON bool
+
 	// The file path that terragrunt should use when rendering the terragrunt.hcl config as json.
 	JSONOut string
 
+	// The file path that terragrunt should use when rendering the inputs of the terragrunt.hcl config as tfvars json.
+	TFVarsOut string
+
 	// When used with `run-all`, restrict the modules in the stack to only those that include at least one of the files
 	// in this list.
 	ModulesThatInclude []string
@@ -259,6 +334,11 @@ type TerragruntOptions struct {
 	// in this list.
 	UnitsReading []string
 
+	// When used with `run-all`, reuse the dependency graph resolved by a previous run in the same process as long as
+	// the options, feature flags and environment variables are the same and none of the configuration files it was
+	// resolved from have changed.
+	GraphCache bool
+
 	// A command that can be used to run Terragrunt with the given options. This is useful for running Terragrunt
 	// multiple times (e.g. when spinning up a stack of Terraform modules). The actual command is normally defined
 	// in the cli package, which depends on almost all other packages, so we declare it here so that other
@@ -274,6 +354,13 @@ type TerragruntOptions struct {
 	// command for more info.
 	AwsProviderPatchOverrides map[string]string
 
+	// The name of the providers, e.g. `aws` or `google`, whose blocks nested within modules are patched by the
+	// aws-provider-patch command.
+	AwsProviderPatchProviderName string
+
+	// If set to true, the aws-provider-patch command only prints the diff of the files it would patch.
+	AwsProviderPatchDryRun bool
+
 	// True if is required to show dependent modules and confirm action
 	CheckDependentModules bool
 
@@ -289,9 +376,21 @@ type TerragruntOptions struct {
 	// Include fields metadata in render-json
 	RenderJSONWithMetadata bool
 
+	// If set to true, render-json renders the config of all the units in the working dir into a single bundle.
+	RenderJSONAll bool
+
+	// The file to write the bundle of the rendered configs to with RenderJSONAll.
+	RenderJSONBundleOut string
+
+	// The format render-json renders the config in, `json` or `yaml`.
+	RenderJSONFormat string
+
 	// Disable TF output formatting
 	ForwardTFStdout bool
 
+	// If set to true, fail the OpenTofu/Terraform commands whose JSON diagnostics, printed with -json, contain warnings.
+	TFWarningsAsErrors bool
+
 	// Fail execution if is required to create S3 bucket
 	FailIfBucketCreationRequired bool
 
@@ -301,6 +400,15 @@ type TerragruntOptions struct {
 	// Disables validation terraform command
 	DisableCommandValidation bool
 
+	// If set to true, the variables of the `.env` file of the unit are loaded into the environment of tofu/terraform.
+	LoadDotEnv bool
+
+	// If set to true, the `output` command prints the outputs of the unit merged with the outputs of its dependencies.
+	OutputMerge bool
+
+	// If set to true, it is an error for an `include` or a `find_in_parent_folders` to resolve to a file outside the git repository of the config.
+	RestrictIncludesToRepo bool
+
 	// Variables for usage in scaffolding.
 	ScaffoldVars []string
 
@@ -313,6 +421,15 @@ type TerragruntOptions struct {
 	// Name of the root Terragrunt configuration file, if used.
 	ScaffoldRootFileName string
 
+	// The ref of the module sources to replace by the `source bump` command.
+	SourceBumpFrom string
+
+	// The ref the module sources are bumped to by the `source bump` command.
+	SourceBumpTo string
+
+	// If set to true, the `source bump` command only prints the module sources that would be bumped.
+	SourceBumpDryRun bool
+
 	// Root directory for graph command.
 	GraphRoot string
 
@@ -334,15 +451,79 @@ type TerragruntOptions struct {
 	// The port of the Terragrunt Provider Cache server.
 	ProviderCachePort int
 
+	// The `host:port` address the Terragrunt Provider Cache server binds to, instead of the hostname and port.
+	ProviderCacheAddr string
+
 	// The list of remote registries to cached by Terragrunt Provider Cache server.
 	ProviderCacheRegistryNames []string
 
+	// The providers, as `registry/namespace/name` patterns, that are not cached by Terragrunt Provider Cache server.
+	ProviderCacheExcludes []string
+
+	// The units, as glob patterns of their paths relative to the working directory, that use the Terragrunt Provider Cache
+	// server. The other units download their providers directly. If empty, all the units use the cache server.
+	ProviderCacheFilters []string
+
+	// The maximum size in bytes of the provider cache directory, beyond which the least recently used providers are
+	// evicted once a provider is cached, 0 for no limit.
+	ProviderCacheMaxBytes int64
+
+	// The PEM encoded certificate and key files to serve the Terragrunt Provider Cache server over HTTPS.
+	ProviderCacheTLSCertFile string
+	ProviderCacheTLSKeyFile  string
+
+	// The PEM encoded CA file to require and verify the client certificates of the Terragrunt Provider Cache server.
+	ProviderCacheTLSClientCAFile string
+
 	// Folder to store output files.
 	OutputFolder string
 
 	// Folder to store JSON representation of output files.
 	JSONOutputFolder string
 
+	// Folder to store the stdout/stderr of each unit in a separate log file.
+	UnitLogsFolder string
+
+	// Controls how the output of the units is written when running a stack.
+	OutputMode OutputMode
+
+	// Controls in which order the output of the units is written when running a stack.
+	OutputOrder OutputOrder
+
+	// The size in bytes beyond which the buffered output of a unit is spilled to a temporary file on disk, 0 to always
+	// keep it in memory.
+	OutputSpillThreshold int64
+
+	// If set to true, fail the stack run if the same provider is pinned to different versions across units.
+	CheckProviderConsistency bool
+
+	// If set to true, run plan before applying the units of a stack and skip the units without changes.
+	SkipNoChanges bool
+
+	// If set to true, print the execution plan of the stack as JSON before running it.
+	PrintExecutionPlan bool
+
+	// If set to true, print the execution plan of the stack as JSON and exit without running it.
+	ExecutionPlanOnly bool
+
+	// The file to write the metrics of a stack run to, in the Prometheus text format.
+	MetricsFile string
+
+	// The file to stream the events of a stack run to as JSON lines, `-` for stdout.
+	EventsOut string
+
+	// If set to true, warn about the units of a stack that store their state locally.
+	WarnLocalState bool
+
+	// If set to true, randomize the order in which the units of the same level of a stack are run.
+	QueueShuffle bool
+
+	// The seed of the shuffle of the units, a random one is used if 0.
+	QueueShuffleSeed int64
+
+	// If set to true, run the units of a stack one at a time, in dependency order then sorted by path.
+	Sequential bool
+
 	// The command and arguments that can be used to fetch authentication configurations.
 	// Terragrunt invokes this command before running tofu/terraform operations for each working directory.
 	AuthProviderCmd string
@@ -350,6 +531,17 @@ type TerragruntOptions struct {
 	// Allows to skip the output of all dependencies. Intended for use with `hclvalidate` command.
 	SkipOutput bool
 
+	// The list of HCL functions that are allowed to be called in the Terragrunt configuration. If empty, all functions
+	// are allowed, except the ones in DeniedFunctions.
+	AllowedFunctions []string
+
+	// The list of HCL functions that are not allowed to be called in the Terragrunt configuration.
+	DeniedFunctions []string
+
+	// Mock outputs set from the command line, keyed by `<dependency name>.<output name>`. They are merged into the
+	// `mock_outputs` of the matching dependency blocks, taking precedence over the values set in the config.
+	MockOutputs map[string]string
+
 	// Flag to enable engine for running IaC operations.
 	EngineEnabled bool
 
@@ -469,6 +661,8 @@ func NewTerragruntOptionsWithWriters(stdout, stderr io.Writer) *TerragruntOption
 	return &TerragruntOptions{
 		TerraformPath:                  DefaultWrappedPath,
 		ExcludesFile:                   defaultExcludesFile,
+		IgnoreFile:                     defaultIgnoreFile,
+		AwsProviderPatchProviderName:   defaultAwsProviderPatchProviderName,
 		OriginalTerraformCommand:       "",
 		TerraformCommand:               "",
 		AutoInit:                       true,
@@ -481,6 +675,7 @@ func NewTerragruntOptionsWithWriters(stdout, stderr io.Writer) *TerragruntOption
 		Env:                            map[string]string{},
 		Source:                         "",
 		SourceMap:                      map[string]string{},
+		ConfigFilenameOverrides:        map[string]string{},
 		SourceUpdate:                   false,
 		IgnoreDependencyErrors:         false,
 		IgnoreDependencyOrder:          false,
@@ -504,6 +699,9 @@ func NewTerragruntOptionsWithWriters(stdout, stderr io.Writer) *TerragruntOption
 		UsePartialParseConfigCache:     false,
 		ForwardTFStdout:                false,
 		JSONOut:                        DefaultJSONOutName,
+		RenderJSONBundleOut:            DefaultRenderJSONBundleOutName,
+		RenderJSONFormat:               DefaultRenderJSONFormat,
+		TFVarsOut:                      DefaultTFVarsOutName,
 		TerraformImplementation:        UnknownImpl,
 		JSONDisableDependentModules:    false,
 		RunTerragrunt: func(ctx context.Context, opts *TerragruntOptions) error {
@@ -512,6 +710,8 @@ func NewTerragruntOptionsWithWriters(stdout, stderr io.Writer) *TerragruntOption
 		ProviderCacheRegistryNames: defaultProviderCacheRegistryNames,
 		OutputFolder:               "",
 		JSONOutputFolder:           "",
+		OutputMode:                 OutputModeStream,
+		OutputOrder:                OutputOrderFinish,
 		FeatureFlags:               xsync.NewMapOf[string, string](),
 		ReadFiles:                  xsync.NewMapOf[string, []string](),
 		ExperimentMode:             false,
@@ -606,6 +806,7 @@ func (opts *TerragruntOptions) Clone(terragruntConfigPath string) (*TerragruntOp
 		TerraformCliArgs:             util.CloneStringList(opts.TerraformCliArgs),
 		WorkingDir:                   workingDir,
 		RootWorkingDir:               opts.RootWorkingDir,
+		ConfigSearchUp:               opts.ConfigSearchUp,
 		Logger: opts.Logger.WithFields(log.Fields{
 			placeholders.WorkDirKeyName:     workingDir,
 			placeholders.DownloadDirKeyName: opts.DownloadDir,
@@ -613,7 +814,11 @@ func (opts *TerragruntOptions) Clone(terragruntConfigPath string) (*TerragruntOp
 		LogLevel:                       opts.LogLevel,
 		LogFormatter:                   opts.LogFormatter,
 		ValidateStrict:                 opts.ValidateStrict,
+		WarnRedundantInputs:            opts.WarnRedundantInputs,
 		Env:                            util.CloneStringMap(opts.Env),
+		LoadDotEnv:                     opts.LoadDotEnv,
+		OutputMerge:                    opts.OutputMerge,
+		RestrictIncludesToRepo:         opts.RestrictIncludesToRepo,
 		Source:                         opts.Source,
 		SourceMap:                      opts.SourceMap,
 		SourceUpdate:                   opts.SourceUpdate,
@@ -625,6 +830,7 @@ func (opts *TerragruntOptions) Clone(terragruntConfigPath string) (*TerragruntOp
 		IgnoreDependencyOrder:          opts.IgnoreDependencyOrder,
 		IgnoreExternalDependencies:     opts.IgnoreExternalDependencies,
 		IncludeExternalDependencies:    opts.IncludeExternalDependencies,
+		ExternalDependencyOutputsOnly:  opts.ExternalDependencyOutputsOnly,
 		Writer:                         opts.Writer,
 		ErrWriter:                      opts.ErrWriter,
 		MaxFoldersToCheck:              opts.MaxFoldersToCheck,
@@ -633,20 +839,29 @@ func (opts *TerragruntOptions) Clone(terragruntConfigPath string) (*TerragruntOp
 		RetrySleepInterval:             opts.RetrySleepInterval,
 		RetryableErrors:                util.CloneStringList(opts.RetryableErrors),
 		ExcludesFile:                   opts.ExcludesFile,
+		IncludesFile:                   opts.IncludesFile,
+		IgnoreFile:                     opts.IgnoreFile,
+		ConfigFilenameOverrides:        opts.ConfigFilenameOverrides,
 		ExcludeDirs:                    opts.ExcludeDirs,
 		IncludeDirs:                    opts.IncludeDirs,
+		MaxFolderDepth:                 opts.MaxFolderDepth,
 		ExcludeByDefault:               opts.ExcludeByDefault,
 		ModulesThatInclude:             opts.ModulesThatInclude,
 		UnitsReading:                   opts.UnitsReading,
+		GraphCache:                     opts.GraphCache,
 		ReadFiles:                      opts.ReadFiles,
 		Parallelism:                    opts.Parallelism,
 		StrictInclude:                  opts.StrictInclude,
 		RunTerragrunt:                  opts.RunTerragrunt,
 		AwsProviderPatchOverrides:      opts.AwsProviderPatchOverrides,
+		AwsProviderPatchProviderName:   opts.AwsProviderPatchProviderName,
+		AwsProviderPatchDryRun:         opts.AwsProviderPatchDryRun,
 		HclFile:                        opts.HclFile,
 		HclExclude:                     opts.HclExclude,
 		HclFromStdin:                   opts.HclFromStdin,
+		HclIncludeJSON:                 opts.HclIncludeJSON,
 		JSONOut:                        opts.JSONOut,
+		TFVarsOut:                      opts.TFVarsOut,
 		JSONLogFormat:                  opts.JSONLogFormat,
 		Check:                          opts.Check,
 		CheckDependentModules:          opts.CheckDependentModules,
@@ -654,6 +869,7 @@ func (opts *TerragruntOptions) Clone(terragruntConfigPath string) (*TerragruntOp
 		FetchDependencyOutputFromState: opts.FetchDependencyOutputFromState,
 		UsePartialParseConfigCache:     opts.UsePartialParseConfigCache,
 		ForwardTFStdout:                opts.ForwardTFStdout,
+		TFWarningsAsErrors:             opts.TFWarningsAsErrors,
 		FailIfBucketCreationRequired:   opts.FailIfBucketCreationRequired,
 		DisableBucketUpdate:            opts.DisableBucketUpdate,
 		TerraformImplementation:        opts.TerraformImplementation,
@@ -661,16 +877,44 @@ func (opts *TerragruntOptions) Clone(terragruntConfigPath string) (*TerragruntOp
 		ScaffoldVars:                   opts.ScaffoldVars,
 		ScaffoldVarFiles:               opts.ScaffoldVarFiles,
 		JSONDisableDependentModules:    opts.JSONDisableDependentModules,
+		RenderJSONWithMetadata:         opts.RenderJSONWithMetadata,
+		RenderJSONAll:                  opts.RenderJSONAll,
+		RenderJSONBundleOut:            opts.RenderJSONBundleOut,
+		RenderJSONFormat:               opts.RenderJSONFormat,
 		ProviderCache:                  opts.ProviderCache,
 		ProviderCacheToken:             opts.ProviderCacheToken,
 		ProviderCacheDir:               opts.ProviderCacheDir,
 		ProviderCacheRegistryNames:     opts.ProviderCacheRegistryNames,
+		ProviderCacheExcludes:          opts.ProviderCacheExcludes,
+		ProviderCacheFilters:           opts.ProviderCacheFilters,
+		ProviderCacheMaxBytes:          opts.ProviderCacheMaxBytes,
+		ProviderCacheTLSCertFile:       opts.ProviderCacheTLSCertFile,
+		ProviderCacheTLSKeyFile:        opts.ProviderCacheTLSKeyFile,
+		ProviderCacheTLSClientCAFile:   opts.ProviderCacheTLSClientCAFile,
 		DisableLogColors:               opts.DisableLogColors,
 		OutputFolder:                   opts.OutputFolder,
 		JSONOutputFolder:               opts.JSONOutputFolder,
+		UnitLogsFolder:                 opts.UnitLogsFolder,
+		OutputMode:                     opts.OutputMode,
+		OutputOrder:                    opts.OutputOrder,
+		OutputSpillThreshold:           opts.OutputSpillThreshold,
+		CheckProviderConsistency:       opts.CheckProviderConsistency,
+		SkipNoChanges:                  opts.SkipNoChanges,
+		PrintExecutionPlan:             opts.PrintExecutionPlan,
+		ExecutionPlanOnly:              opts.ExecutionPlanOnly,
+		MetricsFile:                    opts.MetricsFile,
+		EventsOut:                      opts.EventsOut,
+		WarnLocalState:                 opts.WarnLocalState,
+		QueueShuffle:                   opts.QueueShuffle,
+		QueueShuffleSeed:               opts.QueueShuffleSeed,
+		Sequential:                     opts.Sequential,
 		AuthProviderCmd:                opts.AuthProviderCmd,
 		SkipOutput:                     opts.SkipOutput,
+		MockOutputs:                    opts.MockOutputs,
+		AllowedFunctions:               opts.AllowedFunctions,
+		DeniedFunctions:                opts.DeniedFunctions,
 		DisableLog:                     opts.DisableLog,
+		Quiet:                          opts.Quiet,
 		EngineEnabled:                  opts.EngineEnabled,
 		EngineCachePath:                opts.EngineCachePath,
 		EngineLogLevel:                 opts.EngineLogLevel,
@@ -687,6 +931,9 @@ func (opts *TerragruntOptions) Clone(terragruntConfigPath string) (*TerragruntOp
 		Errors:                 cloneErrorsConfig(opts.Errors),
 		ScaffoldNoIncludeRoot:  opts.ScaffoldNoIncludeRoot,
 		ScaffoldRootFileName:   opts.ScaffoldRootFileName,
+		SourceBumpFrom:         opts.SourceBumpFrom,
+		SourceBumpTo:           opts.SourceBumpTo,
+		SourceBumpDryRun:       opts.SourceBumpDryRun,
 		Headless:               opts.Headless,
 		LogDisableErrorSummary: opts.LogDisableErrorSummary,
 	}, nil
@@ -839,6 +1086,25 @@ func (opts *TerragruntOptions) DidReadFile(file, unit string) bool {
 	return false
 }
 
+// DidReadFileMatching checks if a file matching the given glob pattern, e.g. `/live/config/**`, was read by a given unit.
+func (opts *TerragruntOptions) DidReadFileMatching(pattern, unit string) bool {
+	if opts.ReadFiles == nil {
+		return false
+	}
+
+	found := false
+
+	opts.ReadFiles.Range(func(file string, units []string) bool {
+		if util.MatchGlobPath(pattern, file) && util.ListContainsElement(units, unit) {
+			found = true
+		}
+
+		return !found
+	})
+
+	return found
+}
+
 // CloneReadFiles creates a copy of the ReadFiles map.
 func (opts *TerragruntOptions) CloneReadFiles(readFiles *xsync.MapOf[string, []string]) {
 	if readFiles == nil {
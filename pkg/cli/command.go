@@ -152,6 +152,13 @@ func (cmd *Command) Run(ctx *Context, args Args) (err error) {
 	return nil
 }
 
+// ParseFlags parses the given args for the flags of the command, setting their destinations, and returns the args that
+// are not flags of the command. This is useful for the commands run as the final commands by their parent, such as the
+// commands skipped with SkipRunning.
+func (cmd *Command) ParseFlags(args Args) (Args, error) {
+	return cmd.parseFlags(args.Slice())
+}
+
 func (cmd *Command) parseFlags(args []string) ([]string, error) {
 	var undefArgs []string
 
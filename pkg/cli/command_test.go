@@ -292,6 +292,30 @@ func TestCommandSubcommand(t *testing.T) {
 	}
 }
 
+func TestCommandParseFlags(t *testing.T) {
+	t.Parallel()
+
+	var (
+		format string
+		all    bool
+	)
+
+	command := cli.Command{
+		Name: "foo",
+		Flags: cli.Flags{
+			&cli.GenericFlag[string]{Name: "format", Destination: &format},
+			&cli.BoolFlag{Name: "all", Destination: &all},
+		},
+	}
+
+	args, err := command.ParseFlags(cli.Args{"-format", "ndjson", "-input=false", "--all", "bar"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "ndjson", format)
+	assert.True(t, all)
+	assert.Equal(t, cli.Args{"-input=false", "bar"}, args)
+}
+
 func TestCommandVisibleSubcommand(t *testing.T) {
 	t.Parallel()
 
@@ -18,6 +18,7 @@ type Formatter struct {
 	placeholders   placeholders.Placeholders
 	disableColors  bool
 	relativePather *options.RelativePather
+	disabledLevels log.Levels
 	mu             sync.Mutex
 }
 
@@ -30,7 +31,7 @@ func NewFormatter(phs placeholders.Placeholders) *Formatter {
 
 // Format implements logrus.Format.
 func (formatter *Formatter) Format(entry *log.Entry) ([]byte, error) {
-	if formatter.placeholders == nil {
+	if formatter.placeholders == nil || formatter.disabledLevels.Contains(entry.Level) {
 		return nil, nil
 	}
 
@@ -65,6 +66,11 @@ func (formatter *Formatter) Format(entry *log.Entry) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// DisableLevels disables the output of the entries with the given levels, regardless of the logger level.
+func (formatter *Formatter) DisableLevels(levels ...log.Level) {
+	formatter.disabledLevels = append(formatter.disabledLevels, levels...)
+}
+
 // DisableColors disables log color.
 func (formatter *Formatter) DisableColors() {
 	formatter.disableColors = true
@@ -3,7 +3,9 @@ package remote
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"reflect"
 	"sync"
 
@@ -53,6 +55,9 @@ func (state *RemoteState) String() string {
 type RemoteStateGenerate struct {
 	Path     string `cty:"path" mapstructure:"path"`
 	IfExists string `cty:"if_exists" mapstructure:"if_exists"`
+	// Partial generates a backend block with only the backend type, leaving the backend configuration to be passed
+	// with `-backend-config` when running `init`, e.g. by a CI pipeline.
+	Partial bool `cty:"partial" mapstructure:"partial"`
 }
 
 type RemoteStateInitializer interface {
@@ -84,6 +89,10 @@ func (state *RemoteState) Validate() error {
 		return errors.New(ErrRemoteBackendMissing)
 	}
 
+	if state.Generate != nil && (state.Generate.Path == "" || state.Generate.IfExists == "") {
+		return errors.New(ErrGenerateAttrMissingField)
+	}
+
 	return nil
 }
 
@@ -121,6 +130,12 @@ func (state *RemoteState) NeedsInit(terragruntOptions *options.TerragruntOptions
 		return false, nil
 	}
 
+	// The backend configuration of a partial backend is passed with `-backend-config` outside of Terragrunt, so there
+	// is nothing for Terragrunt to initialize or compare against.
+	if state.IsPartial() {
+		return false, nil
+	}
+
 	// Remote state not configured
 	if parsedState == nil {
 		return true, nil
@@ -137,6 +152,64 @@ func (state *RemoteState) NeedsInit(terragruntOptions *options.TerragruntOptions
 	return false, nil
 }
 
+// IsPartial returns true if the remote state generates a partial backend block, with only the backend type.
+func (state *RemoteState) IsPartial() bool {
+	return state.Generate != nil && state.Generate.Partial
+}
+
+// defaultS3WorkspaceKeyPrefix is the workspace_key_prefix of the s3 backend when it is not set.
+const defaultS3WorkspaceKeyPrefix = "env:"
+
+// StateLocation returns an identifier of the location the state is stored at with this remote state, e.g.
+// `s3://bucket/key`, so that the remote states storing the state at the same location can be detected. For the
+// backends whose location attributes are not known, the whole backend configuration identifies the location. An empty
+// string is returned if the location is relative to the working directory of the unit, as with a relative `path` of the
+// local backend, or if the backend configuration is passed at init time with a partial backend.
+func (state *RemoteState) StateLocation() string {
+	if state.IsPartial() {
+		return ""
+	}
+
+	get := func(name string) string {
+		if val, ok := state.Config[name]; ok && val != nil {
+			return fmt.Sprint(val)
+		}
+
+		return ""
+	}
+
+	switch state.Backend {
+	case "s3":
+		location := fmt.Sprintf("s3://%s/%s", get("bucket"), get("key"))
+
+		// The states of the non-default workspaces are stored under the workspace key prefix. The workspace a unit
+		// runs in is not known from its configuration, so the units with different prefixes are assumed to use them.
+		if prefix := get("workspace_key_prefix"); prefix != "" && prefix != defaultS3WorkspaceKeyPrefix {
+			location += "?workspace_key_prefix=" + prefix
+		}
+
+		return location
+	case "gcs":
+		return fmt.Sprintf("gcs://%s/%s", get("bucket"), get("prefix"))
+	case "azurerm":
+		return fmt.Sprintf("azurerm://%s/%s/%s", get("storage_account_name"), get("container_name"), get("key"))
+	case "local":
+		if path := get("path"); filepath.IsAbs(path) {
+			return "local://" + filepath.ToSlash(path)
+		}
+
+		return ""
+	}
+
+	// The keys of the maps are sorted when marshalled, so the same configurations give the same location.
+	config, err := json.Marshal(state.Config)
+	if err != nil {
+		return ""
+	}
+
+	return state.Backend + ":" + string(config)
+}
+
 // DiffersFrom returns true if this remote state is different than
 // the given remote state that is currently being used by terraform.
 func (state *RemoteState) DiffersFrom(existingBackend *TerraformBackend, terragruntOptions *options.TerragruntOptions) bool {
@@ -236,6 +309,11 @@ func (state *RemoteState) GenerateTerraformCode(terragruntOptions *options.Terra
 		config = initializer.GetTerraformInitArgs(config)
 	}
 
+	// A partial backend block only has the backend type, the rest of the configuration is passed with `-backend-config`.
+	if state.Generate.Partial {
+		config = nil
+	}
+
 	// Convert the IfExists setting to the internal enum representation before calling generate.
 	ifExistsEnum, err := codegen.GenerateConfigExistsFromString(state.Generate.IfExists)
 	if err != nil {
@@ -262,6 +340,7 @@ func (state *RemoteState) GenerateTerraformCode(terragruntOptions *options.Terra
 var (
 	ErrRemoteBackendMissing             = errors.New("the remote_state.backend field cannot be empty")
 	ErrGenerateCalledWithNoGenerateAttr = errors.New("generate code routine called when no generate attribute is configured")
+	ErrGenerateAttrMissingField         = errors.New("the remote_state.generate attribute requires both the path and if_exists fields")
 )
 
 type BucketCreationNotAllowed string
@@ -1,11 +1,15 @@
 package remote_test
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/remote"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -127,6 +131,125 @@ func TestToTerraformInitArgsNoBackendConfigs(t *testing.T) {
 	}
 }
 
+func TestGenerateTerraformCodePartial(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	remoteState := remote.RemoteState{
+		Backend: "s3",
+		Generate: &remote.RemoteStateGenerate{
+			Path:     "backend.tf",
+			IfExists: "overwrite_terragrunt",
+			Partial:  true,
+		},
+		Config: map[string]interface{}{
+			"bucket": "my-bucket",
+			"key":    "terraform.tfstate",
+			"region": "us-east-1",
+		},
+	}
+
+	require.NoError(t, remoteState.GenerateTerraformCode(opts))
+
+	contents, err := os.ReadFile(filepath.Join(workingDir, "backend.tf"))
+	require.NoError(t, err)
+
+	file, diags := hclsyntax.ParseConfig(contents, "backend.tf", hcl.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+
+	// The generated backend block only has the backend type, without any configuration keys.
+	terraformBlocks := file.Body.(*hclsyntax.Body).Blocks
+	require.Len(t, terraformBlocks, 1)
+	assert.Equal(t, "terraform", terraformBlocks[0].Type)
+
+	backendBlocks := terraformBlocks[0].Body.Blocks
+	require.Len(t, backendBlocks, 1)
+	assert.Equal(t, "backend", backendBlocks[0].Type)
+	assert.Equal(t, []string{"s3"}, backendBlocks[0].Labels)
+	assert.Empty(t, backendBlocks[0].Body.Attributes)
+	assert.Empty(t, backendBlocks[0].Body.Blocks)
+
+	// No -backend-config args are passed by Terragrunt, so the ones given to `init`, e.g. by a CI pipeline, complete the
+	// backend configuration, and Terragrunt does not try to initialize the backend itself.
+	assert.Empty(t, remoteState.ToTerraformInitArgs())
+
+	needsInit, err := remoteState.NeedsInit(opts)
+	require.NoError(t, err)
+	assert.False(t, needsInit)
+}
+
+func TestStateLocation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		remoteState      remote.RemoteState
+		expectedLocation string
+	}{
+		{
+			"s3",
+			remote.RemoteState{Backend: "s3", Config: map[string]interface{}{"bucket": "foo", "key": "bar/terraform.tfstate", "region": "us-east-1"}},
+			"s3://foo/bar/terraform.tfstate",
+		},
+		{
+			"s3 default workspace key prefix",
+			remote.RemoteState{Backend: "s3", Config: map[string]interface{}{"bucket": "foo", "key": "bar/terraform.tfstate", "workspace_key_prefix": "env:"}},
+			"s3://foo/bar/terraform.tfstate",
+		},
+		{
+			"s3 workspace key prefix",
+			remote.RemoteState{Backend: "s3", Config: map[string]interface{}{"bucket": "foo", "key": "terraform.tfstate", "workspace_key_prefix": "bar"}},
+			"s3://foo/terraform.tfstate?workspace_key_prefix=bar",
+		},
+		{
+			"gcs",
+			remote.RemoteState{Backend: "gcs", Config: map[string]interface{}{"project": "foo-123456", "bucket": "foo", "prefix": "bar"}},
+			"gcs://foo/bar",
+		},
+		{
+			"azurerm",
+			remote.RemoteState{Backend: "azurerm", Config: map[string]interface{}{"storage_account_name": "foo", "container_name": "tfstate", "key": "bar.tfstate"}},
+			"azurerm://foo/tfstate/bar.tfstate",
+		},
+		{
+			"local absolute path",
+			remote.RemoteState{Backend: "local", Config: map[string]interface{}{"path": "/states/bar.tfstate"}},
+			"local:///states/bar.tfstate",
+		},
+		{
+			"local relative path",
+			remote.RemoteState{Backend: "local", Config: map[string]interface{}{"path": "terraform.tfstate"}},
+			"",
+		},
+		{
+			"unknown backend",
+			remote.RemoteState{Backend: "consul", Config: map[string]interface{}{"path": "bar", "address": "consul:8500"}},
+			`consul:{"address":"consul:8500","path":"bar"}`,
+		},
+		{
+			"partial",
+			remote.RemoteState{
+				Backend:  "s3",
+				Generate: &remote.RemoteStateGenerate{Path: "backend.tf", IfExists: "overwrite", Partial: true},
+				Config:   map[string]interface{}{"bucket": "foo", "key": "bar"},
+			},
+			"",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expectedLocation, tc.remoteState.StateLocation())
+		})
+	}
+}
+
 func TestDiffersFrom(t *testing.T) {
 	t.Parallel()
 
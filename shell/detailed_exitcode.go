@@ -5,7 +5,8 @@ import (
 )
 
 const (
-	DetailedExitCodeError = 1
+	DetailedExitCodeError   = 1
+	DetailedExitCodeChanges = 2
 )
 
 // DetailedExitCode is the TF detailed exit code. https://opentofu.org/docs/cli/commands/plan/
@@ -1,12 +1,17 @@
 package cache
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"net"
+	"os"
 	"strconv"
 	"time"
 
+	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
 	"github.com/gruntwork-io/terragrunt/terraform/cache/handlers"
+	"github.com/gruntwork-io/terragrunt/terraform/cache/models"
 	"github.com/gruntwork-io/terragrunt/terraform/cache/services"
 )
 
@@ -37,6 +42,15 @@ func WithPort(port int) Option {
 	}
 }
 
+// WithAddr sets the `host:port` address the server binds to, instead of the hostname and port. If the host is
+// unspecified, e.g. `0.0.0.0`, the server listens on all interfaces and is still advertised with the hostname.
+func WithAddr(addr string) Option {
+	return func(cfg Config) Config {
+		cfg.addr = addr
+		return cfg
+	}
+}
+
 func WithToken(token string) Option {
 	return func(cfg Config) Config {
 		cfg.token = token
@@ -58,6 +72,34 @@ func WithProviderHandlers(handlers ...handlers.ProviderHandler) Option {
 	}
 }
 
+// WithCacheExcludes sets the providers that are not cached, given as `registry/namespace/name` patterns, where each
+// part can be a `*` wildcard. The requests for these providers are proxied to the upstream registry.
+func WithCacheExcludes(excludes ...string) Option {
+	return func(cfg Config) Config {
+		cfg.cacheExcludes = models.ParseProviders(excludes...)
+		return cfg
+	}
+}
+
+// WithTLS serves the cache server over HTTPS with the given PEM encoded certificate and key files.
+func WithTLS(certFile, keyFile string) Option {
+	return func(cfg Config) Config {
+		cfg.tlsCertFile = certFile
+		cfg.tlsKeyFile = keyFile
+
+		return cfg
+	}
+}
+
+// WithTLSClientCA requires the clients to present a certificate signed by one of the CAs of the given PEM encoded file.
+// It only takes effect when the server is served over HTTPS, see `WithTLS`.
+func WithTLSClientCA(caFile string) Option {
+	return func(cfg Config) Config {
+		cfg.tlsClientCAFile = caFile
+		return cfg
+	}
+}
+
 func WithLogger(logger log.Logger) Option {
 	return func(cfg Config) Config {
 		cfg.logger = logger
@@ -68,11 +110,17 @@ func WithLogger(logger log.Logger) Option {
 type Config struct {
 	hostname        string
 	port            int
+	addr            string
 	token           string
 	shutdownTimeout time.Duration
 
 	services         []services.Service
 	providerHandlers handlers.ProviderHandlers
+	cacheExcludes    models.Providers
+
+	tlsCertFile     string
+	tlsKeyFile      string
+	tlsClientCAFile string
 
 	logger log.Logger
 }
@@ -95,6 +143,63 @@ func (cfg *Config) WithOptions(opts ...Option) *Config {
 	return cfg
 }
 
-func (cfg *Config) Addr() string {
-	return net.JoinHostPort(cfg.hostname, strconv.Itoa(cfg.port))
+// Addr returns the address the server binds to. It returns an error if the address set by `WithAddr` is invalid.
+func (cfg *Config) Addr() (string, error) {
+	if cfg.addr == "" {
+		return net.JoinHostPort(cfg.hostname, strconv.Itoa(cfg.port)), nil
+	}
+
+	_, port, err := net.SplitHostPort(cfg.addr)
+	if err != nil {
+		return "", errors.Errorf("invalid address %q, expected host:port: %w", cfg.addr, err)
+	}
+
+	if port, err := strconv.ParseUint(port, 10, 16); err != nil || port == 0 {
+		return "", errors.Errorf("invalid address %q, expected a port between 1 and 65535", cfg.addr)
+	}
+
+	return cfg.addr, nil
+}
+
+// TLSConfig returns the TLS configuration of the server, or nil if the server is served over plain HTTP.
+// It returns an error if the certificate, key or client CA files cannot be loaded.
+func (cfg *Config) TLSConfig() (*tls.Config, error) {
+	if cfg.tlsCertFile == "" && cfg.tlsKeyFile == "" {
+		if cfg.tlsClientCAFile != "" {
+			return nil, errors.Errorf("the TLS client CA file %s requires a TLS certificate and key", cfg.tlsClientCAFile)
+		}
+
+		return nil, nil
+	}
+
+	if cfg.tlsCertFile == "" || cfg.tlsKeyFile == "" {
+		return nil, errors.Errorf("both the TLS certificate and key files are required")
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.tlsCertFile, cfg.tlsKeyFile)
+	if err != nil {
+		return nil, errors.Errorf("error loading the TLS certificate %s and key %s: %w", cfg.tlsCertFile, cfg.tlsKeyFile, err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if cfg.tlsClientCAFile != "" {
+		caPEM, err := os.ReadFile(cfg.tlsClientCAFile)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caPEM) {
+			return nil, errors.Errorf("no PEM encoded certificates found in the TLS client CA file %s", cfg.tlsClientCAFile)
+		}
+
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
 }
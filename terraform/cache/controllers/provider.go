@@ -25,6 +25,9 @@ type ProviderController struct {
 
 	AuthMiddleware   echo.MiddlewareFunc
 	ProviderHandlers []handlers.ProviderHandler
+
+	// CacheExcludes are the providers that are never cached, the requests for them are proxied to the upstream registry.
+	CacheExcludes models.Providers
 }
 
 // Endpoints implements controllers.Endpointer.Endpoints
@@ -96,6 +99,11 @@ func (controller *ProviderController) getPlatformsAction(ctx echo.Context) (er e
 		Arch:         arch,
 	}
 
+	if cacheRequestID != "" && controller.CacheExcludes.Find(provider) != nil {
+		// The provider is excluded from the cache, so we act as a proxy, the same way as without a cache request.
+		cacheRequestID = ""
+	}
+
 	for _, handler := range controller.ProviderHandlers {
 		if handler.CanHandleProvider(provider) {
 			if err := handler.GetPlatform(ctx, provider, controller.DownloaderController, cacheRequestID); err == nil {
@@ -31,8 +31,13 @@ func (router *Router) Group(urlPath string) *Router {
 }
 
 func (router *Router) URL() *url.URL {
+	scheme := "http"
+	if router.TLSListener != nil {
+		scheme = "https"
+	}
+
 	return &url.URL{
-		Scheme: "http",
+		Scheme: scheme,
 		Host:   router.Server.Addr,
 		Path:   router.urlPath,
 	}
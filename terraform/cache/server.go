@@ -3,8 +3,11 @@ package cache
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
 	"net/http"
+	"strconv"
+	"syscall"
 
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/terraform/cache/controllers"
@@ -38,6 +41,7 @@ func NewServer(opts ...Option) *Server {
 		AuthMiddleware:       authMiddleware,
 		DownloaderController: downloaderController,
 		ProviderHandlers:     cfg.providerHandlers,
+		CacheExcludes:        cfg.cacheExcludes,
 	}
 
 	discoveryController := &controllers.DiscoveryController{
@@ -68,14 +72,39 @@ func (server *Server) DiscoveryURL(ctx context.Context, registryName string) (*h
 }
 
 // Listen starts listening to the given configuration address. It also automatically chooses a free port if not explicitly specified.
+// If TLS is configured, the certificate files are validated before listening and the connections are served over TLS.
 func (server *Server) Listen() (net.Listener, error) {
-	ln, err := net.Listen("tcp", server.Addr())
+	tlsConfig, err := server.Config.TLSConfig()
 	if err != nil {
+		return nil, err
+	}
+
+	addr, err := server.Config.Addr()
+	if err != nil {
+		return nil, err
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		if errors.Is(err, syscall.EADDRINUSE) {
+			return nil, errors.Errorf("the address %s of Terragrunt Cache server is already in use", addr)
+		}
+
 		return nil, errors.New(err)
 	}
 
 	server.Server.Addr = ln.Addr().String()
 
+	// When listening on all interfaces, the server is advertised with the hostname instead of the unspecified address.
+	if tcpAddr, ok := ln.Addr().(*net.TCPAddr); ok && tcpAddr.IP.IsUnspecified() {
+		server.Server.Addr = net.JoinHostPort(server.hostname, strconv.Itoa(tcpAddr.Port))
+	}
+
+	if tlsConfig != nil {
+		ln = tls.NewListener(ln, tlsConfig)
+		server.TLSListener = ln
+	}
+
 	server.logger.Infof("Terragrunt Cache server is listening on %s", ln.Addr())
 
 	return ln, nil
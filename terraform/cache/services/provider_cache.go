@@ -10,6 +10,8 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -33,6 +35,10 @@ const (
 
 	retryDelayFetchFile = time.Second * 2
 	maxRetriesFetchFile = 5
+
+	// The provider cache has the same file structure as the terraform plugin_cache_dir, the package of a provider is
+	// located at `hostname/namespace/type/version/os_arch`.
+	packageDirDepth = 5
 )
 
 // Borrow the "unpack a zip cache into a target directory" logic from go-getter
@@ -196,6 +202,13 @@ func (cache *ProviderCache) addRequestID(requestID string) {
 // 2. Downloads the provider from the original registry, unpacks and saves it into the cache directory.
 func (cache *ProviderCache) warmUp(ctx context.Context) error {
 	if util.FileExists(cache.packageDir) {
+		// The modification time of the package directory is the last access time of the cached provider,
+		// used by `terragrunt provider-cache gc` to remove the providers that are no longer in use.
+		now := time.Now()
+		if err := os.Chtimes(cache.packageDir, now, now); err != nil {
+			cache.logger.Debugf("Failed to update the access time of the cached provider %s: %v", cache.Provider, err)
+		}
+
 		return nil
 	}
 
@@ -315,6 +328,15 @@ type ProviderService struct {
 
 	credsSource *cliconfig.CredentialsSource
 
+	// The maximum size in bytes of the cache directory, beyond which the least recently used providers are evicted, 0 for no limit.
+	maxCacheBytes int64
+	evictMu       sync.Mutex
+
+	// The package directories of the providers requested from this service, which are never evicted, since they are
+	// being served to the running `terraform init` commands.
+	inUsePackageDirs   map[string]bool
+	inUsePackageDirsMu sync.Mutex
+
 	logger log.Logger
 }
 
@@ -324,10 +346,19 @@ func NewProviderService(cacheDir, userCacheDir string, credsSource *cliconfig.Cr
 		userCacheDir:          userCacheDir,
 		providerCacheWarmUpCh: make(chan *ProviderCache),
 		credsSource:           credsSource,
+		inUsePackageDirs:      make(map[string]bool),
 		logger:                logger,
 	}
 }
 
+// WithMaxCacheBytes makes the service evict the least recently used providers of the cache directory, once a provider is
+// cached, until the size of the directory is back under `maxCacheBytes`. A value of 0 disables the limit.
+func (service *ProviderService) WithMaxCacheBytes(maxCacheBytes int64) *ProviderService {
+	service.maxCacheBytes = maxCacheBytes
+
+	return service
+}
+
 func (service *ProviderService) Logger() log.Logger {
 	return service.logger
 }
@@ -379,6 +410,10 @@ func (service *ProviderService) CacheProvider(ctx context.Context, requestID str
 		archivePath:     filepath.Join(service.tempDir, packageName+path.Ext(provider.Filename)),
 	}
 
+	service.inUsePackageDirsMu.Lock()
+	service.inUsePackageDirs[cache.packageDir] = true
+	service.inUsePackageDirsMu.Unlock()
+
 	select {
 	case service.providerCacheWarmUpCh <- cache:
 		// We need to wait for caching to start and only then release the client (Terraform) requestID. Otherwise, the client may call `WaitForCacheReady()` faster than `service.ReadyMuReady` will be lock.
@@ -472,5 +507,164 @@ func (service *ProviderService) startProviderCaching(ctx context.Context, cache
 
 	cache.ready = true
 
+	if service.maxCacheBytes > 0 {
+		if err := service.evictLeastRecentlyUsed(); err != nil {
+			service.logger.Warnf("Failed to evict providers from the cache directory %s: %v", service.cacheDir, err)
+		}
+	}
+
+	return nil
+}
+
+// cachedPackage is a provider package found in the cache directory.
+type cachedPackage struct {
+	dir        string
+	size       int64
+	accessTime time.Time
+}
+
+// evictLeastRecentlyUsed removes the packages of the cache directory, from the least recently accessed, until the size of
+// the directory is under the max cache size. The last access time of a package is the modification time of its directory,
+// which is updated every time the provider is requested. The packages requested from this service and the symlinks to the
+// user plugins directory are never evicted.
+func (service *ProviderService) evictLeastRecentlyUsed() error {
+	service.evictMu.Lock()
+	defer service.evictMu.Unlock()
+
+	packages, err := service.listCachedPackages()
+	if err != nil {
+		return err
+	}
+
+	var totalSize int64
+
+	for _, pkg := range packages {
+		totalSize += pkg.size
+	}
+
+	if totalSize <= service.maxCacheBytes {
+		return nil
+	}
+
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].accessTime.Before(packages[j].accessTime)
+	})
+
+	service.inUsePackageDirsMu.Lock()
+	defer service.inUsePackageDirsMu.Unlock()
+
+	for _, pkg := range packages {
+		if totalSize <= service.maxCacheBytes {
+			break
+		}
+
+		// Removing a symlink to the user plugins directory frees nothing and would only break the link for the next runs.
+		if pkg.size == 0 || service.inUsePackageDirs[pkg.dir] {
+			continue
+		}
+
+		if err := os.RemoveAll(pkg.dir); err != nil {
+			return errors.New(err)
+		}
+
+		if err := removeEmptyParentDirs(service.cacheDir, pkg.dir); err != nil {
+			return err
+		}
+
+		totalSize -= pkg.size
+
+		relPath, _ := filepath.Rel(service.cacheDir, pkg.dir)
+		service.logger.Infof("Evicted %s from the provider cache, the cache exceeds %d bytes", filepath.ToSlash(relPath), service.maxCacheBytes)
+	}
+
+	if totalSize > service.maxCacheBytes {
+		service.logger.Warnf("The provider cache %s still exceeds %d bytes, the remaining providers are in use", service.cacheDir, service.maxCacheBytes)
+	}
+
+	return nil
+}
+
+// listCachedPackages returns the packages of the cache directory with their size and last access time. The size of a
+// symlink to the user plugins directory is not counted, since its target is not stored in the cache directory.
+func (service *ProviderService) listCachedPackages() ([]cachedPackage, error) {
+	matches, err := filepath.Glob(filepath.Join(service.cacheDir, strings.Repeat("*"+string(filepath.Separator), packageDirDepth-1)+"*"))
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	packages := make([]cachedPackage, 0, len(matches))
+
+	for _, dir := range matches {
+		info, err := os.Lstat(dir)
+		if err != nil {
+			continue
+		}
+
+		pkg := cachedPackage{dir: dir, accessTime: info.ModTime()}
+
+		if info.Mode()&os.ModeSymlink == 0 {
+			if !info.IsDir() {
+				continue
+			}
+
+			if pkg.size, err = dirSize(dir); err != nil {
+				return nil, err
+			}
+		} else if targetInfo, err := os.Stat(dir); err == nil && targetInfo.ModTime().After(pkg.accessTime) {
+			pkg.accessTime = targetInfo.ModTime()
+		}
+
+		packages = append(packages, pkg)
+	}
+
+	return packages, nil
+}
+
+// dirSize returns the total size of the regular files of the given directory.
+func dirSize(dir string) (int64, error) {
+	var size int64
+
+	err := filepath.WalkDir(dir, func(_ string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		size += info.Size()
+
+		return nil
+	})
+	if err != nil {
+		return 0, errors.New(err)
+	}
+
+	return size, nil
+}
+
+// removeEmptyParentDirs removes the parent directories of the removed package directory, up to the cache directory, that are left empty.
+func removeEmptyParentDirs(cacheDir, packageDir string) error {
+	for dir := filepath.Dir(packageDir); dir != cacheDir && util.HasPathPrefix(dir, cacheDir); dir = filepath.Dir(dir) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return errors.New(err)
+		}
+
+		if len(entries) > 0 {
+			return nil
+		}
+
+		if err := os.Remove(dir); err != nil {
+			return errors.New(err)
+		}
+	}
+
 	return nil
 }
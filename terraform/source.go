@@ -64,7 +64,7 @@ func (src Source) EncodeSourceVersion() (string, error) {
 
 		var err error
 		if src.WalkWithSymlinks {
-			err = util.WalkWithSymlinks(sourceDir, func(path string, info os.FileInfo, err error) error {
+			err = util.WalkWithSymlinks(src.Logger, sourceDir, func(path string, info os.FileInfo, err error) error {
 				if err != nil {
 					// If we've encountered an error while walking the tree, give up
 					return err
@@ -1,7 +1,14 @@
 package terraform
 
 import (
+	"path/filepath"
+	"strings"
+
 	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsimple"
 	"github.com/hashicorp/terraform-config-inspect/tfconfig"
 )
 
@@ -24,6 +31,7 @@ const (
 	CommandNameForceUnlock    = "force-unlock"
 	CommandNameShow           = "show"
 	CommandNameVersion        = "version"
+	CommandNameWorkspace      = "workspace"
 
 	FlagNameDetailedExitCode = "-detailed-exitcode"
 	FlagNameHelpLong         = "-help"
@@ -42,6 +50,7 @@ const (
 	EnvNameTFPluginCacheMayBreakDependencyLockFile = "TF_PLUGIN_CACHE_MAY_BREAK_DEPENDENCY_LOCK_FILE"
 	EnvNameTFTokenFmt                              = "TF_TOKEN_%s"
 	EnvNameTFVarFmt                                = "TF_VAR_%s"
+	EnvNameTFWorkspace                             = "TF_WORKSPACE"
 
 	TerraformLockFile = ".terraform.lock.hcl"
 
@@ -70,3 +79,100 @@ func ModuleVariables(modulePath string) ([]string, []string, error) {
 
 	return required, optional, nil
 }
+
+// ModuleVariablesDefaults will return the default values of the optional variables defined in the downloaded terraform
+// modules, keyed by the variable name.
+func ModuleVariablesDefaults(modulePath string) (map[string]interface{}, error) {
+	module, diags := tfconfig.LoadModule(modulePath)
+	if diags.HasErrors() {
+		return nil, errors.New(diags)
+	}
+
+	defaults := map[string]interface{}{}
+
+	for _, variable := range module.Variables {
+		if !variable.Required {
+			defaults[variable.Name] = variable.Default
+		}
+	}
+
+	return defaults, nil
+}
+
+// ModuleProviderVersions will return the versions of the providers pinned by the module at the given path, keyed by
+// the provider source address, e.g. `hashicorp/aws`. The versions are read from the dependency lock file. If the module
+// has no lock file, the versions are read from the `required_providers` that are pinned to an exact version.
+func ModuleProviderVersions(modulePath string) (map[string]string, error) {
+	lockFilename := filepath.Join(modulePath, TerraformLockFile)
+
+	if util.FileExists(lockFilename) {
+		return lockFileProviderVersions(lockFilename)
+	}
+
+	module, diags := tfconfig.LoadModule(modulePath)
+	if diags.HasErrors() {
+		return nil, errors.New(diags)
+	}
+
+	versions := map[string]string{}
+
+	for name, provider := range module.RequiredProviders {
+		source := provider.Source
+		if source == "" {
+			source = "hashicorp/" + name
+		}
+
+		if len(provider.VersionConstraints) != 1 {
+			continue
+		}
+
+		if pinned := exactVersion(provider.VersionConstraints[0]); pinned != "" {
+			versions[shortProviderAddress(source)] = pinned
+		}
+	}
+
+	return versions, nil
+}
+
+func lockFileProviderVersions(filename string) (map[string]string, error) {
+	var lockFile struct {
+		Providers []struct {
+			Address string   `hcl:"address,label"`
+			Version string   `hcl:"version"`
+			Remain  hcl.Body `hcl:",remain"`
+		} `hcl:"provider,block"`
+	}
+
+	if err := hclsimple.DecodeFile(filename, nil, &lockFile); err != nil {
+		return nil, errors.New(err)
+	}
+
+	versions := map[string]string{}
+
+	for _, provider := range lockFile.Providers {
+		versions[shortProviderAddress(provider.Address)] = provider.Version
+	}
+
+	return versions, nil
+}
+
+// exactVersion returns the version of the given constraint if it pins an exact version, e.g. `= 1.2.3` or `1.2.3`.
+func exactVersion(constraint string) string {
+	constraint = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(constraint), "="))
+
+	if _, err := version.NewVersion(constraint); err != nil {
+		return ""
+	}
+
+	return constraint
+}
+
+// shortProviderAddress removes the public registry hostnames from the provider address, so that the same provider
+// has the same address in the lock files of OpenTofu and Terraform.
+func shortProviderAddress(address string) string {
+	for _, hostname := range []string{"registry.terraform.io/", "registry.opentofu.org/"} {
+		address = strings.TrimPrefix(address, hostname)
+	}
+
+	return address
+}
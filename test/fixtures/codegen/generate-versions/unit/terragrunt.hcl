@@ -0,0 +1,10 @@
+locals {
+  policy = read_terragrunt_config(find_in_parent_folders("versions-policy.hcl"))
+}
+
+generate "versions" {
+  path               = "versions.tf"
+  if_exists          = "overwrite_terragrunt"
+  required_version   = local.policy.locals.required_version
+  required_providers = local.policy.locals.required_providers
+}
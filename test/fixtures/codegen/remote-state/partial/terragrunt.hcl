@@ -0,0 +1,15 @@
+remote_state {
+  backend = "local"
+  generate = {
+    path      = "backend.tf"
+    if_exists = "overwrite_terragrunt"
+    partial   = true
+  }
+  config = {
+    path = "foo.tfstate"
+  }
+}
+
+terraform {
+  source = "../../module"
+}
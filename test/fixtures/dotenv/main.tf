@@ -0,0 +1,23 @@
+variable "from_dotenv" {
+  type = string
+}
+
+variable "from_env_vars" {
+  type = string
+}
+
+variable "from_inputs" {
+  type = string
+}
+
+output "from_dotenv" {
+  value = var.from_dotenv
+}
+
+output "from_env_vars" {
+  value = var.from_env_vars
+}
+
+output "from_inputs" {
+  value = var.from_inputs
+}
@@ -0,0 +1,12 @@
+terraform {
+  extra_arguments "env_vars" {
+    commands = ["apply", "output"]
+    env_vars = {
+      TF_VAR_from_env_vars = "env_vars"
+    }
+  }
+}
+
+inputs = {
+  from_inputs = "inputs"
+}
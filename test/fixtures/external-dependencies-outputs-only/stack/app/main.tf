@@ -0,0 +1,7 @@
+variable "external_name" {
+  type = string
+}
+
+output "external_name" {
+  value = "app received ${var.external_name}"
+}
@@ -0,0 +1,11 @@
+dependency "external" {
+  config_path = "../../external"
+
+  mock_outputs = {
+    name = "mock-external-output"
+  }
+}
+
+inputs = {
+  external_name = dependency.external.outputs.name
+}
@@ -0,0 +1,3 @@
+global_extra_arguments = {
+  apply = ["-lock-timeout=5m"]
+}
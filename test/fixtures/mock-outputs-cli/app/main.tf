@@ -0,0 +1,11 @@
+variable "name" {
+  type = string
+}
+
+variable "region" {
+  type = string
+}
+
+output "result" {
+  value = "${var.name} in ${var.region}"
+}
@@ -0,0 +1,14 @@
+dependency "dep" {
+  config_path = "../dep"
+
+  mock_outputs = {
+    name   = "config-mock"
+    region = "config-region"
+  }
+  mock_outputs_allowed_terraform_commands = ["plan"]
+}
+
+inputs = {
+  name   = dependency.dep.outputs.name
+  region = dependency.dep.outputs.region
+}
@@ -0,0 +1,7 @@
+output "name" {
+  value = "unit-a"
+}
+
+output "description" {
+  value = "Output of unit-a"
+}
@@ -0,0 +1,7 @@
+output "name" {
+  value = "unit-b"
+}
+
+output "description" {
+  value = "Output of unit-b"
+}
@@ -0,0 +1,7 @@
+output "name" {
+  value = "unit-c"
+}
+
+output "description" {
+  value = "Output of unit-c"
+}
@@ -0,0 +1,7 @@
+locals {
+  name = "app"
+}
+
+inputs = {
+  name = local.name
+}
@@ -0,0 +1,7 @@
+locals {
+  name = "db"
+}
+
+inputs = {
+  name = local.name
+}
@@ -0,0 +1,3 @@
+include "repo" {
+  path = find_in_parent_folders("repo.hcl")
+}
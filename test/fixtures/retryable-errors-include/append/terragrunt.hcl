@@ -0,0 +1,6 @@
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+retryable_errors       = ["(?s).*RequestLimitExceeded.*"]
+retryable_errors_merge = "append"
@@ -0,0 +1,5 @@
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+retryable_errors = ["(?s).*RequestLimitExceeded.*"]
@@ -0,0 +1,4 @@
+retryable_errors = [
+  "(?s).*Error installing provider.*",
+  "(?s).*connection reset by peer.*",
+]
@@ -0,0 +1,12 @@
+variable "value" {
+  type    = string
+  default = "a"
+}
+
+resource "terraform_data" "unit_a" {
+  input = var.value
+}
+
+output "value" {
+  value = terraform_data.unit_a.output
+}
@@ -0,0 +1,12 @@
+variable "value" {
+  type    = string
+  default = "b"
+}
+
+resource "terraform_data" "unit_b" {
+  input = var.value
+}
+
+output "value" {
+  value = terraform_data.unit_b.output
+}
@@ -0,0 +1,5 @@
+# unit-b doesn't consume the outputs of unit-a, so its plan has no changes when unit-a changes,
+# yet it must be applied after unit-a.
+dependencies {
+  paths = ["../unit-a"]
+}
@@ -0,0 +1,12 @@
+variable "value" {
+  type    = string
+  default = "c"
+}
+
+resource "terraform_data" "unit_c" {
+  input = var.value
+}
+
+output "value" {
+  value = terraform_data.unit_c.output
+}
@@ -0,0 +1,7 @@
+variable "name" {
+  type = string
+}
+
+output "result" {
+  value = "Hello, ${var.name}"
+}
@@ -0,0 +1,12 @@
+dependency "dep" {
+  config_path = "../dep"
+
+  mock_outputs = {
+    name = "mock"
+  }
+  mock_outputs_allowed_terraform_commands = ["validate"]
+}
+
+inputs = {
+  name = dependency.dep.outputs.name
+}
@@ -0,0 +1,8 @@
+locals {
+	# the relative path resolves outside of the unit directory
+	common = yamldecode(file(mark_as_read("../../config/common.yaml")))
+}
+
+inputs = {
+	name = local.common.name
+}
@@ -0,0 +1,3 @@
+inputs = {
+	name = "nothing"
+}
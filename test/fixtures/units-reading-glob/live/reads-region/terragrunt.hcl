@@ -0,0 +1,7 @@
+locals {
+	region = yamldecode(file(mark_as_read(find_in_parent_folders("config/region/us.yaml"))))
+}
+
+inputs = {
+	region = local.region.region
+}
@@ -0,0 +1,16 @@
+variable "region" {
+  type    = string
+  default = "us-east-1"
+}
+
+variable "tags" {
+  type = map(string)
+  default = {
+    team = "platform"
+  }
+}
+
+variable "instance_count" {
+  type    = number
+  default = 1
+}
@@ -0,0 +1,7 @@
+inputs = {
+  region = "us-east-1"
+  tags = {
+    team = "platform"
+  }
+  instance_count = 2
+}
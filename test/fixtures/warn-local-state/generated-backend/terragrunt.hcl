@@ -0,0 +1,11 @@
+generate "backend" {
+  path      = "backend.tf"
+  if_exists = "overwrite_terragrunt"
+  contents  = <<EOT
+terraform {
+  backend "local" {
+    path = "terraform.tfstate"
+  }
+}
+EOT
+}
@@ -0,0 +1 @@
+# No remote_state block nor generate block configuring a backend, the state is stored locally.
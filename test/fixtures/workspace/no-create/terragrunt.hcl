@@ -0,0 +1,4 @@
+terraform {
+  workspace        = "missing"
+  create_workspace = false
+}
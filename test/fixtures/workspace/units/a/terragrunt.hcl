@@ -0,0 +1,3 @@
+terraform {
+  workspace = "ws-${basename(get_terragrunt_dir())}"
+}
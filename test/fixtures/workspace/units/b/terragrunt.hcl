@@ -0,0 +1,7 @@
+locals {
+  env = "stage"
+}
+
+terraform {
+  workspace = "${local.env}-${basename(get_terragrunt_dir())}"
+}
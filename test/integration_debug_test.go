@@ -16,12 +16,15 @@ import (
 	"github.com/gruntwork-io/terragrunt/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 const (
-	fixtureMultiIncludeDependency = "fixtures/multiinclude-dependency"
-	fixtureRenderJSON             = "fixtures/render-json"
-	fixtureRenderJSONRegression   = "fixtures/render-json-regression"
+	fixtureMultiIncludeDependency  = "fixtures/multiinclude-dependency"
+	fixtureRenderJSON              = "fixtures/render-json"
+	fixtureRenderJSONRegression    = "fixtures/render-json-regression"
+	fixtureRenderJSONBundle        = "fixtures/render-json-bundle"
+	fixtureValidateInputsRedundant = "fixtures/validate-inputs-redundant"
 )
 
 var (
@@ -129,6 +132,22 @@ func TestTerragruntValidateInputsWithCLIVarFile(t *testing.T) {
 	helpers.RunTerragruntValidateInputs(t, moduleDir, args, true)
 }
 
+func TestTerragruntValidateInputsWarnRedundantInputs(t *testing.T) {
+	t.Parallel()
+
+	helpers.CleanupTerraformFolder(t, fixtureValidateInputsRedundant)
+	tmpEnvPath := helpers.CopyEnvironment(t, fixtureValidateInputsRedundant)
+	rootPath := util.JoinPath(tmpEnvPath, fixtureValidateInputsRedundant)
+
+	_, stderr, err := helpers.RunTerragruntCommandWithOutput(t, "terragrunt validate-inputs --terragrunt-warn-redundant-inputs --terragrunt-non-interactive --terragrunt-working-dir "+rootPath)
+	require.NoError(t, err)
+
+	assert.Contains(t, stderr, "set to the variable default value and can be removed")
+	assert.Contains(t, stderr, "- region")
+	assert.Contains(t, stderr, "- tags")
+	assert.NotContains(t, stderr, "- instance_count")
+}
+
 func TestTerragruntValidateInputsWithStrictMode(t *testing.T) {
 	t.Parallel()
 
@@ -388,6 +407,130 @@ func TestRenderJSONConfigWithIncludesDependenciesAndLocals(t *testing.T) {
 	}
 }
 
+func TestRenderJSONConfigAll(t *testing.T) {
+	t.Parallel()
+
+	tmpEnvPath := helpers.CopyEnvironment(t, fixtureRenderJSONBundle)
+	workDir := filepath.Join(tmpEnvPath, fixtureRenderJSONBundle)
+	bundleOut := filepath.Join(tmpEnvPath, "bundle.json")
+
+	helpers.RunTerragrunt(t, fmt.Sprintf("terragrunt render-json --all --out %s --terragrunt-non-interactive --terragrunt-json-disable-dependent-modules --terragrunt-working-dir %s", bundleOut, workDir))
+
+	bundleBytes, err := os.ReadFile(bundleOut)
+	require.NoError(t, err)
+
+	var bundle map[string]struct {
+		Config map[string]interface{} `json:"config"`
+		Error  string                 `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(bundleBytes, &bundle))
+	require.Len(t, bundle, 3)
+
+	for _, name := range []string{"app", "db"} {
+		unit, ok := bundle[name]
+		require.True(t, ok, "bundle is missing unit %s", name)
+		assert.Empty(t, unit.Error)
+		assert.Equal(t, map[string]interface{}{"name": name}, unit.Config["inputs"])
+	}
+
+	broken, ok := bundle["broken"]
+	require.True(t, ok, "bundle is missing unit broken")
+	assert.Nil(t, broken.Config)
+	assert.Contains(t, broken.Error, "broken/terragrunt.hcl")
+}
+
+func TestRenderJSONConfigYAML(t *testing.T) {
+	t.Parallel()
+
+	tmpEnvPath := helpers.CopyEnvironment(t, fixtureRenderJSONBundle)
+	workDir := filepath.Join(tmpEnvPath, fixtureRenderJSONBundle)
+	appDir := filepath.Join(workDir, "app")
+
+	helpers.RunTerragrunt(t, "terragrunt render-json --terragrunt-non-interactive --terragrunt-json-disable-dependent-modules --terragrunt-working-dir "+appDir)
+	helpers.RunTerragrunt(t, "terragrunt render-json --format yaml --terragrunt-non-interactive --terragrunt-json-disable-dependent-modules --terragrunt-working-dir "+appDir)
+
+	jsonBytes, err := os.ReadFile(filepath.Join(appDir, "terragrunt_rendered.json"))
+	require.NoError(t, err)
+
+	// the default output file gets the .yaml extension
+	yamlBytes, err := os.ReadFile(filepath.Join(appDir, "terragrunt_rendered.yaml"))
+	require.NoError(t, err)
+
+	var jsonConfig, yamlConfig map[string]interface{}
+	require.NoError(t, json.Unmarshal(jsonBytes, &jsonConfig))
+	require.NoError(t, yaml.Unmarshal(yamlBytes, &yamlConfig))
+
+	assert.Equal(t, map[string]interface{}{"name": "app"}, yamlConfig["inputs"])
+	assert.Equal(t, jsonConfig, yamlConfig)
+
+	bundleOut := filepath.Join(tmpEnvPath, "bundle.yaml")
+
+	helpers.RunTerragrunt(t, fmt.Sprintf("terragrunt render-json --all --format yaml --out %s --terragrunt-non-interactive --terragrunt-json-disable-dependent-modules --terragrunt-working-dir %s", bundleOut, workDir))
+
+	bundleBytes, err := os.ReadFile(bundleOut)
+	require.NoError(t, err)
+
+	var bundle map[string]struct {
+		Config map[string]interface{} `yaml:"config"`
+		Error  string                 `yaml:"error"`
+	}
+	require.NoError(t, yaml.Unmarshal(bundleBytes, &bundle))
+	require.Len(t, bundle, 3)
+
+	assert.Equal(t, jsonConfig, bundle["app"].Config)
+	assert.Contains(t, bundle["broken"].Error, "broken/terragrunt.hcl")
+}
+
+func TestRenderJSONConfigNDJSON(t *testing.T) {
+	t.Parallel()
+
+	tmpEnvPath := helpers.CopyEnvironment(t, fixtureRenderJSONBundle)
+	workDir := filepath.Join(tmpEnvPath, fixtureRenderJSONBundle)
+
+	testCases := []struct {
+		name string
+		cmd  string
+	}{
+		{"run-all", "terragrunt run-all render-json --format ndjson --terragrunt-non-interactive --terragrunt-json-disable-dependent-modules --terragrunt-working-dir " + workDir},
+		{"all", "terragrunt render-json --all --format ndjson --terragrunt-non-interactive --terragrunt-json-disable-dependent-modules --terragrunt-working-dir " + workDir},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			stdout := bytes.Buffer{}
+			stderr := bytes.Buffer{}
+
+			// the broken unit fails to render with run-all, the other units are still printed
+			_ = helpers.RunTerragruntCommand(t, tc.cmd, &stdout, &stderr)
+
+			configs := make(map[string]map[string]interface{})
+
+			for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+				require.True(t, json.Valid([]byte(line)), "line is not valid json: %s", line)
+
+				var unit struct {
+					Path   string                 `json:"path"`
+					Config map[string]interface{} `json:"config"`
+				}
+				require.NoError(t, json.Unmarshal([]byte(line), &unit))
+				require.NotEmpty(t, unit.Path, "line has no path: %s", line)
+
+				if unit.Config != nil {
+					configs[unit.Path] = unit.Config
+				}
+			}
+
+			for _, name := range []string{"app", "db"} {
+				config, ok := configs[name]
+				require.True(t, ok, "output is missing unit %s", name)
+				assert.Equal(t, map[string]interface{}{"name": name}, config["inputs"])
+			}
+		})
+	}
+}
+
 func TestRenderJSONConfigRunAll(t *testing.T) {
 	t.Parallel()
 
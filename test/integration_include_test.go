@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -26,6 +27,7 @@ const (
 	includeChildFixturePath     = "child"
 	includeMultipleFixturePath  = "fixtures/include-multiple/"
 	includeRunAllFixturePath    = "fixtures/include-runall/"
+	includeRestrictToRepoPath   = "fixtures/restrict-includes-to-repo"
 )
 
 func TestTerragruntWorksWithIncludeLocals(t *testing.T) {
@@ -288,3 +290,48 @@ func validateIncludeRemoteStateReflection(t *testing.T, s3BucketName string, key
 		remoteStateOut,
 	)
 }
+
+func TestTerragruntRestrictIncludesToRepo(t *testing.T) {
+	t.Parallel()
+
+	tmpEnvPath, err := filepath.EvalSymlinks(helpers.CopyEnvironment(t, includeRestrictToRepoPath))
+	require.NoError(t, err)
+
+	rootPath := util.JoinPath(tmpEnvPath, includeRestrictToRepoPath)
+	repoPath := util.JoinPath(rootPath, "repo")
+
+	output, err := exec.Command("git", "init", repoPath).CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	tc := []struct {
+		unit        string
+		expectedErr bool
+	}{
+		{"inside", false},
+		{"outside", true},
+		{"relative", true},
+	}
+
+	for _, tt := range tc {
+		tt := tt
+
+		t.Run(tt.unit, func(t *testing.T) {
+			t.Parallel()
+
+			unitPath := util.JoinPath(repoPath, tt.unit)
+
+			// without the flag, the includes outside the repository are allowed
+			_, _, err := helpers.RunTerragruntCommandWithOutput(t, "terragrunt terragrunt-info --terragrunt-non-interactive --terragrunt-working-dir "+unitPath)
+			require.NoError(t, err)
+
+			_, _, err = helpers.RunTerragruntCommandWithOutput(t, "terragrunt terragrunt-info --terragrunt-non-interactive --terragrunt-restrict-includes-to-repo --terragrunt-working-dir "+unitPath)
+			if !tt.expectedErr {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), util.JoinPath(rootPath, "root.hcl")+", which is outside of its git repository "+repoPath)
+		})
+	}
+}
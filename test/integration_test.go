@@ -19,6 +19,7 @@ import (
 	terragruntinfo "github.com/gruntwork-io/terragrunt/cli/commands/terragrunt-info"
 	"github.com/gruntwork-io/terragrunt/codegen"
 	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/configstack"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/internal/view/diagnostic"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
@@ -40,6 +41,7 @@ const (
 	testFixtureBufferModuleOutput             = "fixtures/buffer-module-output"
 	testFixtureCodegenPath                    = "fixtures/codegen"
 	testFixtureCommandsThatNeedInput          = "fixtures/commands-that-need-input"
+	testFixtureConfigSearchUp                 = "fixtures/config-search-up"
 	testFixtureConfigSingleJSONPath           = "fixtures/config-files/single-json-config"
 	testFixtureConfigWithNonDefaultNames      = "fixtures/config-files/with-non-default-names"
 	testFixtureDependenciesOptimisation       = "fixtures/dependency-optimisation"
@@ -49,13 +51,18 @@ const (
 	testFixtureDisabledModule                 = "fixtures/disabled/"
 	testFixtureDisabledPath                   = "fixtures/disabled-path/"
 	testFixtureDisjoint                       = "fixtures/stack/disjoint"
+	testFixtureDotEnv                         = "fixtures/dotenv"
 	testFixtureDownload                       = "fixtures/download"
 	testFixtureEmptyState                     = "fixtures/empty-state/"
 	testFixtureEnvVarsBlockPath               = "fixtures/env-vars-block/"
 	testFixtureErrorPrint                     = "fixtures/error-print"
 	testFixtureExcludesFile                   = "fixtures/excludes-file"
+	testFixtureExecutionPlan                  = "fixtures/execution-plan"
 	testFixtureExternalDependence             = "fixtures/external-dependencies"
 	testFixtureExternalDependency             = "fixtures/external-dependency/"
+	testFixtureExternalDependenciesOutputs    = "fixtures/external-dependencies-outputs-only"
+	testFixtureMockOutputsCLI                 = "fixtures/mock-outputs-cli"
+	testFixtureSkipOutputs                    = "fixtures/skip-outputs"
 	testFixtureExtraArgsPath                  = "fixtures/extra-args/"
 	testFixtureFailedTerraform                = "fixtures/failure"
 	testFixtureFindParent                     = "fixtures/find-parent"
@@ -63,6 +70,7 @@ const (
 	testFixtureGetOutput                      = "fixtures/get-output"
 	testFixtureGetTerragruntSourceCli         = "fixtures/get-terragrunt-source-cli"
 	testFixtureGraphDependencies              = "fixtures/graph-dependencies"
+	testFixtureGlobalExtraArgs                = "fixtures/global-extra-args"
 	testFixtureHclfmtDiff                     = "fixtures/hclfmt-diff"
 	testFixtureHclfmtStdin                    = "fixtures/hclfmt-stdin"
 	testFixtureHclvalidate                    = "fixtures/hclvalidate"
@@ -85,7 +93,9 @@ const (
 	testFixtureOutDir                         = "fixtures/out-dir"
 	testFixtureOutputAll                      = "fixtures/output-all"
 	testFixtureOutputModuleGroups             = "fixtures/output-module-groups"
+	testFixtureOutputMode                     = "fixtures/output-mode"
 	testFixtureParallelRun                    = "fixtures/parallel-run"
+	testFixtureProviderConsistency            = "fixtures/provider-consistency"
 	testFixtureParallelStateInit              = "fixtures/parallel-state-init"
 	testFixtureParallelism                    = "fixtures/parallelism"
 	testFixturePath                           = "fixtures/terragrunt/"
@@ -99,10 +109,15 @@ const (
 	testFixtureSkip                           = "fixtures/skip/"
 	testFixtureSkipLegacyRoot                 = "fixtures/skip-legacy-root/"
 	testFixtureSkipDependencies               = "fixtures/skip-dependencies"
+	testFixtureSkipNoChanges                  = "fixtures/skip-no-changes"
 	testFixtureSourceMapSlashes               = "fixtures/source-map/slashes-in-ref"
 	testFixtureStack                          = "fixtures/stack/"
 	testFixtureStdout                         = "fixtures/download/stdout-test"
 	testFixtureTfTest                         = "fixtures/tftest/"
+	testFixtureTFWarnings                     = "fixtures/tf-warnings"
+	testFixtureUnitLogsDir                    = "fixtures/unit-logs-dir"
+	testFixtureWarnLocalState                 = "fixtures/warn-local-state"
+	testFixtureWorkspace                      = "fixtures/workspace"
 	textFixtureDisjointSymlinks               = "fixtures/stack/disjoint-symlinks"
 
 	terraformFolder = ".terraform"
@@ -361,6 +376,30 @@ func TestDisableLogging(t *testing.T) {
 	assert.Empty(t, stderr)
 }
 
+func TestQuietLogging(t *testing.T) {
+	t.Parallel()
+
+	helpers.CleanupTerraformFolder(t, testFixtureLogFormatter)
+	tmpEnvPath := helpers.CopyEnvironment(t, testFixtureLogFormatter)
+	rootPath := util.JoinPath(tmpEnvPath, testFixtureLogFormatter)
+
+	stdout, stderr, err := helpers.RunTerragruntCommandWithOutput(t, "terragrunt run-all init --terragrunt-quiet --terragrunt-non-interactive -no-color --terragrunt-no-color --terragrunt-working-dir "+rootPath)
+	require.NoError(t, err)
+
+	assert.Empty(t, stdout)
+	assert.NotContains(t, stderr, "INFO")
+	assert.NotContains(t, stderr, "The stack at")
+
+	tmpEnvPath = helpers.CopyEnvironment(t, testFixtureErrorPrint)
+	testPath := util.JoinPath(tmpEnvPath, testFixtureErrorPrint)
+
+	_, stderr, err = helpers.RunTerragruntCommandWithOutput(t, "terragrunt apply --terragrunt-quiet --terragrunt-non-interactive --terragrunt-no-color --terragrunt-working-dir "+testPath+" --terragrunt-tfpath "+testPath+"/custom-tf-script.sh")
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "Custom error from script")
+	assert.NotContains(t, stderr, "INFO")
+}
+
 func TestLogWithAbsPath(t *testing.T) {
 	t.Parallel()
 
@@ -509,6 +548,19 @@ func TestTerragruntExcludesFile(t *testing.T) {
 			"--terragrunt-excludes-file ./excludes-file-pass-as-flag",
 			[]string{`value = "a"`, `value = "c"`},
 		},
+		{
+			"--terragrunt-excludes-file ./excludes-file-glob",
+			[]string{`value = "d"`},
+		},
+		{
+			// a is excluded by the default excludes file, which is applied after the includes file
+			"--terragrunt-includes-file ./includes-file",
+			[]string{`value = "b"`},
+		},
+		{
+			"--terragrunt-includes-file ./includes-file --terragrunt-excludes-file ./excludes-file-pass-as-flag",
+			[]string{`value = "a"`},
+		},
 	}
 
 	for i, tt := range tc {
@@ -1124,6 +1176,31 @@ func TestInputsPassedThroughCorrectly(t *testing.T) {
 	validateInputs(t, outputs)
 }
 
+func TestRenderInputs(t *testing.T) {
+	t.Parallel()
+
+	tmpEnvPath := helpers.CopyEnvironment(t, testFixtureInputs)
+	helpers.CleanupTerraformFolder(t, tmpEnvPath)
+	rootPath := util.JoinPath(tmpEnvPath, testFixtureInputs)
+
+	// render the inputs of the unit given as argument, relative to the working directory
+	helpers.RunTerragrunt(t, fmt.Sprintf("terragrunt render-inputs --terragrunt-non-interactive --terragrunt-working-dir %s %s", filepath.Dir(rootPath), filepath.Base(rootPath)))
+
+	tfvarsBytes, err := os.ReadFile(util.JoinPath(rootPath, "terragrunt_rendered.tfvars.json"))
+	require.NoError(t, err)
+
+	var tfvars map[string]interface{}
+	require.NoError(t, json.Unmarshal(tfvarsBytes, &tfvars))
+
+	outputs := map[string]helpers.TerraformOutput{}
+	for name, value := range tfvars {
+		outputs[name] = helpers.TerraformOutput{Value: value}
+	}
+
+	validateInputs(t, outputs)
+	assert.Equal(t, "this var does not exist in module", tfvars["undefined_var"])
+}
+
 func TestTerragruntMissingDependenciesFail(t *testing.T) {
 	t.Parallel()
 
@@ -1179,6 +1256,26 @@ func TestTerragruntExcludeExternalDependencies(t *testing.T) {
 	assert.NotContains(t, applyAllStdoutString, "Hello World, "+excludedModule)
 }
 
+func TestTerragruntExternalDependenciesOutputsOnly(t *testing.T) {
+	t.Parallel()
+
+	helpers.CleanupTerraformFolder(t, testFixtureExternalDependenciesOutputs)
+	tmpEnvPath := helpers.CopyEnvironment(t, testFixtureExternalDependenciesOutputs)
+	rootPath := util.JoinPath(tmpEnvPath, testFixtureExternalDependenciesOutputs)
+	externalPath := util.JoinPath(rootPath, "external")
+	stackPath := util.JoinPath(rootPath, "stack")
+
+	helpers.RunTerragrunt(t, "terragrunt apply -auto-approve --terragrunt-non-interactive --terragrunt-working-dir "+externalPath)
+
+	stdout, stderr, err := helpers.RunTerragruntCommandWithOutput(t, "terragrunt run-all apply --terragrunt-non-interactive --terragrunt-external-dependencies-outputs-only --terragrunt-forward-tf-stdout --terragrunt-working-dir "+stackPath)
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, "app received real-external-output")
+	assert.NotContains(t, stdout, "mock-external-output")
+	// Only the unit inside the stack is applied, the external dependency is not executed.
+	assert.Equal(t, 1, strings.Count(stdout, "Apply complete!"), stderr)
+}
+
 func TestApplySkipTrue(t *testing.T) {
 	t.Parallel()
 
@@ -1292,6 +1389,33 @@ func TestDependencyOutput(t *testing.T) {
 	assert.Equal(t, 42, int(outputs["z"].Value.(float64)))
 }
 
+func TestDependencyOutputMerge(t *testing.T) {
+	t.Parallel()
+
+	helpers.CleanupTerraformFolder(t, testFixtureGetOutput)
+	tmpEnvPath := helpers.CopyEnvironment(t, testFixtureGetOutput)
+	rootPath := util.JoinPath(tmpEnvPath, testFixtureGetOutput, "integration")
+
+	helpers.RunTerragrunt(t, "terragrunt run-all apply --terragrunt-non-interactive --terragrunt-working-dir "+rootPath)
+
+	stdout := bytes.Buffer{}
+	stderr := bytes.Buffer{}
+
+	app3Path := util.JoinPath(rootPath, "app3")
+	require.NoError(
+		t,
+		helpers.RunTerragruntCommand(t, "terragrunt output --terragrunt-output-merge --terragrunt-non-interactive --terragrunt-working-dir "+app3Path, &stdout, &stderr),
+	)
+
+	outputs := map[string]map[string]any{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &outputs))
+	assert.Equal(t, map[string]map[string]any{
+		".":       {"z": float64(42)},
+		"../app1": {"x": float64(14)},
+		"../app2": {"y": float64(28)},
+	}, outputs)
+}
+
 func TestDependencyOutputErrorBeforeApply(t *testing.T) {
 	t.Parallel()
 
@@ -1308,6 +1432,11 @@ func TestDependencyOutputErrorBeforeApply(t *testing.T) {
 	// Verify that we fail because the dependency is not applied yet
 	assert.Contains(t, err.Error(), "has not been applied yet")
 
+	// Verify that the failure has its own exit code
+	exitCode, exitCodeErr := util.GetExitCode(err)
+	require.NoError(t, exitCodeErr)
+	assert.Equal(t, config.DependencyNotAppliedExitCode, exitCode)
+
 	helpers.LogBufferContentsLineByLine(t, showStdout, "show stdout")
 	helpers.LogBufferContentsLineByLine(t, showStderr, "show stderr")
 }
@@ -1332,6 +1461,26 @@ func TestDependencyOutputSkipOutputs(t *testing.T) {
 	helpers.LogBufferContentsLineByLine(t, showStderr, "show stderr")
 }
 
+// Test that --terragrunt-skip-outputs skips retrieving the outputs of all dependencies.
+func TestDependencyOutputSkipOutputsFlag(t *testing.T) {
+	t.Parallel()
+
+	helpers.CleanupTerraformFolder(t, testFixtureSkipOutputs)
+	tmpEnvPath := helpers.CopyEnvironment(t, testFixtureSkipOutputs)
+	appPath := util.JoinPath(tmpEnvPath, testFixtureSkipOutputs, "app")
+
+	// without the flag, the outputs of the dependency are read
+	_, stderr, err := helpers.RunTerragruntCommandWithOutput(t, "terragrunt validate --terragrunt-non-interactive --terragrunt-log-level debug --terragrunt-working-dir "+appPath)
+	require.NoError(t, err, stderr)
+
+	assert.Contains(t, stderr, "output -json")
+
+	_, stderr, err = helpers.RunTerragruntCommandWithOutput(t, "terragrunt validate --terragrunt-non-interactive --terragrunt-skip-outputs --terragrunt-log-level debug --terragrunt-working-dir "+appPath)
+	require.NoError(t, err, stderr)
+
+	assert.NotContains(t, stderr, "output -json")
+}
+
 func TestDependencyOutputSkipOutputsWithMockOutput(t *testing.T) {
 	t.Parallel()
 
@@ -1379,6 +1528,21 @@ func TestDependencyOutputSkipOutputsWithMockOutput(t *testing.T) {
 	assert.Equal(t, "The answer is 0", outputs["truth"].Value)
 }
 
+// Test that mock outputs passed with --terragrunt-mock-output take precedence over the mock_outputs set in the config.
+func TestDependencyMockOutputFromCLI(t *testing.T) {
+	t.Parallel()
+
+	helpers.CleanupTerraformFolder(t, testFixtureMockOutputsCLI)
+	tmpEnvPath := helpers.CopyEnvironment(t, testFixtureMockOutputsCLI)
+	appPath := util.JoinPath(tmpEnvPath, testFixtureMockOutputsCLI, "app")
+
+	stdout, stderr, err := helpers.RunTerragruntCommandWithOutput(t, "terragrunt plan --terragrunt-non-interactive --terragrunt-mock-output dep.name=cli-mock --terragrunt-working-dir "+appPath)
+	require.NoError(t, err, stderr)
+
+	assert.Contains(t, stdout, "cli-mock in config-region")
+	assert.NotContains(t, stdout, "config-mock")
+}
+
 // Test that when you have a mock_output on a dependency, the dependency will use the mock as the output instead
 // of erroring out.
 func TestDependencyMockOutput(t *testing.T) {
@@ -2546,6 +2710,39 @@ func TestTerragruntGenerateBlockOverwriteTerragruntFail(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestTerragruntGenerateBlockRequiredProviders(t *testing.T) {
+	t.Parallel()
+
+	tmpEnvPath := helpers.CopyEnvironment(t, testFixtureCodegenPath)
+	generateTestCase := util.JoinPath(tmpEnvPath, testFixtureCodegenPath, "generate-versions", "unit")
+	versionsFile := filepath.Join(generateTestCase, "versions.tf")
+
+	expected := "# " + codegen.TerragruntGeneratedSignature + `
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    null = {
+      source  = "hashicorp/null"
+      version = "3.2.2"
+    }
+  }
+}
+`
+
+	helpers.RunTerragrunt(t, "terragrunt apply -auto-approve --terragrunt-non-interactive --terragrunt-working-dir "+generateTestCase)
+
+	contents, err := os.ReadFile(versionsFile)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(contents))
+
+	// generating the file again produces the same contents
+	helpers.RunTerragrunt(t, "terragrunt apply -auto-approve --terragrunt-non-interactive --terragrunt-working-dir "+generateTestCase)
+
+	contents, err = os.ReadFile(versionsFile)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(contents))
+}
+
 func TestTerragruntGenerateBlockNestedInherit(t *testing.T) {
 	t.Parallel()
 
@@ -2710,6 +2907,22 @@ func TestTerragruntRemoteStateCodegenOverwrites(t *testing.T) {
 	assert.False(t, helpers.FileIsInFolder(t, "bar.tfstate", generateTestCase))
 }
 
+func TestTerragruntRemoteStateCodegenPartial(t *testing.T) {
+	t.Parallel()
+
+	generateTestCase := filepath.Join(testFixtureCodegenPath, "remote-state", "partial")
+
+	helpers.CleanupTerraformFolder(t, generateTestCase)
+	helpers.CleanupTerragruntFolder(t, generateTestCase)
+
+	// The backend configuration is completed by the -backend-config arg, as a CI pipeline would pass it.
+	helpers.RunTerragrunt(t, "terragrunt init -backend-config=path=ci.tfstate --terragrunt-non-interactive --terragrunt-working-dir "+generateTestCase)
+	helpers.RunTerragrunt(t, "terragrunt apply -auto-approve --terragrunt-non-interactive --terragrunt-working-dir "+generateTestCase)
+
+	assert.True(t, helpers.FileIsInFolder(t, "ci.tfstate", generateTestCase))
+	assert.False(t, helpers.FileIsInFolder(t, "foo.tfstate", generateTestCase))
+}
+
 func TestTerragruntRemoteStateCodegenErrorsIfExists(t *testing.T) {
 	t.Parallel()
 
@@ -3723,6 +3936,259 @@ func TestStorePlanFilesJsonRelativePath(t *testing.T) {
 	assert.Len(t, listJSON, 2)
 }
 
+func TestRunAllUnitLogsDir(t *testing.T) {
+	t.Parallel()
+
+	tmpEnvPath := helpers.CopyEnvironment(t, testFixtureUnitLogsDir)
+	helpers.CleanupTerraformFolder(t, tmpEnvPath)
+	testPath := util.JoinPath(tmpEnvPath, testFixtureUnitLogsDir)
+	logsDir := util.JoinPath(testPath, "logs")
+
+	_, _, err := helpers.RunTerragruntCommandWithOutput(t, fmt.Sprintf("terragrunt run-all apply --terragrunt-non-interactive --terragrunt-working-dir %s --terragrunt-unit-logs-dir logs", testPath))
+	require.NoError(t, err)
+
+	// verify that each unit has its own log file with the unit output
+	list, err := findFilesWithExtension(logsDir, ".log")
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+
+	for _, unit := range []string{"unit-a", "unit-b"} {
+		content, err := os.ReadFile(filepath.Join(logsDir, unit+".log"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), fmt.Sprintf("name = %q", unit))
+	}
+}
+
+func TestRunAllOutputModeGrouped(t *testing.T) {
+	t.Parallel()
+
+	tmpEnvPath := helpers.CopyEnvironment(t, testFixtureOutputMode)
+	helpers.CleanupTerraformFolder(t, tmpEnvPath)
+	testPath := util.JoinPath(tmpEnvPath, testFixtureOutputMode)
+
+	stdout, stderr, err := helpers.RunTerragruntCommandWithOutput(t, fmt.Sprintf("terragrunt run-all apply --terragrunt-non-interactive --terragrunt-working-dir %s --terragrunt-output-mode grouped", testPath))
+	require.NoError(t, err)
+
+	unitRe := regexp.MustCompile(`prefix=(unit-[a-z])`)
+
+	for _, output := range []string{stdout, stderr} {
+		var (
+			finished = map[string]bool{}
+			current  string
+		)
+
+		for _, line := range strings.Split(output, "\n") {
+			match := unitRe.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+
+			unit := match[1]
+			if unit == current {
+				continue
+			}
+
+			assert.False(t, finished[unit], "output of %s is interleaved with the output of another unit:\n%s", unit, output)
+
+			if current != "" {
+				finished[current] = true
+			}
+
+			current = unit
+		}
+	}
+}
+
+func TestRunAllOutputOrder(t *testing.T) {
+	t.Parallel()
+
+	tmpEnvPath := helpers.CopyEnvironment(t, testFixtureOutputMode)
+	helpers.CleanupTerraformFolder(t, tmpEnvPath)
+	testPath := util.JoinPath(tmpEnvPath, testFixtureOutputMode)
+
+	helpers.RunTerragrunt(t, fmt.Sprintf("terragrunt run-all apply --terragrunt-non-interactive --terragrunt-working-dir %s", testPath))
+
+	stdout, _, err := helpers.RunTerragruntCommandWithOutput(t, fmt.Sprintf("terragrunt run-all output --terragrunt-non-interactive --terragrunt-working-dir %s --terragrunt-output-order path", testPath))
+	require.NoError(t, err)
+
+	positions := make([]int, 0, 3)
+
+	for _, unit := range []string{"unit-a", "unit-b", "unit-c"} {
+		position := strings.Index(stdout, fmt.Sprintf("name = %q", unit))
+		require.GreaterOrEqual(t, position, 0, "output of %s not found:\n%s", unit, stdout)
+
+		positions = append(positions, position)
+	}
+
+	assert.IsIncreasing(t, positions, "the output of the units is not sorted by path:\n%s", stdout)
+}
+
+func TestGlobalExtraArgumentsAppliedToAllUnits(t *testing.T) {
+	t.Parallel()
+
+	tmpEnvPath := helpers.CopyEnvironment(t, testFixtureGlobalExtraArgs)
+	helpers.CleanupTerraformFolder(t, tmpEnvPath)
+	testPath := util.JoinPath(tmpEnvPath, testFixtureGlobalExtraArgs)
+
+	_, stderr, err := helpers.RunTerragruntCommandWithOutput(t, fmt.Sprintf("terragrunt run-all apply --terragrunt-non-interactive --terragrunt-log-level debug --terragrunt-working-dir %s", testPath))
+	require.NoError(t, err)
+
+	applyRe := regexp.MustCompile(`prefix=(unit-[a-z]) .*msg=Running command: \S+ apply (.*)`)
+
+	units := map[string]string{}
+
+	for _, line := range strings.Split(stderr, "\n") {
+		if match := applyRe.FindStringSubmatch(line); match != nil {
+			units[match[1]] = match[2]
+		}
+	}
+
+	assert.Len(t, units, 2)
+
+	for unit, args := range units {
+		assert.Contains(t, args, "-lock-timeout=5m", "apply command of %s", unit)
+	}
+}
+
+func TestTerragruntLoadDotEnv(t *testing.T) {
+	t.Parallel()
+
+	tmpEnvPath := helpers.CopyEnvironment(t, testFixtureDotEnv, ".env")
+	helpers.CleanupTerraformFolder(t, tmpEnvPath)
+	testPath := util.JoinPath(tmpEnvPath, testFixtureDotEnv)
+
+	helpers.RunTerragrunt(t, "terragrunt apply -auto-approve --terragrunt-non-interactive --terragrunt-load-dotenv --terragrunt-working-dir "+testPath)
+
+	stdout, _, err := helpers.RunTerragruntCommandWithOutput(t, "terragrunt output -no-color -json --terragrunt-non-interactive --terragrunt-load-dotenv --terragrunt-working-dir "+testPath)
+	require.NoError(t, err)
+
+	outputs := map[string]helpers.TerraformOutput{}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &outputs))
+
+	assert.Equal(t, "hello from .env", outputs["from_dotenv"].Value)
+	// the env_vars of extra_arguments take precedence over the .env file
+	assert.Equal(t, "env_vars", outputs["from_env_vars"].Value)
+	// the .env file takes precedence over inputs, like the process environment
+	assert.Equal(t, "dotenv", outputs["from_inputs"].Value)
+}
+
+func TestRunAllCheckProviderConsistency(t *testing.T) {
+	t.Parallel()
+
+	tmpEnvPath := helpers.CopyEnvironment(t, testFixtureProviderConsistency)
+	helpers.CleanupTerraformFolder(t, tmpEnvPath)
+	testPath := util.JoinPath(tmpEnvPath, testFixtureProviderConsistency)
+
+	_, stderr, err := helpers.RunTerragruntCommandWithOutput(t, fmt.Sprintf("terragrunt run-all plan --terragrunt-non-interactive --terragrunt-working-dir %s --terragrunt-check-provider-consistency", testPath))
+	require.Error(t, err)
+
+	var conflictsErr configstack.ProviderVersionConflictsError
+	require.ErrorAs(t, err, &conflictsErr)
+
+	assert.Equal(t, configstack.ProviderVersionConflictsError{
+		{
+			Provider: "hashicorp/null",
+			Units: map[string][]string{
+				"3.2.2": {"unit-a", "unit-c"},
+				"3.2.3": {"unit-b"},
+			},
+		},
+	}, conflictsErr)
+	assert.Contains(t, err.Error(), "- hashicorp/null: 3.2.2 (unit-a, unit-c), 3.2.3 (unit-b)")
+
+	// the units are not run if the providers are inconsistent
+	assert.NotContains(t, stderr, "Running command")
+}
+
+func TestRunAllSkipNoChanges(t *testing.T) {
+	t.Parallel()
+
+	tmpEnvPath := helpers.CopyEnvironment(t, testFixtureSkipNoChanges)
+	helpers.CleanupTerraformFolder(t, tmpEnvPath)
+	testPath := util.JoinPath(tmpEnvPath, testFixtureSkipNoChanges)
+
+	helpers.RunTerragrunt(t, "terragrunt run-all apply --terragrunt-non-interactive --terragrunt-working-dir "+testPath)
+
+	// change unit-a only, unit-b depends on it while unit-c is unrelated
+	mainTfPath := util.JoinPath(testPath, "unit-a", "main.tf")
+	mainTf, err := os.ReadFile(mainTfPath)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(mainTfPath, []byte(strings.Replace(string(mainTf), `default = "a"`, `default = "changed"`, 1)), 0644))
+
+	_, stderr, err := helpers.RunTerragruntCommandWithOutput(t, "terragrunt run-all apply --terragrunt-non-interactive --terragrunt-skip-no-changes --terragrunt-log-level debug --terragrunt-working-dir "+testPath)
+	require.NoError(t, err)
+
+	skipped := regexp.MustCompile(`Skipping module .*?(unit-[a-z]) as its plan has no changes`).FindAllStringSubmatch(stderr, -1)
+	require.Len(t, skipped, 1)
+	assert.Equal(t, "unit-c", skipped[0][1])
+
+	// unit-b has no changes itself, but it is applied because its dependency unit-a has changes
+	outputs := map[string]helpers.TerraformOutput{}
+	stdout, _, err := helpers.RunTerragruntCommandWithOutput(t, "terragrunt output -no-color -json --terragrunt-non-interactive --terragrunt-working-dir "+util.JoinPath(testPath, "unit-a"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(stdout), &outputs))
+	assert.Equal(t, "changed", outputs["value"].Value)
+
+	assert.Regexp(t, `prefix=unit-b .*Running command: \S+ apply`, stderr)
+	assert.NotRegexp(t, `prefix=unit-c .*Running command: \S+ apply`, stderr)
+}
+
+func TestRunAllExecutionPlan(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		args     string
+		expected configstack.ExecutionPlan
+	}{
+		{
+			name: "all units",
+			expected: configstack.ExecutionPlan{
+				Command: "apply",
+				Units: []configstack.ExecutionPlanUnit{
+					{Path: "other", Group: 1, Dependencies: []string{}, Reason: configstack.InclusionReasonDiscovered},
+					{Path: "vpc", Group: 1, Dependencies: []string{}, Reason: configstack.InclusionReasonDiscovered},
+					{Path: "db", Group: 2, Dependencies: []string{"vpc"}, Reason: configstack.InclusionReasonDiscovered},
+					{Path: "app", Group: 3, Dependencies: []string{"db", "vpc"}, Reason: configstack.InclusionReasonDiscovered},
+				},
+			},
+		},
+		{
+			name: "include dir",
+			args: "--terragrunt-include-dir app",
+			expected: configstack.ExecutionPlan{
+				Command: "apply",
+				Units: []configstack.ExecutionPlanUnit{
+					{Path: "vpc", Group: 1, Dependencies: []string{}, Reason: configstack.InclusionReasonDependency},
+					{Path: "db", Group: 2, Dependencies: []string{"vpc"}, Reason: configstack.InclusionReasonDependency},
+					{Path: "app", Group: 3, Dependencies: []string{"db", "vpc"}, Reason: configstack.InclusionReasonIncludeDir},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpEnvPath := helpers.CopyEnvironment(t, testFixtureExecutionPlan)
+			helpers.CleanupTerraformFolder(t, tmpEnvPath)
+			testPath := util.JoinPath(tmpEnvPath, testFixtureExecutionPlan)
+
+			stdout, stderr, err := helpers.RunTerragruntCommandWithOutput(t, fmt.Sprintf("terragrunt run-all apply --terragrunt-non-interactive --terragrunt-log-level debug --terragrunt-execution-plan-only --terragrunt-working-dir %s %s", testPath, tc.args))
+			require.NoError(t, err)
+
+			var plan configstack.ExecutionPlan
+
+			require.NoError(t, json.Unmarshal([]byte(stdout), &plan))
+			assert.Equal(t, tc.expected, plan)
+
+			// the units are not run when only the execution plan is requested
+			assert.NotContains(t, stderr, "Running command")
+		})
+	}
+}
+
 func TestPlanJsonFilesRunAll(t *testing.T) {
 	t.Parallel()
 
@@ -3965,3 +4431,90 @@ func TestTerragruntTerraformOutputJson(t *testing.T) {
 		assert.NotNil(t, output["time"])
 	}
 }
+
+func TestTerragruntConfigSearchUp(t *testing.T) {
+	t.Parallel()
+
+	tmpEnvPath := helpers.CopyEnvironment(t, testFixtureConfigSearchUp)
+	helpers.CleanupTerraformFolder(t, tmpEnvPath)
+	unitPath := util.JoinPath(tmpEnvPath, testFixtureConfigSearchUp, "unit")
+	nestedPath := util.JoinPath(unitPath, "modules", "app")
+
+	// without the flag, the nested directory is used as the working dir and no config is found
+	_, _, err := helpers.RunTerragruntCommandWithOutput(t, "terragrunt apply -auto-approve --terragrunt-non-interactive --terragrunt-working-dir "+nestedPath)
+	require.Error(t, err)
+
+	stdout, _, err := helpers.RunTerragruntCommandWithOutput(t, "terragrunt apply -auto-approve --terragrunt-non-interactive --terragrunt-config-search-up --terragrunt-working-dir "+nestedPath)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, `value = "unit"`)
+
+	// the directory of the nearest config is used as the working dir
+	assert.FileExists(t, util.JoinPath(unitPath, "terraform.tfstate"))
+	assert.NoFileExists(t, util.JoinPath(nestedPath, "terraform.tfstate"))
+}
+
+func TestRunAllWarnLocalState(t *testing.T) {
+	t.Parallel()
+
+	tmpEnvPath := helpers.CopyEnvironment(t, testFixtureWarnLocalState)
+	helpers.CleanupTerraformFolder(t, tmpEnvPath)
+	testPath := util.JoinPath(tmpEnvPath, testFixtureWarnLocalState)
+
+	_, stderr, err := helpers.RunTerragruntCommandWithOutput(t, "terragrunt run-all plan --terragrunt-non-interactive --terragrunt-warn-local-state --terragrunt-working-dir "+testPath)
+	require.NoError(t, err)
+
+	warnings := regexp.MustCompile(`Unit (\S+) has neither a remote_state block nor a generate block configuring a backend`).FindAllStringSubmatch(stderr, -1)
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "local-state", warnings[0][1])
+
+	// no warnings without the flag
+	_, stderr, err = helpers.RunTerragruntCommandWithOutput(t, "terragrunt run-all plan --terragrunt-non-interactive --terragrunt-working-dir "+testPath)
+	require.NoError(t, err)
+	assert.NotContains(t, stderr, "has neither a remote_state block")
+}
+
+func TestTerragruntTFWarningsAsErrors(t *testing.T) {
+	t.Parallel()
+
+	tmpEnvPath := helpers.CopyEnvironment(t, testFixtureTFWarnings)
+	helpers.CleanupTerraformFolder(t, tmpEnvPath)
+	testPath := util.JoinPath(tmpEnvPath, testFixtureTFWarnings)
+
+	_, _, err := helpers.RunTerragruntCommandWithOutput(t, "terragrunt plan -json --terragrunt-non-interactive --terragrunt-tf-warnings-as-errors --terragrunt-working-dir "+testPath)
+
+	var warningsErr terraform.TerraformWarningsError
+	require.ErrorAs(t, err, &warningsErr)
+	assert.Equal(t, []string{"Value for undeclared variable"}, warningsErr.Warnings)
+
+	// the warnings don't fail the unit without the flag
+	_, _, err = helpers.RunTerragruntCommandWithOutput(t, "terragrunt plan -json --terragrunt-non-interactive --terragrunt-working-dir "+testPath)
+	require.NoError(t, err)
+}
+
+func TestTerragruntUnknownCommandSuggestion(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := helpers.RunTerragruntCommandWithOutput(t, "terragrunt aply --terragrunt-non-interactive --terragrunt-working-dir "+testFixtureExtraArgsPath)
+	require.ErrorIs(t, err, expectedWrongCommandErr("aply"))
+	assert.Contains(t, err.Error(), `Did you mean "apply"?`)
+}
+
+func TestTerragruntWorkspace(t *testing.T) {
+	t.Parallel()
+
+	helpers.CleanupTerraformFolder(t, testFixtureWorkspace)
+	tmpEnvPath := helpers.CopyEnvironment(t, testFixtureWorkspace)
+	rootPath := util.JoinPath(tmpEnvPath, testFixtureWorkspace)
+
+	helpers.RunTerragrunt(t, "terragrunt run-all apply --terragrunt-non-interactive --terragrunt-working-dir "+util.JoinPath(rootPath, "units"))
+
+	for unit, expectedWorkspace := range map[string]string{"a": "ws-a", "b": "stage-b"} {
+		stdout, _, err := helpers.RunTerragruntCommandWithOutput(t, "terragrunt output -raw workspace --terragrunt-non-interactive --terragrunt-working-dir "+util.JoinPath(rootPath, "units", unit))
+		require.NoError(t, err)
+		assert.Equal(t, expectedWorkspace, strings.TrimSpace(stdout))
+	}
+
+	_, _, err := helpers.RunTerragruntCommandWithOutput(t, "terragrunt apply -auto-approve --terragrunt-non-interactive --terragrunt-working-dir "+util.JoinPath(rootPath, "no-create"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `The workspace "missing" does not exist`)
+}
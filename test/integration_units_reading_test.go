@@ -1,6 +1,7 @@
 package test_test
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 	"testing"
@@ -12,7 +13,8 @@ import (
 )
 
 const (
-	testFixtureUnitsReading = "fixtures/units-reading/"
+	testFixtureUnitsReading     = "fixtures/units-reading/"
+	testFixtureUnitsReadingGlob = "fixtures/units-reading-glob"
 )
 
 func TestUnitsReading(t *testing.T) {
@@ -160,3 +162,62 @@ func TestUnitsReading(t *testing.T) {
 		})
 	}
 }
+
+func TestUnitsReadingGlob(t *testing.T) {
+	t.Parallel()
+
+	cleanupTerraformFolder(t, testFixtureUnitsReadingGlob)
+
+	tc := []struct {
+		name          string
+		unitsReading  string
+		expectedUnits []string
+	}{
+		{
+			name:          "double_star",
+			unitsReading:  "config/**",
+			expectedUnits: []string{"live/reads-common", "live/reads-region"},
+		},
+		{
+			name:          "star",
+			unitsReading:  "config/*.yaml",
+			expectedUnits: []string{"live/reads-common"},
+		},
+		{
+			name:          "alternatives",
+			unitsReading:  "config/{region,other}/*",
+			expectedUnits: []string{"live/reads-region"},
+		},
+		{
+			name:          "no_match",
+			unitsReading:  "config/**/*.json",
+			expectedUnits: []string{},
+		},
+	}
+
+	includedLogEntryRegex := regexp.MustCompile(`=> Module ./([^ ]+) \(excluded: false`)
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpEnvPath := helpers.CopyEnvironment(t, testFixtureUnitsReadingGlob)
+			rootPath := util.JoinPath(tmpEnvPath, testFixtureUnitsReadingGlob)
+
+			// the glob is relative to the working directory, the units read the files through relative paths outside of their directories
+			cmd := fmt.Sprintf("terragrunt run-all plan --terragrunt-non-interactive --terragrunt-log-level trace --terragrunt-working-dir %s --terragrunt-queue-include-units-reading %s", rootPath, tt.unitsReading)
+
+			_, stderr, err := helpers.RunTerragruntCommandWithOutput(t, cmd)
+			require.NoError(t, err)
+
+			includedUnits := []string{}
+			for _, line := range strings.Split(stderr, "\n") {
+				if includedLogEntryRegex.MatchString(line) {
+					includedUnits = append(includedUnits, includedLogEntryRegex.FindStringSubmatch(line)[1])
+				}
+			}
+
+			assert.ElementsMatch(t, tt.expectedUnits, includedUnits)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package util
+
+import (
+	"bufio"
+	"regexp"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// DotEnvFileName is the name of the file with the environment variables of a unit.
+const DotEnvFileName = ".env"
+
+var dotEnvKeyRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// ParseDotEnv parses the contents of a `.env` file into a map of environment variables.
+//
+// Each line is a `KEY=VALUE` pair, optionally prefixed with `export`. Blank lines and lines starting with `#` are
+// ignored. Values in single quotes are taken literally, values in double quotes support the `\n`, `\t`, `\"` and `\\`
+// escape sequences, and unquoted values are trimmed and end at the first ` #` comment.
+func ParseDotEnv(contents string) (map[string]string, error) {
+	envs := map[string]string{}
+
+	scanner := bufio.NewScanner(strings.NewReader(contents))
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+
+		if !ok || !dotEnvKeyRegexp.MatchString(key) {
+			return nil, errors.Errorf("invalid line %d, expected KEY=VALUE: %s", lineNum, line)
+		}
+
+		value, err := parseDotEnvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, errors.Errorf("invalid value of %s on line %d: %w", key, lineNum, err)
+		}
+
+		envs[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.New(err)
+	}
+
+	return envs, nil
+}
+
+func parseDotEnvValue(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	switch quote := value[0]; quote {
+	case '\'', '"':
+		end := strings.LastIndexByte(value, quote)
+		if end == 0 {
+			return "", errors.Errorf("missing closing quote %c", quote)
+		}
+
+		if rest := strings.TrimSpace(value[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", errors.Errorf("unexpected characters after the closing quote: %s", rest)
+		}
+
+		value = value[1:end]
+
+		if quote == '"' {
+			value = strings.NewReplacer(`\n`, "\n", `\t`, "\t", `\"`, `"`, `\\`, `\`).Replace(value)
+		}
+
+		return value, nil
+	}
+
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+
+	return value, nil
+}
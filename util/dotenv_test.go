@@ -0,0 +1,63 @@
+package util_test
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDotEnv(t *testing.T) {
+	t.Parallel()
+
+	contents := `
+# comment
+PLAIN=value
+export EXPORTED=exported
+SPACED = spaced value   # trailing comment
+HASH=no#comment
+EMPTY=
+SINGLE='literal \n $VAR # not a comment'
+DOUBLE="line1\nline2 \"quoted\""
+TF_VAR_region=us-east-1
+`
+
+	envs, err := util.ParseDotEnv(contents)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"PLAIN":         "value",
+		"EXPORTED":      "exported",
+		"SPACED":        "spaced value",
+		"HASH":          "no#comment",
+		"EMPTY":         "",
+		"SINGLE":        `literal \n $VAR # not a comment`,
+		"DOUBLE":        "line1\nline2 \"quoted\"",
+		"TF_VAR_region": "us-east-1",
+	}, envs)
+}
+
+func TestParseDotEnvErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		contents string
+		expected string
+	}{
+		{"NO_VALUE", "invalid line 1, expected KEY=VALUE: NO_VALUE"},
+		{"A=1\n1INVALID=2", "invalid line 2, expected KEY=VALUE: 1INVALID=2"},
+		{`UNCLOSED="value`, "invalid value of UNCLOSED on line 1: missing closing quote \""},
+		{`TRAILING='value' rest`, "invalid value of TRAILING on line 1: unexpected characters after the closing quote: rest"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.contents, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := util.ParseDotEnv(tc.contents)
+			require.Error(t, err)
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
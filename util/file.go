@@ -7,8 +7,10 @@ import (
 	"encoding/gob"
 	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	urlhelper "github.com/hashicorp/go-getter/helper/url"
@@ -94,6 +96,23 @@ func CanonicalPath(path string, basePath string) (string, error) {
 	return CleanPath(absPath), nil
 }
 
+// MatchGlobPath returns true if the given path matches the glob pattern. Unlike zglob, a trailing `**` matches the files
+// of all the subdirectories, e.g. `config/**` matches `config/region/us.yaml`.
+func MatchGlobPath(pattern, path string) bool {
+	if strings.HasSuffix(pattern, "/**") {
+		pattern += "/*"
+	}
+
+	matched, err := zglob.Match(pattern, path)
+
+	return err == nil && matched
+}
+
+// IsGlobPattern returns true if the given path contains any of the glob meta characters `*`, `?`, `[` or `{`.
+func IsGlobPattern(path string) bool {
+	return strings.ContainsAny(path, "*?[{")
+}
+
 // GlobCanonicalPath returns the canonical versions of the given glob paths, relative to the given base path.
 func GlobCanonicalPath(basePath string, globPaths ...string) ([]string, error) {
 	if len(globPaths) == 0 {
@@ -637,12 +656,14 @@ func (err PathIsNotFile) Error() string {
 }
 
 // ListTfFiles returns a list of all TF files in the specified directory.
-func ListTfFiles(directoryPath string, walkWithSymlinks bool) ([]string, error) {
+func ListTfFiles(logger log.Logger, directoryPath string, walkWithSymlinks bool) ([]string, error) {
 	var tfFiles []string
 
 	walkFunc := filepath.Walk
 	if walkWithSymlinks {
-		walkFunc = WalkWithSymlinks
+		walkFunc = func(root string, fn filepath.WalkFunc) error {
+			return WalkWithSymlinks(logger, root, fn)
+		}
 	}
 
 	err := walkFunc(directoryPath, func(path string, info os.FileInfo, err error) error {
@@ -710,8 +731,10 @@ func GetTempDir() (string, error) {
 	return tempDir, nil
 }
 
-// GetExcludeDirsFromFile returns a list of directories from the given filename, where each directory path starts on a new line.
-func GetExcludeDirsFromFile(baseDir, filename string) ([]string, error) {
+// GetDirsFromFile returns a list of directories from the given filename, where each directory path starts on a new line.
+// Each entry may be a glob pattern, e.g. `apps/*/legacy`, which is expanded to all the matching directories. Blank lines
+// and lines starting with `#` are ignored.
+func GetDirsFromFile(baseDir, filename string) ([]string, error) {
 	filename, err := CanonicalPath(filename, baseDir)
 	if err != nil {
 		return nil, err
@@ -837,15 +860,20 @@ func Copy(ctx context.Context, dst io.Writer, src io.Reader) (int64, error) {
 
 // WalkWithSymlinks traverses a directory tree, following symbolic links and calling
 // the provided function for each file or directory encountered. It handles both regular
-// symlinks and circular symlinks without getting into infinite loops.
+// symlinks and circular symlinks without getting into infinite loops. A symlink to a
+// directory that is already on the path being walked, such as `a/loop -> ../a`, is skipped
+// entirely and logged at debug level, so that the directories it loops back to are not reported
+// again under its path.
 //
 //nolint:funlen
-func WalkWithSymlinks(root string, externalWalkFn filepath.WalkFunc) error {
+func WalkWithSymlinks(logger log.Logger, root string, externalWalkFn filepath.WalkFunc) error {
 	// pathPair keeps track of both the physical (real) path on disk
 	// and the logical path (how it appears in the walk)
 	type pathPair struct {
 		physical string
 		logical  string
+		// ancestors are the physical paths of the symlinks followed to reach this walk
+		ancestors []string
 	}
 
 	// visited tracks symlink paths to prevent circular references
@@ -877,6 +905,15 @@ func WalkWithSymlinks(root string, externalWalkFn filepath.WalkFunc) error {
 				return err
 			}
 
+			// Skip symlinks pointing back to a directory on the current path, they would only
+			// repeat the walk of that directory under a longer logical path
+			isSymlink := info.Mode()&os.ModeSymlink != 0
+			if isSymlink && realInfo.IsDir() && isAncestorPath(realPath, append(pair.ancestors, currentPath)) {
+				logger.Debugf("Skipping symlink %s pointing to %s, which is already on the walked path", logicalPath, realPath)
+
+				return nil
+			}
+
 			// Call the provided function only if we haven't seen this logical path before
 			if !visitedLogical[logicalPath] {
 				visitedLogical[logicalPath] = true
@@ -887,7 +924,7 @@ func WalkWithSymlinks(root string, externalWalkFn filepath.WalkFunc) error {
 			}
 
 			// If we encounter a symlink, resolve and follow it
-			if info.Mode()&os.ModeSymlink != 0 {
+			if isSymlink {
 				// Skip if we've seen this symlink->target combination before
 				// This prevents infinite loops with circular symlinks
 				if visited[realPath+":"+currentPath] {
@@ -899,8 +936,9 @@ func WalkWithSymlinks(root string, externalWalkFn filepath.WalkFunc) error {
 				// If the target is a directory, recursively walk it
 				if realInfo.IsDir() {
 					return walkFn(pathPair{
-						physical: realPath,
-						logical:  logicalPath,
+						physical:  realPath,
+						logical:   logicalPath,
+						ancestors: append(slices.Clone(pair.ancestors), currentPath),
 					})
 				}
 			}
@@ -921,6 +959,17 @@ func WalkWithSymlinks(root string, externalWalkFn filepath.WalkFunc) error {
 	})
 }
 
+// isAncestorPath returns true if dir is one of the given paths or one of their parent directories.
+func isAncestorPath(dir string, paths []string) bool {
+	for _, path := range paths {
+		if path == dir || strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func evalRealPathAndInfo(currentPath string) (string, os.FileInfo, error) {
 	realPath, err := filepath.EvalSymlinks(currentPath)
 	if err != nil {
@@ -935,3 +984,54 @@ func evalRealPathAndInfo(currentPath string) (string, os.FileInfo, error) {
 
 	return realPath, realInfo, nil
 }
+
+// BytesDiff uses GNU diff to display the differences between the contents of a file before and after an update, in the
+// unified format.
+func BytesDiff(logger log.Logger, b1, b2 []byte, path string) ([]byte, error) {
+	f1, err := os.CreateTemp("", "")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := f1.Close(); err != nil {
+			logger.Warnf("Failed to close file %s %v", f1.Name(), err)
+		}
+
+		if err := os.Remove(f1.Name()); err != nil {
+			logger.Warnf("Failed to remove file %s %v", f1.Name(), err)
+		}
+	}()
+
+	f2, err := os.CreateTemp("", "")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := f2.Close(); err != nil {
+			logger.Warnf("Failed to close file %s %v", f2.Name(), err)
+		}
+
+		if err := os.Remove(f2.Name()); err != nil {
+			logger.Warnf("Failed to remove file %s %v", f2.Name(), err)
+		}
+	}()
+
+	if _, err := f1.Write(b1); err != nil {
+		return nil, err
+	}
+
+	if _, err := f2.Write(b2); err != nil {
+		return nil, err
+	}
+
+	data, err := exec.Command("diff", "--label="+filepath.Join("old", path), "--label="+filepath.Join("new/", path), "-u", f1.Name(), f2.Name()).CombinedOutput()
+	if len(data) > 0 {
+		// diff exits with a non-zero status when the files don't match.
+		// Ignore that failure as long as we get output.
+		err = nil
+	}
+
+	return data, err
+}
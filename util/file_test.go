@@ -1,6 +1,7 @@
 package util_test
 
 import (
+	"bytes"
 	"errors"
 	"os"
 	"path"
@@ -128,6 +129,34 @@ func TestGlobCanonicalPath(t *testing.T) {
 	}
 }
 
+func TestGetDirsFromFile(t *testing.T) {
+	t.Parallel()
+
+	baseDir := t.TempDir()
+
+	for _, dir := range []string{"apps/x/legacy", "apps/y/legacy", "apps/y/current", "db", "vpc"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(baseDir, dir), os.ModePerm))
+	}
+
+	contents := "# legacy apps\napps/*/legacy\n\ndb\n#vpc\n"
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, ".terragrunt-excludes"), []byte(contents), 0644))
+
+	actual, err := util.GetDirsFromFile(baseDir, ".terragrunt-excludes")
+	require.NoError(t, err)
+
+	expected := []string{
+		filepath.ToSlash(filepath.Join(baseDir, "apps/x/legacy")),
+		filepath.ToSlash(filepath.Join(baseDir, "apps/y/legacy")),
+		filepath.ToSlash(filepath.Join(baseDir, "db")),
+	}
+	assert.ElementsMatch(t, expected, actual)
+
+	// a missing excludes file is not an error
+	actual, err = util.GetDirsFromFile(baseDir, "missing")
+	require.NoError(t, err)
+	assert.Empty(t, actual)
+}
+
 func TestPathContainsHiddenFileOrFolder(t *testing.T) {
 	t.Parallel()
 
@@ -425,7 +454,7 @@ func TestWalkWithSimpleSymlinks(t *testing.T) {
 	require.NoError(t, os.Symlink(filepath.Join(tempDir, "a"), filepath.Join(tempDir, "d", "a")))
 
 	var paths []string
-	err = util.WalkWithSymlinks(tempDir, func(path string, _ os.FileInfo, err error) error {
+	err = util.WalkWithSymlinks(log.New(), tempDir, func(path string, _ os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -500,7 +529,7 @@ func TestWalkWithCircularSymlinks(t *testing.T) {
 	require.NoError(t, os.Symlink(filepath.Join(tempDir, "a"), filepath.Join(tempDir, "d", "link-to-a")))
 
 	var paths []string
-	err = util.WalkWithSymlinks(tempDir, func(path string, _ os.FileInfo, err error) error {
+	err = util.WalkWithSymlinks(log.New(), tempDir, func(path string, _ os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -522,9 +551,6 @@ func TestWalkWithCircularSymlinks(t *testing.T) {
 		".",
 		"a",
 		"a/link-to-d",
-		"a/link-to-d/link-to-a",
-		"a/link-to-d/link-to-a/link-to-d",
-		"a/link-to-d/link-to-a/test.txt",
 		"a/test.txt",
 		"b",
 		"b/link-to-a",
@@ -536,6 +562,7 @@ func TestWalkWithCircularSymlinks(t *testing.T) {
 		"c/another-link-to-a/test.txt",
 		"d",
 		"d/link-to-a",
+		"d/link-to-a/test.txt",
 	}
 	sort.Strings(expectedPaths)
 
@@ -555,13 +582,55 @@ func TestWalkWithCircularSymlinks(t *testing.T) {
 	}
 }
 
+func TestWalkWithSymlinkLoops(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	tempDir, err := filepath.EvalSymlinks(tempDir)
+	require.NoError(t, err)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "a", "b"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "a", "b", "test.txt"), []byte("test"), 0644))
+
+	// Create symlinks to the directory itself and to its ancestors
+	require.NoError(t, os.Symlink("../a", filepath.Join(tempDir, "a", "self")))
+	require.NoError(t, os.Symlink("..", filepath.Join(tempDir, "a", "b", "parent")))
+	require.NoError(t, os.Symlink(tempDir, filepath.Join(tempDir, "a", "b", "root")))
+
+	var (
+		paths  []string
+		output bytes.Buffer
+	)
+
+	logger := log.New(log.WithOutput(&output), log.WithLevel(log.DebugLevel))
+
+	err = util.WalkWithSymlinks(logger, tempDir, func(path string, _ os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(tempDir, path)
+		require.NoError(t, err)
+
+		paths = append(paths, relPath)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{".", "a", "a/b", "a/b/test.txt"}, paths)
+	assert.Contains(t, output.String(), "Skipping symlink "+filepath.Join(tempDir, "a", "self"))
+	assert.Contains(t, output.String(), "Skipping symlink "+filepath.Join(tempDir, "a", "b", "parent"))
+	assert.Contains(t, output.String(), "Skipping symlink "+filepath.Join(tempDir, "a", "b", "root"))
+}
+
 func TestWalkWithSymlinksErrors(t *testing.T) {
 	t.Parallel()
 
 	tempDir := t.TempDir()
 
 	// Test with non-existent directory
-	require.Error(t, util.WalkWithSymlinks(filepath.Join(tempDir, "nonexistent"), func(_ string, _ os.FileInfo, err error) error {
+	require.Error(t, util.WalkWithSymlinks(log.New(), filepath.Join(tempDir, "nonexistent"), func(_ string, _ os.FileInfo, err error) error {
 		return err
 	}))
 
@@ -569,7 +638,38 @@ func TestWalkWithSymlinksErrors(t *testing.T) {
 	brokenLink := filepath.Join(tempDir, "broken")
 	require.NoError(t, os.Symlink(filepath.Join(tempDir, "nonexistent"), brokenLink))
 
-	require.Error(t, util.WalkWithSymlinks(tempDir, func(_ string, _ os.FileInfo, err error) error {
+	require.Error(t, util.WalkWithSymlinks(log.New(), tempDir, func(_ string, _ os.FileInfo, err error) error {
 		return err
 	}))
 }
+
+func TestMatchGlobPath(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{"/live/config/**", "/live/config/common.yaml", true},
+		{"/live/config/**", "/live/config/region/us.yaml", true},
+		{"/live/config/*.yaml", "/live/config/common.yaml", true},
+		{"/live/config/{region,env}/*", "/live/config/region/us.yaml", true},
+		{"/live/config/common.yaml", "/live/config/common.yaml", true},
+
+		{"/live/config/*.yaml", "/live/config/region/us.yaml", false},
+		{"/live/config/**", "/live/other/common.yaml", false},
+		{"/live/config/{env,account}/*", "/live/config/region/us.yaml", false},
+	}
+
+	for i, tt := range tc {
+		tt := tt
+
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			actual := util.MatchGlobPath(tt.pattern, tt.path)
+			assert.Equal(t, tt.expected, actual, "For pattern %s and path %s", tt.pattern, tt.path)
+		})
+	}
+}